@@ -30,7 +30,8 @@ func main() {
 			filepath.Join("testdata", "foo.md"),
 			filepath.Join("testdata", "bar.md"),
 		},
-		AsPublic: false,
+		AsPublic:     false,
+		AllowSecrets: false,
 	}
 
 	gistURL, err := obj.Create(argsCreate)
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newBackupCmd() *cobra.Command {
+	var (
+		opts   = gisty.NewBackupOptions()
+		output string
+		useZip bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "backup",
+		Short: "Back up all your gists to an archive",
+		Long: `Back up all the gists you own to a tar.gz archive, or zip with --zip.
+
+The archive holds a "manifest.json" with the descriptions and the visibility of
+the gists, and their files in "gists/<id>/files/". With --history, the git
+repositories are cloned to "gists/<id>/repo/", which requires git. The archive
+is written to the standard output unless --output is given.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(_ *cobra.Command, _ []string) error {
+			if useZip {
+				opts.Format = gisty.BackupZip
+			}
+
+			var writer io.Writer = a.streams.Stdout
+
+			if output != "" && output != "-" {
+				file, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("failed to create backup file: %w", err)
+				}
+
+				defer file.Close()
+
+				writer = file
+			}
+
+			obj := a.gisty()
+
+			manifest, err := obj.Backup(writer, opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to back up gists: %w", err))
+			}
+
+			_, err = fmt.Fprintf(a.streams.Stderr, "backed up %d gists\n", len(manifest.Gists))
+
+			return err //nolint:wrapcheck // error of writing to stderr
+		},
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Write the archive to the `file` instead of the standard output")
+	cmd.Flags().BoolVar(&useZip, "zip", false, "Write a zip archive instead of tar.gz")
+	cmd.Flags().BoolVar(&opts.WithComments, "comments", false, "Include the comments of the gists")
+	cmd.Flags().BoolVar(&opts.WithHistory, "history", false, "Include the git repositories with the full history")
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupCmd_and_RestoreCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	archive := filepath.Join(t.TempDir(), "backup.zip")
+
+	stdout, stderr, err := runApp(t, srv.NewGisty, "backup", "--zip", "--comments", "-o", archive)
+
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+	require.Equal(t, "backed up 1 gists\n", stderr)
+	require.FileExists(t, archive)
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	stdout, _, err = runApp(t, dstSrv.NewGisty, "restore", archive)
+
+	require.NoError(t, err)
+
+	gists := dstSrv.Gists()
+	require.Len(t, gists, 1)
+	require.Equal(t, testGistID+"\t"+gists[0].ID+"\n", stdout)
+	require.Equal(t, "test gist", gists[0].Description)
+
+	// Restore into the existing gist.
+	stdout, _, err = runApp(t, dstSrv.NewGisty, "restore", archive, "--map", testGistID+"="+gists[0].ID)
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\t"+gists[0].ID+"\n", stdout)
+	require.Len(t, dstSrv.Gists(), 1)
+}
+
+func TestRestoreCmd_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "restore", "backup.tar.gz", "--map", "invalid")
+
+	require.Equal(t, exitUsage, exitCode(err))
+
+	_, _, err = runApp(t, srv.NewGisty, "restore", filepath.Join(t.TempDir(), "missing.tar.gz"))
+
+	require.ErrorContains(t, err, "failed to open backup file")
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newCloneCmd() *cobra.Command {
+	var (
+		args     = gisty.NewCloneArgs("")
+		protocol string
+		out      output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "clone <gist> [<directory>] [-- <gitflags>...]",
+		Short: "Clone a gist locally",
+		Long: `Clone a gist into a local git repository and print its absolute path and the
+checked-out commit, separated by a tab.
+
+<gist> is a gist ID or URL, optionally with a revision as "<id>@<revision>".
+<directory> defaults to the gist ID and must not exist or be empty. The flags
+after "--" are passed to "git clone".`,
+		Example: `  gisty clone 5b10b34f87955dfc86d310cd623a61d1 --depth 1
+  gisty clone 5b10b34f87955dfc86d310cd623a61d1 --revision 6d2a3c6 --protocol ssh`,
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(cmd *cobra.Command, argv []string) error {
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				if dash == 0 || dash > 2 {
+					return &usageError{err: fmt.Errorf("expected <gist> [<directory>] before \"--\", got %q", argv[:dash])}
+				}
+
+				argv, args.GitFlags = argv[:dash], argv[dash:]
+			} else if len(argv) > 2 {
+				return &usageError{err: fmt.Errorf("accepts at most 2 arg(s), received %d", len(argv))}
+			}
+
+			switch gisty.CloneProtocol(protocol) {
+			case gisty.CloneProtocolDefault, gisty.CloneProtocolHTTPS, gisty.CloneProtocolSSH:
+				args.Protocol = gisty.CloneProtocol(protocol)
+			default:
+				return &usageError{err: fmt.Errorf("invalid --protocol %q. Valid values: https, ssh", protocol)}
+			}
+
+			args.Gist = argv[0]
+			if len(argv) == 2 {
+				args.Dir = argv[1]
+			}
+
+			obj := a.gisty()
+
+			result, err := obj.Clone(args)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to clone gist: %w", err))
+			}
+
+			err = a.relayStderr(obj, nil)
+			if err != nil {
+				return err
+			}
+
+			return out.print(a.streams.Stdout, result, func() tabular {
+				return tabular{header: []string{"PATH", "COMMIT"}, rows: [][]string{{result.Path, result.Commit}}}
+			}, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "%s\t%s\n", result.Path, result.Commit)
+
+				return err //nolint:wrapcheck // wrapped by the caller
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&args.Depth, "depth", 0, "Create a shallow clone with the given number of commits")
+	cmd.Flags().StringVarP(&args.Branch, "branch", "b", "", "Check out the given branch instead of the default one")
+	cmd.Flags().StringVar(&args.Revision, "revision", "", "Check out the given commit `sha` of the gist")
+	cmd.Flags().StringVar(&protocol, "protocol", "", "Protocol of the clone URL: https or ssh. Defaults to the gh setting")
+	out.addFlags(cmd)
+
+	return cmd
+}
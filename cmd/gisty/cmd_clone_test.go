@@ -0,0 +1,78 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloneCmd(t *testing.T) {
+	t.Parallel()
+
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+
+	var gotArgs []string
+
+	dir := filepath.Join(t.TempDir(), "dir")
+	newGisty := func() *gisty.Gisty {
+		obj := stubGH(func(args []string) (string, error) {
+			gotArgs = args
+
+			return "", os.MkdirAll(args[3], 0o700)
+		})()
+		obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+			_, err := fmt.Fprintln(cmd.Stdout, commit)
+
+			return err
+		}
+
+		return obj
+	}
+
+	stdout, _, err := runApp(t, newGisty, "clone", testGistID, dir, "--depth", "1", "--", "--quiet")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"gist", "clone", testGistID, dir, "--", "--depth=1", "--quiet"}, gotArgs)
+	require.Equal(t, dir+"\t"+commit+"\n", stdout)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "main.go"), nil, 0o600))
+
+	_, _, err = runApp(t, newGisty, "clone", testGistID, dir)
+
+	require.ErrorContains(t, err, "destination directory is not empty")
+}
+
+func TestCloneCmd_usage_error(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubGH(func([]string) (string, error) {
+		return "", nil
+	})
+
+	for _, args := range [][]string{
+		{"clone", "a", "b", "c"},
+		{"clone", "a", "b", "c", "--", "--depth=1"},
+		{"clone", "a", "--protocol", "ftp"},
+	} {
+		_, _, err := runApp(t, newGisty, args...)
+
+		require.Equal(t, exitUsage, exitCode(err), "args: %q", args)
+	}
+}
+
+func TestCloneCmd_error(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubGH(func([]string) (string, error) {
+		return "", errForcedWrite
+	})
+
+	_, _, err := runApp(t, newGisty, "clone", testGistID)
+
+	require.ErrorContains(t, err, "failed to clone gist")
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newCommentsCmd() *cobra.Command {
+	var (
+		fromCache bool
+		out       output
+	)
+
+	maxComment := gisty.MaxCommentDefault
+
+	cmd := &cobra.Command{
+		Use:   "comments <gist>",
+		Short: "Print the comments of a gist",
+		Long: `Print the latest comments of a gist.
+
+If the gists are mirrored by "gisty mirror", the mirror is read when GitHub
+cannot be reached. With --cache, the mirror is read without requesting GitHub.`,
+		Args:              usageArgs(cobra.ExactArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(_ *cobra.Command, args []string) error {
+			obj := a.gisty()
+			obj.MaxComment = maxComment
+
+			closeMirror, err := a.useMirror(obj, fromCache)
+			if err != nil {
+				return err
+			}
+
+			defer closeMirror()
+
+			comments, err := obj.Comments(args[0])
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to get comments: %w", err))
+			}
+
+			if comments == nil {
+				comments = []gisty.Comment{} // print an empty list instead of null
+			}
+
+			return out.print(a.streams.Stdout, comments, func() tabular {
+				return commentsTable(comments)
+			}, func(w io.Writer) error {
+				return printComments(w, comments)
+			})
+		},
+	}
+
+	cmd.Flags().IntVar(&maxComment, "max", gisty.MaxCommentDefault, "Maximum number of the latest comments to fetch")
+	cmd.Flags().BoolVar(&fromCache, "cache", false, `Read the comments from the mirror made by "gisty mirror"`)
+	out.addFlags(cmd)
+
+	return cmd
+}
+
+// printComments prints the comments in a human-readable form.
+func printComments(w io.Writer, comments []gisty.Comment) error {
+	var out strings.Builder
+
+	for index, comment := range comments {
+		if index > 0 {
+			out.WriteString("\n")
+		}
+
+		fmt.Fprintf(&out, "@%s (%s)\n%s\n", comment.Author.Login, comment.CreatedAt,
+			strings.TrimSpace(comment.BodyText))
+	}
+
+	_, err := io.WriteString(w, out.String())
+
+	return err //nolint:wrapcheck // wrapped by the caller
+}
+
+func commentsTable(comments []gisty.Comment) tabular {
+	rows := make([][]string, 0, len(comments))
+
+	for _, comment := range comments {
+		rows = append(rows, []string{comment.Author.Login, comment.CreatedAt, strings.TrimSpace(comment.BodyText)})
+	}
+
+	return tabular{
+		header: []string{"AUTHOR", "CREATED", "BODY"},
+		rows:   rows,
+	}
+}
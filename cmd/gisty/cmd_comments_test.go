@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommentsCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "comments", testGistID, "--max", "10")
+
+	require.NoError(t, err)
+	require.Equal(t, "@alice (2026-06-02T00:00:00Z)\nnice gist\n", stdout)
+}
+
+func TestCommentsCmd_invalid_id(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "comments", "<>")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newCreateCmd() *cobra.Command {
+	args := gisty.CreateArgs{
+		Description:  "",
+		FilePaths:    nil,
+		AsPublic:     false,
+		AllowSecrets: false,
+	}
+
+	var scan secretScan
+
+	cmd := &cobra.Command{
+		Use:   "create <file>...",
+		Short: "Create a new gist",
+		Long: `Create a new gist with the given files and print its URL.
+
+The gist is secret unless --public is given. The files are scanned for secrets
+such as API keys, tokens and private keys, and the gist is not created if any
+are found, unless --allow-secrets is given.`,
+		Args: usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(_ *cobra.Command, files []string) error {
+			args.FilePaths = files
+
+			args.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			gistURL, err := obj.Create(args)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to create gist: %w", err))
+			}
+
+			_, err = fmt.Fprintln(a.streams.Stdout, gistURL.String())
+
+			return wrapPrintErr(err)
+		},
+	}
+
+	cmd.Flags().StringVarP(&args.Description, "desc", "d", "", "Description of the gist")
+	cmd.Flags().BoolVarP(&args.AsPublic, "public", "p", false, "Create a public gist")
+	scan.addFlags(cmd)
+
+	return cmd
+}
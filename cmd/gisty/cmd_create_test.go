@@ -0,0 +1,76 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	pathFile := filepath.Join(t.TempDir(), "new.txt")
+	require.NoError(t, os.WriteFile(pathFile, []byte("new file"), 0o600))
+
+	stdout, _, err := runApp(t, srv.NewGisty, "create", "--desc", "created", "--public", pathFile)
+	require.NoError(t, err)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 2)
+
+	for _, gist := range gists {
+		if gist.ID == testGistID {
+			continue
+		}
+
+		require.Equal(t, "https://gist.github.com/"+gist.ID+"\n", stdout)
+		require.Equal(t, "created", gist.Description)
+		require.True(t, gist.Public)
+		require.Equal(t, map[string]string{"new.txt": "new file"}, gist.Files)
+	}
+}
+
+func TestCreateCmd_error(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "create", filepath.Join(t.TempDir(), "missing.txt"))
+
+	require.ErrorContains(t, err, "failed to create gist")
+	require.Equal(t, exitError, exitCode(err))
+}
+
+func TestCreateCmd_secrets(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	pathFile := filepath.Join(t.TempDir(), "deploy.sh")
+	require.NoError(t, os.WriteFile(pathFile, []byte("#!/bin/sh\ncurl -H 'X-Internal: corp-1234' ...\n"), 0o600))
+
+	_, _, err := runApp(t, srv.NewGisty, "create", pathFile, "--secret-rule", `internal-header=X-Internal: corp-\d+`)
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, pathFile+":2: internal-header")
+	require.Len(t, srv.Gists(), 1, "gist should not be created")
+
+	_, _, err = runApp(t, srv.NewGisty, "create", pathFile, "--secret-rule", `internal-header=X-Internal: corp-\d+`,
+		"--allow-secrets")
+
+	require.NoError(t, err)
+	require.Len(t, srv.Gists(), 2)
+
+	_, _, err = runApp(t, srv.NewGisty, "create", pathFile, "--secret-rule", "no-pattern")
+
+	require.Equal(t, exitUsage, exitCode(err))
+
+	_, _, err = runApp(t, srv.NewGisty, "create", pathFile, "--secret-rule", "broken=(")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newDeleteCmd() *cobra.Command {
+	opts := gisty.NewBatchOptions()
+
+	cmd := &cobra.Command{
+		Use:   "delete <gist>...",
+		Short: "Delete gists",
+		Long: `Delete the given gists right away, without any confirmation.
+
+<gist> is a gist ID or URL. Multiple gists are deleted concurrently. The
+failures are reported after all the gists are processed.`,
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(true),
+		RunE: func(cmd *cobra.Command, gists []string) error {
+			errs := a.gisty().DeleteMany(cmd.Context(), gists, opts)
+
+			for index, err := range errs {
+				if err != nil {
+					errs[index] = fmt.Errorf("failed to delete gist %s: %w", gists[index], err)
+
+					continue
+				}
+
+				_, err = fmt.Fprintln(a.streams.Stderr, "Deleted gist", gists[index])
+				if err != nil {
+					return wrapPrintErr(err)
+				}
+			}
+
+			return errors.Join(errs...)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", gisty.ConcurrencyDefault, "Number of gists deleted at the same time")
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeleteCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, stderr, err := runApp(t, srv.NewGisty, "delete", testGistID)
+
+	require.NoError(t, err)
+	require.Contains(t, stderr, "Deleted gist "+testGistID)
+	require.Empty(t, srv.Gists())
+
+	_, _, err = runApp(t, srv.NewGisty, "delete", testGistID)
+
+	require.ErrorContains(t, err, "failed to delete gist")
+}
+
+func TestDeleteCmd_many(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	other := srv.Gists()[0]
+	other.ID = ""
+	otherID := srv.AddGist(other)
+
+	_, stderr, err := runApp(t, srv.NewGisty, "delete", testGistID, "0123456789abcdef", otherID, "--concurrency", "2")
+
+	require.ErrorContains(t, err, "failed to delete gist 0123456789abcdef")
+	require.NotContains(t, err.Error(), testGistID, "only the failures should be reported")
+	require.Contains(t, stderr, "Deleted gist "+testGistID)
+	require.Contains(t, stderr, "Deleted gist "+otherID)
+	require.Empty(t, srv.Gists())
+}
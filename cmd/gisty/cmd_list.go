@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+const limitDefault = 10
+
+func (a *app) newListCmd() *cobra.Command {
+	args := gisty.ListArgs{
+		Limit:      limitDefault,
+		OnlyPublic: false,
+		OnlySecret: false,
+	}
+
+	var out output
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List your gists",
+		Long: `List your gists.
+
+By default, each line is tab-separated and contains the gist ID, description,
+number of files, visibility and the last update time. Use --format, --template
+or --jq to change the output.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			obj := a.gisty()
+
+			items, err := obj.List(args)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to list gists: %w", err))
+			}
+
+			if items == nil {
+				items = []gisty.GistInfo{} // print an empty list instead of null
+			}
+
+			table := func() tabular { return listTable(items) }
+
+			return out.print(a.streams.Stdout, items, table, func(w io.Writer) error {
+				return printTable(w, table(), false)
+			})
+		},
+	}
+
+	out.addFlags(cmd)
+
+	cmd.Flags().IntVarP(&args.Limit, "limit", "L", limitDefault, "Maximum number of gists to fetch")
+	cmd.Flags().BoolVar(&args.OnlyPublic, "public", false, "Show only public gists")
+	cmd.Flags().BoolVar(&args.OnlySecret, "secret", false, "Show only secret gists. Takes precedence over --public")
+
+	return cmd
+}
+
+func listTable(items []gisty.GistInfo) tabular {
+	rows := make([][]string, 0, len(items))
+
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.GistID, item.Description, strconv.Itoa(item.Files),
+			visibility(item.IsPublic), item.UpdatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return tabular{
+		header: []string{"ID", "DESCRIPTION", "FILES", "VISIBILITY", "UPDATED"},
+		rows:   rows,
+	}
+}
+
+func visibility(isPublic bool) string {
+	if isPublic {
+		return "public"
+	}
+
+	return "secret"
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestListCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, commandList, "--limit", "5", "--public")
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\ttest gist\t2\tpublic\t2026-06-01T00:00:00Z\n", stdout)
+}
+
+func TestListCmd_secret_only(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, commandList, "--public", "--secret")
+
+	require.NoError(t, err)
+	require.Empty(t, stdout, "--secret should take precedence over --public")
+}
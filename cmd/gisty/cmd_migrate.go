@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newMigrateCmd() *cobra.Command {
+	var (
+		opts               = gisty.NewMigrateOptions()
+		fromHost, toHost   string
+		fromToken, toToken string
+		scan               secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "migrate [<gist>...]",
+		Short: "Copy your gists to another host or account",
+		Long: `Copy the gists you own to another host or account, with the same files,
+description and visibility. If no <gist> is given, all of them are copied.
+
+The hosts default to the default host of gh. The token of each side is taken
+from the environment or the gh config of its host, or from the environment
+variable named by --from-token-env and --to-token-env. E.g. to copy to another
+account on the same host.
+
+Each line of the output is the ID of the source gist and the ID of the copy.
+With --report, the ID mapping is written to the JSON file after each gist and
+running the same command again resumes an interrupted migration. The report
+of other hosts is rejected.
+
+The files are scanned for secrets such as API keys, tokens and private keys,
+and the migration stops before the first gist with any, unless --allow-secrets
+is given.`,
+		ValidArgsFunction: a.completeGistIDs(true),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			source, err := a.migrateEnd(fromHost, fromToken)
+			if err != nil {
+				return err
+			}
+
+			target, err := a.migrateEnd(toHost, toToken)
+			if err != nil {
+				return err
+			}
+
+			err = scan.apply(target)
+			if err != nil {
+				return err
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			opts.Only = args
+			opts.OnMigrate = func(entry gisty.MigrateEntry) {
+				if entry.Done() {
+					fmt.Fprintf(a.streams.Stdout, "%s\t%s\n", entry.SourceID, entry.TargetID) //nolint:errcheck // best effort progress
+				}
+			}
+
+			ctx := cmd.Context()
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			_, err = source.Migrate(ctx, target, opts)
+			if err != nil {
+				return fmt.Errorf("failed to migrate gists: %w", err)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&fromHost, "from-host", "", "The `host` to copy the gists from")
+	cmd.Flags().StringVar(&toHost, "to-host", "", "The `host` to copy the gists to")
+	cmd.Flags().StringVar(&fromToken, "from-token-env", "", "The environment `variable` with the token of the source account")
+	cmd.Flags().StringVar(&toToken, "to-token-env", "", "The environment `variable` with the token of the target account")
+	cmd.Flags().StringVar(&opts.ReportFile, "report", "", "Write the ID mapping to the JSON `file` and resume from it")
+	cmd.Flags().BoolVar(&opts.WithComments, "comments", false, "Post the comments as quoted text")
+	scan.addFlags(cmd)
+
+	return cmd
+}
+
+// migrateEnd returns a Gisty for a side of the migration.
+func (a *app) migrateEnd(host, tokenEnv string) (*gisty.Gisty, error) {
+	obj := a.gisty()
+	obj.Host = host
+
+	if tokenEnv != "" {
+		token := os.Getenv(tokenEnv)
+		if token == "" {
+			return nil, &usageError{err: fmt.Errorf("environment variable %s is not set", tokenEnv)}
+		}
+
+		obj.SetToken(token)
+	}
+
+	return obj, nil
+}
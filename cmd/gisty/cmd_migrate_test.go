@@ -0,0 +1,47 @@
+package main
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	report := filepath.Join(t.TempDir(), "report.json")
+
+	stdout, _, err := runApp(t, srv.NewGisty, "migrate", "--to-host", "ghe.example.com", "--report", report)
+
+	require.NoError(t, err)
+	require.FileExists(t, report)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 2)
+
+	copied := gists[0].ID
+	if copied == testGistID {
+		copied = gists[1].ID
+	}
+
+	require.Equal(t, testGistID+"\t"+copied+"\n", stdout)
+
+	// Resuming the finished migration copies nothing.
+	stdout, _, err = runApp(t, srv.NewGisty, "migrate", testGistID, "--to-host", "ghe.example.com", "--report", report)
+
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+	require.Len(t, srv.Gists(), 2)
+}
+
+func TestMigrateCmd_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "migrate", "--to-token-env", "GISTY_TEST_UNSET_TOKEN")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
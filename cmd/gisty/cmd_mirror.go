@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/store"
+	"github.com/spf13/cobra"
+)
+
+// mirrorName is the name of the mirror database in the cache directory.
+const mirrorName = "mirror.db"
+
+var errNoCacheDir = errors.New("no cache directory for the mirror")
+
+func (a *app) newMirrorCmd() *cobra.Command {
+	opts := gisty.NewMirrorOptions()
+
+	cmd := &cobra.Command{
+		Use:   "mirror",
+		Short: "Copy your gists to the local mirror for offline reads",
+		Long: `Copy your gists, with their files and comments, to a local database in the
+cache directory.
+
+Only the gists updated since the previous mirror are downloaded again, and the
+deleted gists are removed. Once mirrored, "gisty read" and "gisty comments"
+fall back to the mirror when GitHub cannot be reached, and with --cache they
+always read from it.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			mirror, err := a.openMirror()
+			if err != nil {
+				return err
+			}
+
+			defer mirror.Close()
+
+			obj := a.gisty()
+			obj.Store = mirror
+
+			result, err := obj.Mirror(opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to mirror gists: %w", err))
+			}
+
+			_, err = fmt.Fprintf(a.streams.Stdout, "%d updated, %d deleted, %d unchanged\n",
+				len(result.Updated), len(result.Deleted), result.Unchanged)
+
+			return wrapPrintErr(err)
+		},
+	}
+
+	cmd.Flags().IntVarP(&opts.ListLimit, "limit", "L", gisty.SearchListLimitDefault,
+		"Maximum number of recent gists to mirror")
+
+	return cmd
+}
+
+// openMirror opens the mirror database in the cache directory.
+func (a *app) openMirror() (*store.Store, error) {
+	if a.cacheDir == "" {
+		return nil, errNoCacheDir
+	}
+
+	mirror, err := store.Open(filepath.Join(a.cacheDir, mirrorName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open the mirror: %w", err)
+	}
+
+	return mirror, nil
+}
+
+// useMirror sets the mirror as the store of obj if it exists, so that obj
+// falls back to it when GitHub cannot be reached. With fromCache, the mirror is
+// required and read without requesting GitHub. The returned function closes the
+// mirror.
+func (a *app) useMirror(obj *gisty.Gisty, fromCache bool) (func(), error) {
+	if !fromCache && (a.cacheDir == "" || !fileExists(filepath.Join(a.cacheDir, mirrorName))) {
+		return func() {}, nil
+	}
+
+	mirror, err := a.openMirror()
+	if err != nil {
+		if fromCache {
+			return nil, err
+		}
+
+		return func() {}, nil // the mirror is optional without fromCache
+	}
+
+	obj.Store = mirror
+	obj.ReadFromCache = fromCache
+
+	return func() { mirror.Close() }, nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMirrorCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	stdout := new(bytes.Buffer)
+	newApp := func() *app {
+		stdout.Reset()
+
+		return &app{
+			newGisty:   srv.NewGisty,
+			streams:    ghcmd.Streams{Stdin: bytes.NewBuffer(nil), Stdout: stdout, Stderr: new(bytes.Buffer)},
+			setErrPos:  nil,
+			cacheDir:   t.TempDir(),
+			policyFile: "",
+			policies:   nil,
+			trustFile:  "",
+			debug:      false,
+		}
+	}
+
+	mirrorApp := newApp()
+	cmd := mirrorApp.newRootCmd()
+	cmd.SetArgs([]string{"mirror"})
+
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "1 updated, 0 deleted, 0 unchanged\n", stdout.String())
+
+	// Read from the mirror only.
+	readApp := newApp()
+	readApp.cacheDir = mirrorApp.cacheDir
+
+	srv.Close()
+
+	cmd = readApp.newRootCmd()
+	cmd.SetArgs([]string{"read", "--cache", "--file", "main.go", testGistID})
+
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "package main\n", stdout.String())
+
+	stdout.Reset()
+
+	cmd = readApp.newRootCmd()
+	cmd.SetArgs([]string{"comments", "--cache", testGistID})
+
+	require.NoError(t, cmd.Execute())
+	require.Equal(t, "@alice (2026-06-02T00:00:00Z)\nnice gist\n", stdout.String())
+}
+
+func TestMirrorCmd_no_cache_dir(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	cmd := (&app{
+		newGisty:   srv.NewGisty,
+		streams:    ghcmd.Streams{Stdin: bytes.NewBuffer(nil), Stdout: new(bytes.Buffer), Stderr: new(bytes.Buffer)},
+		setErrPos:  nil,
+		cacheDir:   "",
+		policyFile: "",
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
+	}).newRootCmd()
+	cmd.SetArgs([]string{"mirror"})
+
+	require.ErrorIs(t, cmd.Execute(), errNoCacheDir)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newPushCmd() *cobra.Command {
+	var (
+		opts = gisty.NewPushOptions()
+		out  output
+		scan secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "push [<directory>]",
+		Short: "Commit and push the local changes of a cloned gist",
+		Long: `Commit the pending changes of a cloned gist, including the new files, and push
+them to its remote. Print the pushed branch and the local commit, separated by
+a tab, or "nothing to push".
+
+<directory> is the path to the cloned gist. Defaults to the current directory.
+The push is rejected if the gist has changed on the remote since it was
+cloned or updated, unless --rebase is given.
+
+Nothing is committed if the files to push seem to contain secrets such as API
+keys, tokens and private keys, unless --allow-secrets is given.`,
+		Example: `  gisty push -m "Fix typo"
+  gisty push ./5b10b34f87955dfc86d310cd623a61d1 --rebase`,
+		Args: usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(_ *cobra.Command, dirs []string) error {
+			dir := "."
+			if len(dirs) == 1 {
+				dir = dirs[0]
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			result, err := obj.Push(dir, opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to push gist: %w", err))
+			}
+
+			return out.print(a.streams.Stdout, result, func() tabular {
+				return tabular{
+					header: []string{"BRANCH", "COMMIT", "COMMITTED", "PUSHED"},
+					rows: [][]string{{
+						result.Remote + "/" + result.Branch, result.Commit,
+						strconv.FormatBool(result.Committed), strconv.FormatBool(result.Pushed),
+					}},
+				}
+			}, func(w io.Writer) error {
+				var err error
+
+				if result.Pushed {
+					_, err = fmt.Fprintf(w, "%s/%s\t%s\n", result.Remote, result.Branch, result.Commit)
+				} else {
+					_, err = fmt.Fprintln(w, "nothing to push")
+				}
+
+				return err //nolint:wrapcheck // wrapped by the caller
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&opts.Message, "message", "m", "", "Commit message of the pending changes")
+	cmd.Flags().BoolVar(&opts.Rebase, "rebase", false, "Rebase the local commits onto the remote changes before pushing")
+	scan.addFlags(cmd)
+	out.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+// stubPushGit returns a newGisty function whose git commands are answered by
+// handle with the arguments after the auth args. The commands with a standard
+// error fail after printing both outputs.
+func stubPushGit(dir string, handle func(args string) (string, string)) func() *gisty.Gisty {
+	return func() *gisty.Gisty {
+		obj := gisty.NewGisty()
+		obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+			args := cmd.Args
+			if len(args) > 4 && args[0] == "-c" {
+				args = args[4:]
+			}
+
+			call := strings.Join(args, " ")
+
+			out, stderr := handle(call)
+			if stderr != "" {
+				_, err := fmt.Fprintln(cmd.Stderr, stderr)
+				if err != nil {
+					return err
+				}
+
+				_, err = fmt.Fprintln(cmd.Stdout, out)
+				if err != nil {
+					return err
+				}
+
+				return fmt.Errorf("git %s failed", call)
+			}
+
+			switch call {
+			case "rev-parse --show-toplevel":
+				out = dir
+			case "rev-parse --abbrev-ref --symbolic-full-name @{upstream}":
+				out = "origin/main"
+			}
+
+			_, err := fmt.Fprintln(cmd.Stdout, out)
+
+			return err
+		}
+
+		return obj
+	}
+}
+
+func TestPushCmd(t *testing.T) {
+	t.Parallel()
+
+	const commit = "0123456789abcdef0123456789abcdef01234567"
+
+	dir := t.TempDir()
+
+	var gotMessage string
+
+	newGisty := stubPushGit(dir, func(call string) (string, string) {
+		switch {
+		case strings.HasPrefix(call, "commit "):
+			gotMessage = strings.TrimPrefix(call, "commit --quiet --message ")
+		case call == "diff --cached --name-only":
+			return "hello.md", ""
+		case call == "rev-list --count @{upstream}..HEAD":
+			return "1", ""
+		case call == "rev-parse HEAD":
+			return commit, ""
+		}
+
+		return "", ""
+	})
+
+	stdout, _, err := runApp(t, newGisty, "push", dir, "-m", "Fix typo")
+
+	require.NoError(t, err)
+	require.Equal(t, "Fix typo", gotMessage)
+	require.Equal(t, "origin/main\t"+commit+"\n", stdout)
+}
+
+func TestPushCmd_nothing_to_push(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubPushGit(t.TempDir(), func(call string) (string, string) {
+		if call == "rev-list --count @{upstream}..HEAD" {
+			return "0", ""
+		}
+
+		return "", ""
+	})
+
+	stdout, _, err := runApp(t, newGisty, "push", t.TempDir())
+
+	require.NoError(t, err)
+	require.Equal(t, "nothing to push\n", stdout)
+}
+
+func TestPushCmd_rejected(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubPushGit(t.TempDir(), func(call string) (string, string) {
+		switch {
+		case call == "rev-list --count @{upstream}..HEAD":
+			return "1", ""
+		case strings.HasPrefix(call, "push "):
+			return "!\tHEAD:refs/heads/main\t[rejected] (non-fast-forward)", "error: failed to push some refs"
+		}
+
+		return "", ""
+	})
+
+	_, _, err := runApp(t, newGisty, "push", t.TempDir())
+
+	require.ErrorIs(t, err, gisty.ErrPushRejected)
+	require.ErrorContains(t, err, "failed to push gist")
+	require.Equal(t, exitError, exitCode(err))
+}
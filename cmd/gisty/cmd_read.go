@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/cli/cli/v2/pkg/cmd/gist/shared"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newReadCmd() *cobra.Command {
+	var (
+		fileName  string
+		fromCache bool
+		out       output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "read <gist>",
+		Short: "Print the files of a gist",
+		Long: `Print the description and the files of a gist.
+
+<gist> is a gist ID or URL. With --file, only the content of the given file is
+printed as is. The table and tsv formats list the files of the gist.
+
+If the gists are mirrored by "gisty mirror", the mirror is read when GitHub
+cannot be reached. With --cache, the mirror is read without requesting GitHub.`,
+		Args:              usageArgs(cobra.ExactArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(_ *cobra.Command, args []string) error {
+			obj := a.gisty()
+
+			closeMirror, err := a.useMirror(obj, fromCache)
+			if err != nil {
+				return err
+			}
+
+			defer closeMirror()
+
+			gist, err := obj.Read(args[0])
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to read gist: %w", err))
+			}
+
+			if fileName != "" {
+				file, ok := gist.Files[fileName]
+				if !ok {
+					return fmt.Errorf("%w: no file named %q in gist %s", gisty.ErrNotFound, fileName, gist.ID)
+				}
+
+				if out.format == "" && out.template == "" && out.jq == "" {
+					_, err = fmt.Fprint(a.streams.Stdout, file.Content)
+
+					return wrapPrintErr(err)
+				}
+
+				return out.print(a.streams.Stdout, file, func() tabular {
+					return filesTable([]*shared.GistFile{file})
+				}, nil)
+			}
+
+			return out.print(a.streams.Stdout, gist, func() tabular {
+				return filesTable(sortedFiles(gist))
+			}, func(w io.Writer) error {
+				return printGist(w, gist)
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&fileName, "file", "f", "", "Print only the content of the given file")
+	cmd.Flags().BoolVar(&fromCache, "cache", false, `Read the gist from the mirror made by "gisty mirror"`)
+	out.addFlags(cmd)
+
+	return cmd
+}
+
+// printGist prints the description and the files of the gist in a
+// human-readable form.
+func printGist(w io.Writer, gist *shared.Gist) error {
+	var out strings.Builder
+
+	if gist.Description != "" {
+		out.WriteString(gist.Description + "\n\n")
+	}
+
+	for index, file := range sortedFiles(gist) {
+		if index > 0 {
+			out.WriteString("\n")
+		}
+
+		out.WriteString("==> " + file.Filename + " <==\n")
+		out.WriteString(strings.TrimSuffix(file.Content, "\n") + "\n")
+	}
+
+	_, err := io.WriteString(w, out.String())
+
+	return err //nolint:wrapcheck // wrapped by the caller
+}
+
+// sortedFiles returns the files of the gist sorted by name.
+func sortedFiles(gist *shared.Gist) []*shared.GistFile {
+	names := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	files := make([]*shared.GistFile, 0, len(names))
+	for _, name := range names {
+		file := gist.Files[name]
+		if file.Filename == "" {
+			file.Filename = name
+		}
+
+		files = append(files, file)
+	}
+
+	return files
+}
+
+func filesTable(files []*shared.GistFile) tabular {
+	rows := make([][]string, 0, len(files))
+
+	for _, file := range files {
+		rows = append(rows, []string{file.Filename, file.Language, strconv.Itoa(len(file.Content))})
+	}
+
+	return tabular{
+		header: []string{"NAME", "LANGUAGE", "SIZE"},
+		rows:   rows,
+	}
+}
+
+func wrapPrintErr(err error) error {
+	if err != nil {
+		return fmt.Errorf("failed to print: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "read", "https://gist.github.com/octocat/"+testGistID)
+
+	require.NoError(t, err)
+	require.Equal(t, "test gist\n\n==> hello.md <==\n# Hello\n\n==> main.go <==\npackage main\n", stdout)
+}
+
+func TestReadCmd_file(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "read", testGistID, "--file", "main.go")
+
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", stdout)
+
+	_, _, err = runApp(t, srv.NewGisty, "read", testGistID, "--file", "missing.txt")
+
+	require.ErrorContains(t, err, "missing.txt")
+	require.Equal(t, exitNotFound, exitCode(err))
+}
+
+func TestReadCmd_not_found(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "read", "0123456789abcdef")
+
+	require.Error(t, err)
+	require.Equal(t, exitNotFound, exitCode(err))
+}
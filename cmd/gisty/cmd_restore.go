@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newRestoreCmd() *cobra.Command {
+	var (
+		opts    = gisty.NewRestoreOptions()
+		mapping []string
+		scan    secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore <archive>",
+		Short: "Recreate gists from a backup archive",
+		Long: `Recreate the gists in an archive created by "gisty backup".
+
+The files, the descriptions and the visibility are restored. The git history is
+not. Use "-" as <archive> to read it from the standard input. Each line of the
+output is the ID of the archived gist and the ID of the restored one.
+
+Use --map <old>=<new> to overwrite an existing gist instead of creating a new
+one, e.g. to resume an interrupted restore with the output of the previous run.
+The description and the files of the existing gist are replaced with the
+archived ones. With --comments, the comments are posted as quoted text by you,
+except the ones already posted to the existing gist.
+
+The files are scanned for secrets such as API keys, tokens and private keys,
+and the restore stops before the first gist with any, unless --allow-secrets is
+given.`,
+		Args: usageArgs(cobra.ExactArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			for _, pair := range mapping {
+				oldID, newID, ok := strings.Cut(pair, "=")
+				if !ok || oldID == "" || newID == "" {
+					return &usageError{err: fmt.Errorf("invalid --map %q. <old>=<new> expected", pair)}
+				}
+
+				opts.IDMap[oldID] = newID
+			}
+
+			var reader io.Reader = a.streams.Stdin
+
+			if args[0] != "-" {
+				file, err := os.Open(args[0])
+				if err != nil {
+					return fmt.Errorf("failed to open backup file: %w", err)
+				}
+
+				defer file.Close()
+
+				reader = file
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			result, errRestore := obj.Restore(reader, opts)
+
+			// Print the restored gists even on error, to resume with --map.
+			for _, oldID := range slices.Sorted(maps.Keys(result.IDMap)) {
+				_, err = fmt.Fprintf(a.streams.Stdout, "%s\t%s\n", oldID, result.IDMap[oldID])
+				if err != nil {
+					return err //nolint:wrapcheck // error of writing to stdout
+				}
+			}
+
+			if errRestore != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to restore gists: %w", errRestore))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&mapping, "map", nil, "Restore the archived gist into an existing one as `<old>=<new>`")
+	cmd.Flags().BoolVar(&opts.WithComments, "comments", false, "Post the archived comments as quoted text")
+	scan.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newRunCmd() *cobra.Command {
+	var (
+		revision string
+		show     bool
+		trust    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <gist> [<file>] [-- <args>...]",
+		Short: "Run a gist file as a script",
+		Long: `Run a file of a gist as a script, in a new temporary directory.
+
+<file> may be omitted if the gist has a single file, or a single file with a
+shebang line. The interpreter is taken from the shebang line, such as
+"#!/usr/bin/env python3", or from the file extension, such as ".sh".
+
+The script runs only if its content is the same as the one you trusted before.
+The first time, or once the script changed, review it with --show and run it
+again with --trust to record it as trusted. The script is pinned to the latest
+revision of the gist, or to the one given with --revision.
+
+The exit status is the one of the script.`,
+		Example: `  gisty run 5b10b34f87955dfc86d310cd623a61d1 --show
+  gisty run 5b10b34f87955dfc86d310cd623a61d1 deploy.sh --trust -- --dry-run`,
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var scriptArgs []string
+
+			if dash := cmd.ArgsLenAtDash(); dash >= 0 {
+				args, scriptArgs = args[:dash], args[dash:]
+			}
+
+			if len(args) < 1 || len(args) > 2 {
+				return &usageError{err: fmt.Errorf("<gist> and an optional <file> expected before --, got %d", len(args))}
+			}
+
+			file := ""
+			if len(args) == 2 {
+				file = args[1]
+			}
+
+			obj := a.gisty()
+
+			script, err := obj.FetchScript(args[0], file, revision)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to fetch script: %w", err))
+			}
+
+			if show {
+				return wrapPrintErr(printScript(a, script))
+			}
+
+			opts := gisty.NewRunOptions(a.trustFile)
+			opts.Stdin = a.streams.Stdin
+			opts.Stdout = a.streams.Stdout
+			opts.Stderr = a.streams.Stderr
+			opts.Args = scriptArgs
+			opts.Trust = trust
+
+			err = obj.RunScript(script, opts)
+			if errors.Is(err, gisty.ErrUntrustedScript) {
+				return fmt.Errorf("%w\nReview it with: gisty run %s %s --revision %s --show\nThen run it again with --trust",
+					err, script.GistID, script.File, script.Revision)
+			}
+
+			return err //nolint:wrapcheck // already wrapped with the exit status of the script
+		},
+	}
+
+	cmd.Flags().StringVar(&revision, "revision", "", "Run the script at the `sha` of a gist revision")
+	cmd.Flags().BoolVar(&show, "show", false, "Print the script and its interpreter instead of running it")
+	cmd.Flags().BoolVar(&trust, "trust", false, "Trust the current content of the script and run it")
+
+	return cmd
+}
+
+// printScript prints the pinned script and how it would be run.
+func printScript(a *app, script gisty.Script) error {
+	_, err := fmt.Fprintf(a.streams.Stdout, "# gist: %s\n# file: %s\n# revision: %s\n# sha256: %s\n# interpreter: %s\n\n%s",
+		script.GistID, script.File, script.Revision, script.SHA256,
+		strings.Join(script.Interpreter, " "), script.Content)
+
+	return err //nolint:wrapcheck // wrapped by the caller
+}
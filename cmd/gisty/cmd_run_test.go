@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunCmd(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"hello.sh": "echo \"hello $*\"\nexit 7\n", "README.md": "# Hello\n"},
+	})
+	trustFile := filepath.Join(t.TempDir(), "trust.json")
+	stdout := new(bytes.Buffer)
+
+	runCmd := func(args ...string) error {
+		stdout.Reset()
+
+		cmd := (&app{
+			newGisty:   srv.NewGisty,
+			streams:    ghcmd.Streams{Stdin: bytes.NewBuffer(nil), Stdout: stdout, Stderr: new(bytes.Buffer)},
+			setErrPos:  nil,
+			cacheDir:   "",
+			policyFile: "",
+			policies:   nil,
+			trustFile:  trustFile,
+			debug:      false,
+		}).newRootCmd()
+		cmd.SetArgs(args)
+
+		return cmd.Execute()
+	}
+
+	err := runCmd("run", gistID, "hello.sh", "--", "world")
+
+	require.ErrorContains(t, err, "was never trusted")
+	require.ErrorContains(t, err, "Then run it again with --trust")
+	require.Empty(t, stdout.String())
+
+	require.NoError(t, runCmd("run", gistID, "hello.sh", "--show"))
+	require.Contains(t, stdout.String(), "# interpreter: sh\n\necho \"hello $*\"\n")
+
+	err = runCmd("run", gistID, "hello.sh", "--trust", "--", "world", "--flag")
+
+	require.Equal(t, 7, exitCode(err), "exit status of the script should be kept")
+	require.Equal(t, "hello world --flag\n", stdout.String())
+
+	err = runCmd("run", gistID)
+
+	require.ErrorContains(t, err, "Specify the file to run")
+
+	err = runCmd("run", gistID, "hello.sh", "extra")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+// searchIndexName is the name of the search index file in the cache directory.
+const searchIndexName = "search-index.json"
+
+func (a *app) newSearchCmd() *cobra.Command {
+	opts := gisty.NewSearchOptions()
+
+	var out output
+
+	cmd := &cobra.Command{
+		Use:   "search [<query>...]",
+		Short: "Search your gists by content, file name and description",
+		Long: `Search your gists, including the secret ones, by content, file name and
+description.
+
+All the words of the query must be found, case insensitively. The gists are
+indexed locally in the cache directory, and only the gists updated since the
+previous search are downloaded again. Use --offline to search the index as is.
+
+By default, each gist is printed as its ID and description, followed by the
+matching lines as "<file>:<line>: <text>". Use --format, --template or --jq to
+change the output.`,
+		Example: `  gisty search retry backoff
+  gisty search --file '*.go' --language go context`,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if a.cacheDir != "" {
+				opts.IndexPath = filepath.Join(a.cacheDir, searchIndexName)
+			}
+
+			obj := a.gisty()
+
+			results, err := obj.Search(strings.Join(args, " "), opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to search gists: %w", err))
+			}
+
+			table := func() tabular { return searchTable(results) }
+
+			return out.print(a.streams.Stdout, results, table, func(w io.Writer) error {
+				return printSearchResults(w, results)
+			})
+		},
+	}
+
+	out.addFlags(cmd)
+
+	cmd.Flags().StringVar(&opts.Filename, "file", "", "Search only the files matching the `glob` pattern")
+	cmd.Flags().StringVar(&opts.Language, "language", "", "Search only the files of the language")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "L", limitDefault, "Maximum number of gists to show")
+	cmd.Flags().BoolVar(&opts.Offline, "offline", false, "Search the local index without refreshing it")
+
+	return cmd
+}
+
+func printSearchResults(w io.Writer, results []gisty.SearchResult) error {
+	for _, result := range results {
+		_, err := fmt.Fprintf(w, "%s\t%s\n", result.GistID, result.Description)
+		if err != nil {
+			return err //nolint:wrapcheck // error of writing to stdout
+		}
+
+		for _, match := range result.Matches {
+			_, err = fmt.Fprintf(w, "  %s:%d: %s\n", match.File, match.Line, match.Text)
+			if err != nil {
+				return err //nolint:wrapcheck // error of writing to stdout
+			}
+		}
+	}
+
+	return nil
+}
+
+func searchTable(results []gisty.SearchResult) tabular {
+	rows := make([][]string, 0, len(results))
+
+	for _, result := range results {
+		match := ""
+		if len(result.Matches) > 0 {
+			match = fmt.Sprintf("%s:%d: %s", result.Matches[0].File, result.Matches[0].Line, result.Matches[0].Text)
+		}
+
+		rows = append(rows, []string{
+			result.GistID, strconv.Itoa(result.Score), result.Description, match,
+		})
+	}
+
+	return tabular{
+		header: []string{"ID", "SCORE", "DESCRIPTION", "MATCH"},
+		rows:   rows,
+	}
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSearchCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "search", "package", "main")
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\ttest gist\n  main.go:1: package main\n", stdout)
+
+	stdout, _, err = runApp(t, srv.NewGisty, "search", "--file", "*.md", "--format", "tsv", "hello")
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\t3\ttest gist\thello.md:1: # Hello\n", stdout)
+
+	stdout, _, err = runApp(t, srv.NewGisty, "search", "--file", "*.md", "package")
+
+	require.NoError(t, err)
+	require.Empty(t, stdout)
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newStarsCmd() *cobra.Command {
+	var out output
+
+	cmd := &cobra.Command{
+		Use:               "stars <gist>",
+		Short:             "Print the number of stars of a gist",
+		Args:              usageArgs(cobra.ExactArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(_ *cobra.Command, args []string) error {
+			obj := a.gisty()
+
+			count, err := obj.Stargazer(args[0])
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to get stargazers: %w", err))
+			}
+
+			return out.print(a.streams.Stdout, count, func() tabular {
+				return tabular{header: []string{"STARS"}, rows: [][]string{{strconv.Itoa(count)}}}
+			}, func(w io.Writer) error {
+				_, err := fmt.Fprintln(w, count)
+
+				return err //nolint:wrapcheck // wrapped by the caller
+			})
+		},
+	}
+
+	out.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStarsCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "stars", testGistID)
+
+	require.NoError(t, err)
+	require.Equal(t, "3\n", stdout)
+}
+
+func TestStarsCmd_error(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubGH(func([]string) (string, error) {
+		return "", errForcedWrite
+	})
+
+	_, _, err := runApp(t, newGisty, "stars", testGistID)
+
+	require.ErrorContains(t, err, "failed to get stargazers")
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newStatsCmd() *cobra.Command {
+	var out output
+
+	cmd := &cobra.Command{
+		Use:   "stats <gist>...",
+		Short: "Print the stars, comments, forks and files of gists",
+		Long: `Print the number of stars, comments, forks and files of the given gists.
+
+<gist> is a gist ID or URL. The gists are fetched in batches with a single request
+each, so it is faster than "gisty stars" for many gists.`,
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeGistIDs(true),
+		RunE: func(_ *cobra.Command, gistIDs []string) error {
+			obj := a.gisty()
+
+			stats, err := obj.Stats(gistIDs)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to get stats: %w", err))
+			}
+
+			items := make([]gisty.GistStats, 0, len(gistIDs))
+			missing := []error{}
+
+			for _, gistID := range gistIDs {
+				ref, _ := gisty.ParseGistRef(gistID) // validated by Stats
+
+				item, ok := stats[ref.ID]
+				if !ok {
+					missing = append(missing, fmt.Errorf("%w: %s", gisty.ErrNotFound, gistID))
+
+					continue
+				}
+
+				items = append(items, item)
+			}
+
+			table := func() tabular { return statsTable(items) }
+
+			err = out.print(a.streams.Stdout, items, table, func(w io.Writer) error {
+				return printTable(w, table(), true)
+			})
+
+			return errors.Join(append(missing, err)...)
+		},
+	}
+
+	out.addFlags(cmd)
+
+	return cmd
+}
+
+func statsTable(items []gisty.GistStats) tabular {
+	rows := make([][]string, 0, len(items))
+
+	for _, item := range items {
+		rows = append(rows, []string{
+			item.GistID, strconv.Itoa(item.Stars), strconv.Itoa(item.Comments),
+			strconv.Itoa(item.Forks), strconv.Itoa(len(item.Files)),
+		})
+	}
+
+	return tabular{
+		header: []string{"ID", "STARS", "COMMENTS", "FORKS", "FILES"},
+		rows:   rows,
+	}
+}
@@ -0,0 +1,39 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatsCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "stats", testGistID, "--format", "tsv")
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\t3\t1\t0\t2\n", stdout)
+
+	stdout, _, err = runApp(t, srv.NewGisty, "stats", "https://gist.github.com/octocat/"+testGistID, "--format", "tsv")
+
+	require.NoError(t, err)
+	require.Equal(t, testGistID+"\t3\t1\t0\t2\n", stdout, "gist URLs should be accepted")
+
+	stdout, _, err = runApp(t, srv.NewGisty, "stats", testGistID, "0123456789abcdef")
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+	require.ErrorContains(t, err, "0123456789abcdef")
+	require.Equal(t, "ID                                STARS  COMMENTS  FORKS  FILES\n"+
+		testGistID+"  3      1         0      2\n", stdout, "found gists should be printed")
+}
+
+func TestStatsCmd_error(t *testing.T) {
+	t.Parallel()
+
+	_, _, err := runApp(t, gisty.NewGisty, "stats", "<>")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
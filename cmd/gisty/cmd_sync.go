@@ -0,0 +1,89 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newSyncCmd() *cobra.Command {
+	var (
+		opts   = gisty.NewSyncOptions()
+		prefer string
+		out    output
+		scan   secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "sync <gist> [<directory>]",
+		Short: "Sync a directory with a gist in both directions",
+		Long: `Sync the files in a directory with a gist in both directions, without git.
+
+Local changes are pushed and remote changes are pulled. Files changed on both
+sides since the last sync are reported as conflicts, unless --prefer is given.
+<directory> defaults to the current directory. Each line of the output is an
+action: push, pull, delete-remote, delete-local or conflict, and the file name.
+
+Nothing is synced if the files to push seem to contain secrets such as API keys,
+tokens and private keys, unless --allow-secrets is given.`,
+		Args:              usageArgs(cobra.RangeArgs(1, 2)), //nolint:mnd // gist and directory
+		ValidArgsFunction: a.completeGistIDs(false),
+		RunE: func(_ *cobra.Command, args []string) error {
+			switch gisty.SyncPrefer(prefer) {
+			case gisty.SyncPreferNone, gisty.SyncPreferLocal, gisty.SyncPreferRemote:
+				opts.Prefer = gisty.SyncPrefer(prefer)
+			default:
+				return &usageError{err: fmt.Errorf("invalid --prefer %q. Valid values: local, remote", prefer)}
+			}
+
+			dir := "."
+			if len(args) == 2 { //nolint:mnd // gist and directory
+				dir = args[1]
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			result, errSync := obj.Sync(dir, args[0], opts)
+
+			err = out.print(a.streams.Stdout, result, func() tabular {
+				rows := make([][]string, 0, len(result.Actions))
+				for _, action := range result.Actions {
+					rows = append(rows, []string{string(action.Type), action.File})
+				}
+
+				return tabular{header: []string{"ACTION", "FILE"}, rows: rows}
+			}, func(w io.Writer) error {
+				for _, action := range result.Actions {
+					_, err := fmt.Fprintln(w, action)
+					if err != nil {
+						return err //nolint:wrapcheck // wrapped by the caller
+					}
+				}
+
+				return nil
+			})
+
+			if errSync != nil {
+				return fmt.Errorf("failed to sync gist: %w", errSync)
+			}
+
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVarP(&opts.DryRun, "dry-run", "n", false, "Print the planned actions without applying them")
+	cmd.Flags().StringVar(&prefer, "prefer", "", "Resolve the conflicts by keeping the {local|remote} file")
+	scan.addFlags(cmd)
+	out.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSyncCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	dir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "new.txt"), []byte("new"), 0o600))
+
+	stdout, _, err := runApp(t, srv.NewGisty, "sync", testGistID, dir, "--dry-run")
+
+	require.NoError(t, err)
+	require.Equal(t, "pull hello.md\npull main.go\npush new.txt\n", stdout)
+	require.NoFileExists(t, filepath.Join(dir, "main.go"), "dry run should not change files")
+
+	stdout, _, err = runApp(t, srv.NewGisty, "sync", testGistID, dir, "--format", "tsv")
+
+	require.NoError(t, err)
+	require.Equal(t, "pull\thello.md\npull\tmain.go\npush\tnew.txt\n", stdout)
+	require.FileExists(t, filepath.Join(dir, "main.go"))
+
+	gist, _ := srv.Gist(testGistID)
+	require.Equal(t, "new", gist.Files["new.txt"])
+}
+
+func TestSyncCmd_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "sync", testGistID, t.TempDir(), "--prefer", "both")
+
+	require.Equal(t, exitUsage, exitCode(err))
+
+	_, _, err = runApp(t, srv.NewGisty, "sync", "0123456789abcdef", t.TempDir())
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+	require.Equal(t, exitNotFound, exitCode(err))
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/cli/cli/v2/pkg/cmd/gist/shared"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newTemplateCmd() *cobra.Command {
+	var (
+		opts = gisty.NewTemplateOptions()
+		vars []string
+		scan secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "template <dir>",
+		Short: "Create a new gist from a template directory",
+		Long: `Create a new gist from the files of a template directory and print its URL.
+
+Each file in <dir> is a Go text/template, and so is its name. E.g. a file named
+"{{.Name}}_test.go" containing "package {{.Package}}". The description is the
+template in the ".description" file of <dir>, unless --desc is given. All the
+variables used by the templates must be given with --var <name>=<value>.
+
+With --dry-run, the rendered files are printed instead of creating the gist.
+Otherwise, the gist is created as "gisty create" does.`,
+		Example: `  gisty template ./repro --var Name=parser --var Package=demo --dry-run`,
+		Args:    usageArgs(cobra.ExactArgs(1)),
+		RunE: func(_ *cobra.Command, args []string) error {
+			values := make(map[string]string, len(vars))
+
+			for _, pair := range vars {
+				name, value, ok := strings.Cut(pair, "=")
+				if !ok || name == "" {
+					return &usageError{err: fmt.Errorf("invalid --var %q. <name>=<value> expected", pair)}
+				}
+
+				values[name] = value
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			result, err := obj.CreateFromTemplate(args[0], values, opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to create gist from template: %w", err))
+			}
+
+			if opts.DryRun {
+				return wrapPrintErr(printGist(a.streams.Stdout, renderedGist(result)))
+			}
+
+			_, err = fmt.Fprintln(a.streams.Stdout, result.URL.String())
+
+			return wrapPrintErr(err)
+		},
+	}
+
+	cmd.Flags().StringArrayVar(&vars, "var", nil, "Value of a template variable as `<name>=<value>`")
+	cmd.Flags().StringVarP(&opts.Description, "desc", "d", "", "Template of the description of the gist")
+	cmd.Flags().BoolVarP(&opts.AsPublic, "public", "p", false, "Create a public gist")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "Print the rendered files without creating the gist")
+	scan.addFlags(cmd)
+
+	return cmd
+}
+
+// renderedGist returns the rendered template as a gist to print.
+func renderedGist(result gisty.TemplateResult) *shared.Gist {
+	files := make(map[string]*shared.GistFile, len(result.Files))
+
+	for _, file := range result.Files {
+		files[file.Name] = &shared.GistFile{
+			Filename:  file.Name,
+			Type:      "",
+			Language:  "",
+			Content:   file.Content,
+			RawURL:    "",
+			Truncated: false,
+		}
+	}
+
+	return &shared.Gist{
+		ID:          "",
+		Description: result.Description,
+		Files:       files,
+		UpdatedAt:   time.Time{},
+		Public:      false,
+		HTMLURL:     "",
+		Owner:       nil,
+	}
+}
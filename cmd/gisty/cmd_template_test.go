@@ -0,0 +1,44 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{
+		".description":      "Repro for {{.Ticket}}",
+		"{{.Name}}_test.go": "package {{.Name}}\n",
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	stdout, _, err := runApp(t, srv.NewGisty, "template", dir, "--var", "Name=demo", "--var", "Ticket=OPS-1", "--dry-run")
+
+	require.NoError(t, err)
+	require.Equal(t, "Repro for OPS-1\n\n==> demo_test.go <==\npackage demo\n", stdout)
+	require.Len(t, srv.Gists(), 1, "gist should not be created on dry run")
+
+	stdout, _, err = runApp(t, srv.NewGisty, "template", dir, "--var", "Name=demo", "--var", "Ticket=OPS-1")
+
+	require.NoError(t, err)
+	require.Contains(t, stdout, "https://gist.github.com/")
+	require.Len(t, srv.Gists(), 2)
+
+	_, _, err = runApp(t, srv.NewGisty, "template", dir, "--var", "Name=demo")
+
+	require.Equal(t, exitUsage, exitCode(err))
+	require.ErrorContains(t, err, "missing Ticket")
+
+	_, _, err = runApp(t, srv.NewGisty, "template", dir, "--var", "Name")
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
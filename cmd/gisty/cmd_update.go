@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newUpdateCmd() *cobra.Command {
+	var (
+		args = gisty.NewUpdateArgs("")
+		out  output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "update [<directory>]",
+		Short: "Sync a cloned gist with its remote",
+		Long: `Sync the local clone of a gist with its remote repository.
+
+<directory> is the path to the cloned gist. Defaults to the current directory.
+The first line of the output is the synced branch, how it moved (up-to-date,
+fast-forward or reset) and its commit, separated by tabs. The changed files
+follow, one per line.`,
+		Args: usageArgs(cobra.MaximumNArgs(1)),
+		RunE: func(_ *cobra.Command, dirs []string) error {
+			args.PathDirRepo = "."
+			if len(dirs) == 1 {
+				args.PathDirRepo = dirs[0]
+			}
+
+			obj := a.gisty()
+
+			result, err := obj.Update(args)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to update gist: %w", err))
+			}
+
+			return out.print(a.streams.Stdout, result, func() tabular {
+				return tabular{
+					header: []string{"BRANCH", "MODE", "PREVIOUS", "COMMIT", "CHANGED"},
+					rows: [][]string{{
+						result.Branch, string(result.Mode), result.PrevCommit, result.Commit,
+						strconv.Itoa(len(result.ChangedFiles)),
+					}},
+				}
+			}, func(w io.Writer) error {
+				_, err := fmt.Fprintf(w, "%s\t%s\t%s\n", result.Branch, result.Mode, result.Commit)
+				if err != nil {
+					return err //nolint:wrapcheck // wrapped by the caller
+				}
+
+				for _, file := range result.ChangedFiles {
+					_, err = fmt.Fprintln(w, file)
+					if err != nil {
+						return err //nolint:wrapcheck // wrapped by the caller
+					}
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&args.Branch, "branch", "b", "", "Branch to sync")
+	cmd.Flags().StringVarP(&args.Source, "source", "s", "", "Source repository to sync from")
+	cmd.Flags().BoolVar(&args.Force, "force", false, "Sync using a hard reset")
+	out.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	updatePrevCommit = "1111111111111111111111111111111111111111"
+	updateCommit     = "2222222222222222222222222222222222222222"
+)
+
+// stubUpdate returns a newGisty function whose gh commands are handled by
+// handle and whose branch is fast-forwarded by one commit changing hello.md.
+func stubUpdate(handle func(args []string) (string, error)) func() *gisty.Gisty {
+	return func() *gisty.Gisty {
+		obj := stubGH(handle)()
+		commits := []string{updatePrevCommit, updateCommit}
+
+		obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+			var out string
+
+			switch cmd.Args[0] {
+			case "for-each-ref":
+				out, commits = commits[0], commits[1:]
+			case "merge-base":
+				out = updatePrevCommit
+			case "diff":
+				out = "hello.md\x00"
+			}
+
+			_, err := fmt.Fprintln(cmd.Stdout, out)
+
+			return err
+		}
+
+		return obj
+	}
+}
+
+func TestUpdateCmd(t *testing.T) {
+	t.Parallel()
+
+	var gotArgs []string
+
+	newGisty := stubUpdate(func(args []string) (string, error) {
+		gotArgs = args
+
+		return "✓ Synced the \"main\" branch\n", nil
+	})
+
+	stdout, _, err := runApp(t, newGisty, "update", t.TempDir(), "--branch", "main", "--force")
+
+	require.NoError(t, err)
+	require.Equal(t, "main\tfast-forward\t"+updateCommit+"\nhello.md\n", stdout)
+	require.Equal(t, []string{"repo", "sync", "--branch=main", "--force"}, gotArgs)
+}
+
+func TestUpdateCmd_json(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubUpdate(func([]string) (string, error) {
+		return "", nil
+	})
+
+	stdout, _, err := runApp(t, newGisty, "update", t.TempDir(), "--branch", "main", "--format", "json")
+
+	require.NoError(t, err)
+
+	var result gisty.UpdateResult
+
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&result))
+	require.Equal(t, gisty.UpdateResult{
+		Branch:       "main",
+		PrevCommit:   updatePrevCommit,
+		Commit:       updateCommit,
+		Mode:         gisty.UpdateFastForward,
+		ChangedFiles: []string{"hello.md"},
+	}, result)
+}
+
+func TestUpdateCmd_error(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubUpdate(func([]string) (string, error) {
+		return "", errForcedWrite
+	})
+
+	_, _, err := runApp(t, newGisty, "update", t.TempDir())
+
+	require.ErrorContains(t, err, "failed to update gist")
+}
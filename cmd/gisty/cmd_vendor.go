@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newVendorCmd() *cobra.Command {
+	var (
+		opts         = gisty.NewVendorOptions()
+		pathManifest string
+		out          output
+	)
+
+	cmd := &cobra.Command{
+		Use:   "vendor",
+		Short: "Download gist files pinned by a manifest and a lockfile",
+		Long: `Download the gist files listed in the manifest, gisty.vendor.json by default,
+and write the lockfile gisty.vendor.lock next to it with their revisions and
+SHA-256 hashes. E.g.
+
+  {
+    "dir": "third_party/gists",
+    "dependencies": [
+      {"gist": "5b10b34f87955dfc86d310cd623a61d1", "file": ".golangci.yml"},
+      {"gist": "<gist>", "file": "ci.yml", "revision": "<sha>", "path": "ci/lint.yml"}
+    ]
+  }
+
+A file without a revision in the manifest is locked to the latest revision of
+its gist, and kept at the locked revision until --update is given. Files no
+longer in the manifest are removed. Each line of the output is the path and the
+locked revision of a file. Use "gisty vendor verify" to detect drift.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			obj := a.gisty()
+
+			result, err := obj.Vendor(pathManifest, opts)
+			if err != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to vendor gist files: %w", err))
+			}
+
+			return out.print(a.streams.Stdout, result, func() tabular {
+				rows := make([][]string, 0, len(result.Lock.Dependencies))
+				for _, dep := range result.Lock.Dependencies {
+					rows = append(rows, []string{dep.Path, dep.GistID, dep.File, dep.Revision})
+				}
+
+				return tabular{header: []string{"PATH", "GIST", "FILE", "REVISION"}, rows: rows}
+			}, func(w io.Writer) error {
+				for _, dep := range result.Lock.Dependencies {
+					_, err := fmt.Fprintf(w, "%s\t%s\n", dep.Path, dep.Revision)
+					if err != nil {
+						return err //nolint:wrapcheck // wrapped by the caller
+					}
+				}
+
+				return nil
+			})
+		},
+	}
+
+	cmd.PersistentFlags().StringVarP(&pathManifest, "manifest", "m", gisty.VendorManifestFile, "Path to the manifest")
+	cmd.Flags().BoolVarP(&opts.Update, "update", "u", false, "Lock the files to the latest revision of their gists")
+	out.addFlags(cmd)
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "verify",
+		Short: "Check that the vendored files match the lockfile",
+		Long: `Check, without requesting GitHub, that the lockfile matches the manifest and
+that the vendored files match the hashes of the lockfile.
+
+Each drifted file is reported with the reason: missing, modified, not locked,
+locked on another host or not in manifest. Run "gisty vendor" to fix them.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			err := a.gisty().VerifyVendor(pathManifest)
+			if err != nil {
+				return fmt.Errorf("failed to verify vendored files: %w", err)
+			}
+
+			_, err = fmt.Fprintln(a.streams.Stdout, "all vendored files verified")
+
+			return wrapPrintErr(err)
+		},
+	})
+
+	return cmd
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVendorCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	dir := t.TempDir()
+	pathManifest := filepath.Join(dir, gisty.VendorManifestFile)
+
+	require.NoError(t, os.WriteFile(pathManifest,
+		[]byte(`{"dependencies": [{"gist": "`+testGistID+`", "file": "main.go"}]}`), 0o600))
+
+	gist, _ := srv.Gist(testGistID)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "vendor", "--manifest", pathManifest)
+
+	require.NoError(t, err)
+	require.Equal(t, "gists/main.go\t"+gist.Revision()+"\n", stdout)
+	require.FileExists(t, filepath.Join(dir, "gists", "main.go"))
+
+	stdout, _, err = runApp(t, srv.NewGisty, "vendor", "verify", "--manifest", pathManifest)
+
+	require.NoError(t, err)
+	require.Equal(t, "all vendored files verified\n", stdout)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gists", "main.go"), []byte("edited"), 0o600))
+
+	_, _, err = runApp(t, srv.NewGisty, "vendor", "verify", "--manifest", pathManifest)
+
+	require.ErrorIs(t, err, gisty.ErrVendorDrift)
+	require.ErrorContains(t, err, "gists/main.go: modified")
+	require.Equal(t, exitError, exitCode(err))
+}
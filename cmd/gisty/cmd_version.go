@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	buildinfo "github.com/KEINOS/go-gisty/gisty/buildinfos"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newVersionCmd() *cobra.Command {
+	var (
+		out    output
+		asJSON bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "version",
+		Short: "Print the build and runtime information",
+		Long: `Print the version of gisty, its build date and VCS revision, the Go version,
+the version of the GitHub CLI module it is built against and the version of the
+"gh" command on PATH.
+
+The --json flag also prints the versions of all the module dependencies. It is
+the same as "--format json".`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			if asJSON {
+				if out.format != "" || out.template != "" || out.jq != "" {
+					return &usageError{err: errOutputFlags}
+				}
+
+				out.format = formatJSON
+			}
+
+			info := buildInfo()
+
+			return out.print(a.streams.Stdout, info, func() tabular {
+				rows := make([][]string, 0, len(info.Dependencies))
+				for _, dep := range info.Dependencies {
+					rows = append(rows, []string{dep.Path, dep.Version, dep.Replace})
+				}
+
+				return tabular{header: []string{"MODULE", "VERSION", "REPLACE"}, rows: rows}
+			}, func(w io.Writer) error {
+				return printVersion(w, info)
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the information as JSON, including the module dependencies")
+	out.addFlags(cmd)
+
+	return cmd
+}
+
+// printVersion writes the human-readable build information to w. The unknown
+// values are printed as "unknown".
+func printVersion(w io.Writer, info buildinfo.BuildInfo) error {
+	unknown := func(value string) string {
+		if value == "" {
+			return "unknown"
+		}
+
+		return value
+	}
+
+	revision := unknown(info.Revision)
+	if info.Dirty {
+		revision += " (dirty)"
+	}
+
+	ghVersion := info.GHVersion
+	if ghVersion == "" {
+		ghVersion = "not found"
+	}
+
+	_, err := fmt.Fprintf(w, "gisty %s\n  date:     %s\n  revision: %s\n  go:       %s %s\n  cli/cli:  %s\n  gh:       %s\n",
+		info.Version, unknown(info.Date), revision, info.GoVersion, info.Platform, unknown(info.CLIVersion), ghVersion)
+
+	return err //nolint:wrapcheck // wrapped by the caller
+}
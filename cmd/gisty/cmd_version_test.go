@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	buildinfo "github.com/KEINOS/go-gisty/gisty/buildinfos"
+	"github.com/stretchr/testify/require"
+)
+
+// testBuildInfo is the build information returned by stubBuildInfo.
+var testBuildInfo = buildinfo.BuildInfo{
+	Version:      "v1.2.3",
+	Date:         "2026-10-01",
+	Revision:     "0123456789abcdef0123456789abcdef01234567",
+	Dirty:        true,
+	GoVersion:    "go1.26.1",
+	Platform:     "linux/amd64",
+	CLIVersion:   "v2.97.0",
+	GHVersion:    "",
+	Dependencies: []buildinfo.Module{{Path: buildinfo.ModulePathCLI, Version: "v2.97.0", Replace: ""}},
+}
+
+// stubBuildInfo makes the version command print testBuildInfo during the test.
+func stubBuildInfo(t *testing.T) {
+	t.Helper()
+
+	oldBuildInfo := buildInfo
+
+	t.Cleanup(func() {
+		buildInfo = oldBuildInfo
+	})
+
+	buildInfo = func() buildinfo.BuildInfo {
+		return testBuildInfo
+	}
+}
+
+//nolint:paralleltest // This test replaces the package-level build information.
+func TestVersionCmd(t *testing.T) {
+	stubBuildInfo(t)
+
+	stdout, _, err := runApp(t, gisty.NewGisty, "version")
+
+	require.NoError(t, err)
+	require.Equal(t, `gisty v1.2.3
+  date:     2026-10-01
+  revision: 0123456789abcdef0123456789abcdef01234567 (dirty)
+  go:       go1.26.1 linux/amd64
+  cli/cli:  v2.97.0
+  gh:       not found
+`, stdout)
+}
+
+//nolint:paralleltest // This test replaces the package-level build information.
+func TestVersionCmd_json(t *testing.T) {
+	stubBuildInfo(t)
+
+	stdout, _, err := runApp(t, gisty.NewGisty, "version", "--json")
+
+	require.NoError(t, err)
+
+	var info buildinfo.BuildInfo
+
+	require.NoError(t, json.NewDecoder(strings.NewReader(stdout)).Decode(&info))
+	require.Equal(t, testBuildInfo, info)
+	require.Contains(t, stdout, `"cli_version": "v2.97.0"`)
+
+	_, _, err = runApp(t, gisty.NewGisty, "version", "--json", "--format", "yaml")
+
+	require.ErrorIs(t, err, errOutputFlags)
+	require.Equal(t, exitUsage, exitCode(err))
+}
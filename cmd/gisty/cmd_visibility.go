@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newVisibilityCmd() *cobra.Command {
+	var (
+		opts = gisty.NewVisibilityOptions()
+		scan secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "visibility <gist> {public|secret}",
+		Short: "Make a gist public or secret",
+		Long: `Make a gist public or secret.
+
+GitHub does not allow changing the visibility of a gist, so a new gist is
+created with the same files and description. The output is the ID of the
+original gist and the ID of the new one. Comments and stars are not carried
+over.
+
+With --history, the git history is pushed to the new gist. It requires git.
+With --delete, the original gist is deleted once the new one is ready.
+
+Before making a gist public, its files are scanned for secrets such as API keys,
+tokens and private keys, and nothing is done if any are found, unless
+--allow-secrets is given. With --history, the files of every revision are
+scanned too.`,
+		Args: usageArgs(cobra.ExactArgs(2)),
+		ValidArgsFunction: func(_ *cobra.Command, args []string, _ string) ([]string, cobra.ShellCompDirective) {
+			if len(args) == 1 {
+				return []string{"public", "secret"}, cobra.ShellCompDirectiveNoFileComp
+			}
+
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(_ *cobra.Command, args []string) error {
+			var public bool
+
+			switch args[1] {
+			case "public":
+				public = true
+			case "secret":
+				public = false
+			default:
+				return &usageError{err: fmt.Errorf("invalid visibility %q. public or secret expected", args[1])}
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			result, errVisibility := obj.SetVisibility(args[0], public, opts)
+
+			// Print the new gist even on error since it may already exist.
+			if result.NewID != "" {
+				_, err = fmt.Fprintf(a.streams.Stdout, "%s\t%s\n", result.OldID, result.NewID)
+				if err != nil {
+					return err //nolint:wrapcheck // error of writing to stdout
+				}
+			}
+
+			if errVisibility != nil {
+				return a.relayStderr(obj, fmt.Errorf("failed to change the visibility: %w", errVisibility))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.WithHistory, "history", false, "Push the git history to the new gist")
+	cmd.Flags().BoolVar(&opts.DeleteOriginal, "delete", false, "Delete the original gist")
+	scan.addFlags(cmd)
+
+	return cmd
+}
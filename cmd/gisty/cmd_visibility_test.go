@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestVisibilityCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "visibility", testGistID, "secret", "--delete")
+
+	require.NoError(t, err)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 1)
+	require.False(t, gists[0].Public)
+	require.Equal(t, testGistID+"\t"+gists[0].ID+"\n", stdout)
+
+	// Already secret.
+	stdout, _, err = runApp(t, srv.NewGisty, "visibility", gists[0].ID, "secret")
+
+	require.NoError(t, err)
+	require.Equal(t, gists[0].ID+"\t"+gists[0].ID+"\n", stdout)
+}
+
+func TestVisibilityCmd_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "visibility", testGistID, "private")
+
+	require.Equal(t, exitUsage, exitCode(err))
+
+	_, _, err = runApp(t, srv.NewGisty, "visibility", testGistID)
+
+	require.Equal(t, exitUsage, exitCode(err))
+}
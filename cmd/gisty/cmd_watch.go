@@ -0,0 +1,84 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+func (a *app) newWatchCmd() *cobra.Command {
+	var (
+		opts = gisty.NewWatchOptions()
+		scan secretScan
+	)
+
+	cmd := &cobra.Command{
+		Use:   "watch <directory> <gist>",
+		Short: "Publish the changes of a directory to a gist as they happen",
+		Long: `Watch a directory and publish its changes to a gist until interrupted.
+
+The gist mirrors the files in the directory: changed, added, deleted and renamed
+files are pushed to the gist once they stay unchanged for the --debounce time.
+The changes made to the gist by others are overwritten. Failed publishes due to
+rate limits or server errors are retried with a backoff.
+
+Changes that seem to contain secrets such as API keys, tokens and private keys
+are not published until they are removed, unless --allow-secrets is given.`,
+		Args: usageArgs(cobra.ExactArgs(2)), //nolint:mnd // directory and gist
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+			if len(args) == 0 {
+				return nil, cobra.ShellCompDirectiveFilterDirs
+			}
+
+			return a.completeGistIDs(false)(cmd, args[1:], toComplete)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Printing errors are ignored not to stop watching.
+			opts.OnPublish = func(event gisty.WatchEvent) {
+				if errors.Is(event.Err, gisty.ErrSecretFound) {
+					_, _ = fmt.Fprintf(a.streams.Stderr, "not published: %v\n", event.Err)
+
+					return
+				}
+
+				if event.Err != nil {
+					_, _ = fmt.Fprintf(a.streams.Stderr, "failed to publish: %v. Retrying in %s\n", event.Err, event.RetryIn)
+
+					return
+				}
+
+				for _, action := range event.Actions {
+					_, _ = fmt.Fprintln(a.streams.Stdout, action)
+				}
+			}
+
+			opts.AllowSecrets = scan.allow
+
+			obj := a.gisty()
+
+			err := scan.apply(obj)
+			if err != nil {
+				return err
+			}
+
+			err = obj.Watch(cmd.Context(), args[0], args[1], opts)
+			if err == nil || errors.Is(err, context.Canceled) {
+				return nil
+			}
+
+			return fmt.Errorf("failed to watch: %w", err)
+		},
+	}
+
+	cmd.Flags().DurationVar(&opts.Interval, "interval", gisty.WatchIntervalDefault, "Interval to check the files for changes")
+	cmd.Flags().DurationVar(&opts.Debounce, "debounce", gisty.WatchDebounceDefault,
+		"Time to wait after the last change before publishing")
+	cmd.Flags().DurationVar(&opts.MaxBackoff, "max-backoff", gisty.WatchMaxBackoffDefault,
+		"Maximum time to wait before retrying a failed publish")
+	scan.addFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchCmd(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	dir := t.TempDir()
+
+	for name, content := range map[string]string{"hello.md": "# Hello\n", "main.go": "package main\n", "new.txt": "new"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+
+	stdout := new(bytes.Buffer)
+	cmd := (&app{
+		newGisty:   srv.NewGisty,
+		streams:    ghcmd.Streams{Stdin: nil, Stdout: stdout, Stderr: new(bytes.Buffer)},
+		setErrPos:  nil,
+		cacheDir:   "",
+		policyFile: "",
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
+	}).newRootCmd()
+	cmd.SetArgs([]string{"watch", dir, testGistID, "--interval", "5ms", "--debounce", "10ms"})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+
+	go func() {
+		done <- cmd.ExecuteContext(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		gist, _ := srv.Gist(testGistID)
+
+		return gist.Files["new.txt"] == "new"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	cancel()
+
+	require.NoError(t, <-done, "interruption should not be an error")
+	require.Equal(t, "push new.txt\n", stdout.String())
+}
+
+func TestWatchCmd_error(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, "watch", t.TempDir(), "0123456789abcdef")
+
+	require.Equal(t, exitNotFound, exitCode(err))
+}
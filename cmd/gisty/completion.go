@@ -0,0 +1,135 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+const (
+	// completionLimit is the number of recent gists offered for completion.
+	completionLimit = 100
+	// completionTTL is the lifetime of the cached gist list.
+	completionTTL = 5 * time.Minute
+	// completionCacheName is the file name of the cached gist list.
+	completionCacheName = "completion.json"
+)
+
+// completionCache is the content of the completion cache file.
+type completionCache struct {
+	CachedAt time.Time        `json:"cached_at"`
+	Items    []gisty.GistInfo `json:"items"`
+}
+
+// newCompletionCmd returns the command to generate the shell completion
+// scripts.
+func (a *app) newCompletionCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "completion {bash|zsh|fish|powershell}",
+		Short: "Generate the shell completion script",
+		Long: `Generate the completion script of gisty for the given shell.
+
+The gist IDs and descriptions of your recent gists are completed as well. They
+are cached for a few minutes to keep the completion fast.
+
+  bash:       source <(gisty completion bash)
+  zsh:        gisty completion zsh > "${fpath[1]}/_gisty"
+  fish:       gisty completion fish | source
+  powershell: gisty completion powershell | Out-String | Invoke-Expression`,
+		ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+		Args:                  usageArgs(cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs)),
+		DisableFlagsInUseLine: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var err error
+
+			root := cmd.Root()
+			out := a.streams.Stdout
+
+			switch args[0] {
+			case "bash":
+				err = root.GenBashCompletionV2(out, true)
+			case "zsh":
+				err = root.GenZshCompletion(out)
+			case "fish":
+				err = root.GenFishCompletion(out, true)
+			default:
+				err = root.GenPowerShellCompletionWithDesc(out)
+			}
+
+			return wrapPrintErr(err)
+		},
+	}
+}
+
+// completeGistIDs completes the gist IDs of the recent gists. If multiple is
+// false, only the first argument is completed.
+func (a *app) completeGistIDs(multiple bool) cobra.CompletionFunc {
+	return func(_ *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if len(args) > 0 && !multiple {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+
+		items, err := a.recentGists()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+
+		completions := make([]cobra.Completion, 0, len(items))
+
+		for _, item := range items {
+			if !strings.HasPrefix(item.GistID, toComplete) {
+				continue
+			}
+
+			completions = append(completions, cobra.CompletionWithDesc(item.GistID, item.Description))
+		}
+
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// recentGists returns the recent gists from the cache if it is fresh enough,
+// otherwise from the API. The cache is updated on the latter.
+func (a *app) recentGists() ([]gisty.GistInfo, error) {
+	if a.cacheDir == "" {
+		return a.listRecentGists()
+	}
+
+	pathCache := filepath.Join(a.cacheDir, completionCacheName)
+
+	if data, err := os.ReadFile(pathCache); err == nil {
+		var cache completionCache
+
+		if json.Unmarshal(data, &cache) == nil && time.Since(cache.CachedAt) < completionTTL {
+			return cache.Items, nil
+		}
+	}
+
+	items, err := a.listRecentGists()
+	if err != nil {
+		return nil, err
+	}
+
+	// Failing to cache only slows down the next completion.
+	data, err := json.Marshal(completionCache{CachedAt: time.Now(), Items: items})
+	if err == nil && os.MkdirAll(a.cacheDir, 0o700) == nil {
+		_ = os.WriteFile(pathCache, data, 0o600)
+	}
+
+	return items, nil
+}
+
+func (a *app) listRecentGists() ([]gisty.GistInfo, error) {
+	items, err := a.gisty().List(gisty.ListArgs{
+		Limit:      completionLimit,
+		OnlyPublic: false,
+		OnlySecret: false,
+	})
+
+	return items, err //nolint:wrapcheck // completion errors are not printed
+}
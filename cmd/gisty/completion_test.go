@@ -0,0 +1,100 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompletionCmd(t *testing.T) {
+	t.Parallel()
+
+	for _, shell := range []string{"bash", "zsh", "fish", "powershell"} {
+		stdout, _, err := runApp(t, gisty.NewGisty, "completion", shell)
+
+		require.NoError(t, err, shell)
+		require.Contains(t, stdout, "gisty", shell)
+	}
+
+	_, _, err := runApp(t, gisty.NewGisty, "completion", "tcsh")
+
+	require.Error(t, err)
+	require.Equal(t, exitUsage, exitCode(err))
+}
+
+func TestCompleteGistIDs(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	app := &app{
+		newGisty:   srv.NewGisty,
+		streams:    ghcmd.Streams{Stdin: nil, Stdout: nil, Stderr: nil},
+		setErrPos:  nil,
+		cacheDir:   t.TempDir(),
+		policyFile: "",
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
+	}
+
+	complete := app.completeGistIDs(false)
+
+	completions, directive := complete(nil, nil, testGistID[:4])
+
+	require.Equal(t, []string{testGistID + "\ttest gist"}, completions)
+	require.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	srv.AssertRequested(t, http.MethodGet, "/gists")
+
+	// The second completion uses the cached list.
+	srv.ResetRequests()
+
+	completions, _ = complete(nil, nil, "")
+
+	require.Len(t, completions, 1)
+	require.Empty(t, srv.Requests(), "cached list should be used")
+
+	completions, _ = complete(nil, nil, "ffff")
+	require.Empty(t, completions, "gist IDs should be filtered by prefix")
+
+	completions, _ = complete(nil, []string{testGistID}, "")
+	require.Empty(t, completions, "only the first argument should be completed")
+}
+
+func TestCompleteGistIDs_shell(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	stdout, _, err := runApp(t, srv.NewGisty, "__complete", "delete", testGistID, "")
+
+	require.NoError(t, err)
+	require.Contains(t, stdout, testGistID+"\ttest gist\n", "delete should complete multiple gists")
+}
+
+func TestCompleteGistIDs_error(t *testing.T) {
+	t.Parallel()
+
+	newGisty := stubGH(func([]string) (string, error) {
+		return "", errForcedWrite
+	})
+
+	app := &app{
+		newGisty:   newGisty,
+		streams:    ghcmd.Streams{Stdin: nil, Stdout: nil, Stderr: nil},
+		setErrPos:  nil,
+		cacheDir:   "",
+		policyFile: "",
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
+	}
+
+	completions, directive := app.completeGistIDs(true)(nil, nil, "")
+
+	require.Empty(t, completions)
+	require.Equal(t, cobra.ShellCompDirectiveError, directive)
+}
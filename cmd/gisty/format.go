@@ -0,0 +1,284 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// Output formats of the --format flag.
+const (
+	formatJSON  = "json"
+	formatYAML  = "yaml"
+	formatTable = "table"
+	formatTSV   = "tsv"
+)
+
+var formats = []string{formatJSON, formatYAML, formatTable, formatTSV}
+
+var errOutputFlags = errors.New("--format, --template and --jq are mutually exclusive")
+
+// ----------------------------------------------------------------------------
+//  Type: output
+// ----------------------------------------------------------------------------
+
+// output holds the output flags of the read-style commands.
+type output struct {
+	// format is the value of the --format flag. If empty, the default
+	// human-readable output of the command is used.
+	format string
+	// template is the Go text/template given by the --template flag.
+	template string
+	// jq is the jq expression given by the --jq flag.
+	jq string
+}
+
+// tabular is the table representation of a value for the table and tsv formats.
+type tabular struct {
+	header []string
+	rows   [][]string
+}
+
+// addFlags registers the output flags to the command and validates them before
+// running the command.
+func (o *output) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&o.format, "format", "",
+		"Output format: {"+strings.Join(formats, "|")+"}")
+	cmd.Flags().StringVarP(&o.template, "template", "t", "",
+		"Format the output using a Go template. Lists are formatted per item")
+	cmd.Flags().StringVarP(&o.jq, "jq", "q", "",
+		"Filter the JSON output using a jq expression")
+
+	cmd.PreRunE = func(*cobra.Command, []string) error {
+		return o.validate()
+	}
+}
+
+// validate returns a usage error if the output flags are invalid.
+func (o *output) validate() error {
+	count := 0
+
+	for _, value := range []string{o.format, o.template, o.jq} {
+		if value != "" {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return &usageError{err: errOutputFlags}
+	}
+
+	if o.format != "" && !slices.Contains(formats, o.format) {
+		return &usageError{err: fmt.Errorf("invalid format %q. Valid formats: %s",
+			o.format, strings.Join(formats, ", "))}
+	}
+
+	return nil
+}
+
+// print writes the value to w in the requested format. table is called for the
+// table and tsv formats and text for the default output.
+func (o *output) print(w io.Writer, value any, table func() tabular, text func(io.Writer) error) error {
+	var err error
+
+	switch {
+	case o.template != "":
+		err = printTemplate(w, o.template, value)
+	case o.jq != "":
+		err = printJQ(w, o.jq, value)
+	case o.format == formatJSON:
+		err = printJSON(w, value)
+	case o.format == formatYAML:
+		err = printYAML(w, value)
+	case o.format == formatTable:
+		err = printTable(w, table(), true)
+	case o.format == formatTSV:
+		err = printTable(w, table(), false)
+	default:
+		err = text(w)
+	}
+
+	return wrapPrintErr(err)
+}
+
+// ----------------------------------------------------------------------------
+//  Printers
+// ----------------------------------------------------------------------------
+
+func printJSON(w io.Writer, value any) error {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(value) //nolint:wrapcheck // wrapped by the caller
+}
+
+func printYAML(w io.Writer, value any) error {
+	generic, err := toGeneric(value)
+	if err != nil {
+		return err
+	}
+
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2) //nolint:mnd // same indentation as the JSON output
+
+	err = enc.Encode(generic)
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	return enc.Close() //nolint:wrapcheck // wrapped by the caller
+}
+
+// printTemplate executes the template with the value. If the value is a slice,
+// the template is executed for each item. A newline is added after each
+// execution.
+func printTemplate(w io.Writer, text string, value any) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return &usageError{err: fmt.Errorf("invalid template: %w", err)}
+	}
+
+	items := []any{value}
+
+	if rv := reflect.ValueOf(value); rv.Kind() == reflect.Slice {
+		items = make([]any, rv.Len())
+		for index := range items {
+			items[index] = rv.Index(index).Interface()
+		}
+	}
+
+	for _, item := range items {
+		err = tmpl.Execute(w, item)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped by the caller
+		}
+
+		_, err = io.WriteString(w, "\n")
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped by the caller
+		}
+	}
+
+	return nil
+}
+
+// printJQ filters the JSON representation of the value with the jq expression.
+// Strings are printed as is and other results as compact JSON, one per line.
+func printJQ(w io.Writer, expression string, value any) error {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return &usageError{err: fmt.Errorf("invalid jq expression: %w", err)}
+	}
+
+	generic, err := toGeneric(value)
+	if err != nil {
+		return err
+	}
+
+	iter := query.Run(generic)
+
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+
+		if errJQ, isErr := result.(error); isErr {
+			return fmt.Errorf("jq error: %w", errJQ)
+		}
+
+		line, isString := result.(string)
+		if !isString {
+			encoded, err := gojq.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("failed to encode jq result: %w", err)
+			}
+
+			line = string(encoded)
+		}
+
+		_, err = fmt.Fprintln(w, line)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped by the caller
+		}
+	}
+}
+
+// printTable prints the rows aligned with a header or, if aligned is false, as
+// tab-separated values without a header.
+func printTable(w io.Writer, table tabular, aligned bool) error {
+	if !aligned {
+		for _, row := range table.rows {
+			_, err := fmt.Fprintln(w, strings.Join(escapeCells(row), "\t"))
+			if err != nil {
+				return err //nolint:wrapcheck // wrapped by the caller
+			}
+		}
+
+		return nil
+	}
+
+	const padding = 2
+
+	tabw := tabwriter.NewWriter(w, 0, 0, padding, ' ', 0)
+
+	_, err := fmt.Fprintln(tabw, strings.Join(table.header, "\t"))
+	if err != nil {
+		return err //nolint:wrapcheck // wrapped by the caller
+	}
+
+	for _, row := range table.rows {
+		_, err = fmt.Fprintln(tabw, strings.Join(escapeCells(row), "\t"))
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped by the caller
+		}
+	}
+
+	return tabw.Flush() //nolint:wrapcheck // wrapped by the caller
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+var cellEscaper = strings.NewReplacer(`\`, `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+// escapeCells escapes the tabs and newlines in the cells so that each row is
+// kept in a single line.
+func escapeCells(row []string) []string {
+	escaped := make([]string, len(row))
+	for index, cell := range row {
+		escaped[index] = cellEscaper.Replace(cell)
+	}
+
+	return escaped
+}
+
+// toGeneric converts the value to its JSON representation made of maps, slices
+// and primitive values. So the field names are the same as the JSON output.
+func toGeneric(value any) (any, error) {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode to JSON: %w", err)
+	}
+
+	var generic any
+
+	err = json.Unmarshal(encoded, &generic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JSON: %w", err)
+	}
+
+	return generic, nil
+}
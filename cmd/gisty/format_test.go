@@ -0,0 +1,131 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutput_validate(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	for _, args := range [][]string{
+		{commandList, "--format", "xml"},
+		{commandList, "--format", "json", "--jq", ".[]"},
+		{commandList, "--template", "{{.GistID}}", "--jq", ".[]"},
+		{commandList, "--template", "{{.GistID"},
+		{commandList, "--jq", ".[] |"},
+	} {
+		_, _, err := runApp(t, srv.NewGisty, args...)
+
+		require.Error(t, err, args)
+		require.Equal(t, exitUsage, exitCode(err), args)
+	}
+}
+
+func TestOutput_formats(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	for _, test := range []struct {
+		name string
+		want string
+		args []string
+	}{
+		{
+			name: "list json",
+			args: []string{commandList, "--format", "json"},
+			want: `[
+  {
+    "updated_at": "2026-06-01T00:00:00Z",
+    "id": "` + testGistID + `",
+    "description": "test gist",
+    "files": 2,
+    "public": true
+  }
+]
+`,
+		},
+		{
+			name: "list yaml",
+			args: []string{commandList, "--format", "yaml"},
+			want: `- description: test gist
+  files: 2
+  id: ` + testGistID + `
+  public: true
+  updated_at: "2026-06-01T00:00:00Z"
+`,
+		},
+		{
+			name: "list table",
+			args: []string{commandList, "--format", "table"},
+			want: "ID                                DESCRIPTION  FILES  VISIBILITY  UPDATED\n" +
+				testGistID + "  test gist    2      public      2026-06-01T00:00:00Z\n",
+		},
+		{
+			name: "list template",
+			args: []string{commandList, "--template", "{{.GistID}} {{.Files}}"},
+			want: testGistID + " 2\n",
+		},
+		{
+			name: "list jq",
+			args: []string{commandList, "--jq", ".[] | .description, .files"},
+			want: "test gist\n2\n",
+		},
+		{
+			name: "read tsv",
+			args: []string{"read", testGistID, "--format", "tsv"},
+			want: "hello.md\tMarkdown\t8\nmain.go\tGo\t13\n",
+		},
+		{
+			name: "read template",
+			args: []string{"read", testGistID, "--template", `{{(index .Files "main.go").Content}}`},
+			want: "package main\n\n",
+		},
+		{
+			name: "read file jq",
+			args: []string{"read", testGistID, "--file", "main.go", "--jq", ".language"},
+			want: "Go\n",
+		},
+		{
+			name: "comments tsv",
+			args: []string{"comments", testGistID, "--format", "tsv"},
+			want: "alice\t2026-06-02T00:00:00Z\tnice gist\n",
+		},
+		{
+			name: "comments jq",
+			args: []string{"comments", testGistID, "--jq", ".[0].author"},
+			want: `{"avatarUrl":"","login":"alice"}` + "\n",
+		},
+		{
+			name: "stars json",
+			args: []string{"stars", testGistID, "--format", "json"},
+			want: "3\n",
+		},
+	} {
+		stdout, _, err := runApp(t, srv.NewGisty, test.args...)
+
+		require.NoError(t, err, test.name)
+		require.Equal(t, test.want, stdout, test.name)
+	}
+}
+
+func TestOutput_template_exec_error(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+
+	_, _, err := runApp(t, srv.NewGisty, commandList, "--template", "{{.Unknown}}")
+
+	require.ErrorContains(t, err, "failed to print")
+	require.Equal(t, exitError, exitCode(err))
+}
+
+func TestEscapeCells(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, []string{`a\tb\nc\\d`}, escapeCells([]string{"a\tb\nc\\d"}))
+}
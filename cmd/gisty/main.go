@@ -1,4 +1,13 @@
-// Command gisty forwards gist operations to the GitHub CLI.
+/*
+Command gisty manages GitHub Gists from the command line using the gisty
+package.
+
+	gisty <command> [flags] [args]
+
+Run `gisty --help` to list the available commands. The exit status is 0 on
+success, 2 on invalid usage or arguments, 3 if the gist is not found, the exit
+status of the gh command if it failed, and 1 otherwise.
+*/
 package main
 
 import (
@@ -7,17 +16,29 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
+	"os/signal"
+	"path/filepath"
 
+	"github.com/KEINOS/go-gisty/gisty"
+	buildinfo "github.com/KEINOS/go-gisty/gisty/buildinfos"
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
 )
 
 var (
-	execCommandContext           = exec.CommandContext
-	exit                         = os.Exit
-	stdin              io.Reader = os.Stdin
-	stdout             io.Writer = os.Stdout
-	stderr             io.Writer = os.Stderr
+	exit                = os.Exit
+	stdin     io.Reader = os.Stdin
+	stdout    io.Writer = os.Stdout
+	stderr    io.Writer = os.Stderr
+	newGisty            = gisty.NewGisty
+	buildInfo           = buildinfo.Info
+)
+
+// Exit statuses other than the one of the failed gh command.
+const (
+	exitOK       = 0
+	exitError    = 1
+	exitUsage    = 2
+	exitNotFound = 3
 )
 
 func main() {
@@ -27,36 +48,79 @@ func main() {
 		Stderr: stderr,
 	})
 	if err != nil {
-		_, printErr := fmt.Fprintln(stderr, err)
-		if printErr != nil {
-			exit(exitCode(err))
-
-			return
-		}
+		//nolint:errcheck // nothing else to do if stderr is not writable
+		fmt.Fprintln(stderr, "gisty:", err)
 
 		exit(exitCode(err))
 	}
 }
 
+// exitCoder is implemented by the errors that carry the exit status of a child
+// process. Such as *exec.ExitError.
+type exitCoder interface {
+	ExitCode() int
+}
+
+// exitCode maps the error returned by run to the exit status.
 func exitCode(err error) int {
-	var exitErr *exec.ExitError
-	if errors.As(err, &exitErr) {
-		return exitErr.ExitCode()
+	var errUsage *usageError
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.As(err, &errUsage), errors.Is(err, gisty.ErrInvalidGistID), errors.Is(err, gisty.ErrTemplateVars):
+		return exitUsage
+	case errors.Is(err, gisty.ErrNotFound):
+		return exitNotFound
 	}
 
-	return 1
+	var errExit exitCoder
+	if errors.As(err, &errExit) && errExit.ExitCode() > 0 {
+		return errExit.ExitCode()
+	}
+
+	return exitError
 }
 
+// run executes the gisty command with the given arguments.
 func run(args []string, streams ghcmd.Streams) error {
-	err := ghcmd.Run(
-		context.Background(),
-		execCommandContext,
-		streams,
-		append([]string{"gist"}, args...)...,
-	)
-	if err != nil {
-		return fmt.Errorf("execute gh gist: %w", err)
+	app := &app{
+		newGisty: newGisty,
+		streams:  streams,
+		setErrPos: func(enable bool) {
+			gisty.AppendErrPos = enable
+		},
+		cacheDir:   "",
+		policyFile: os.Getenv("GISTY_POLICY"),
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
 	}
 
-	return nil
+	if dir, err := os.UserCacheDir(); err == nil {
+		app.cacheDir = filepath.Join(dir, "gisty")
+	}
+
+	if dir, err := os.UserConfigDir(); err == nil {
+		app.trustFile = filepath.Join(dir, "gisty", "trust.json")
+
+		// The default policy file is optional, unlike the one given explicitly.
+		if pathFile := filepath.Join(dir, "gisty", "policy.json"); app.policyFile == "" && fileExists(pathFile) {
+			app.policyFile = pathFile
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	cmd := app.newRootCmd()
+	cmd.SetArgs(args)
+
+	return cmd.ExecuteContext(ctx) //nolint:wrapcheck // errors are already wrapped by the commands
+}
+
+func fileExists(pathFile string) bool {
+	info, err := os.Stat(pathFile)
+
+	return err == nil && info.Mode().IsRegular()
 }
@@ -4,11 +4,15 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
 	"os/exec"
 	"strconv"
 	"testing"
+	"time"
 
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
 	"github.com/stretchr/testify/require"
 )
@@ -16,6 +20,8 @@ import (
 const (
 	commandGisty = "gisty"
 	commandList  = "list"
+
+	testGistID = "5b10b34f87955dfc86d310cd623a61d1"
 )
 
 var errForcedWrite = errors.New("forced write error")
@@ -26,50 +32,148 @@ func (errorWriter) Write([]byte) (int, error) {
 	return 0, errForcedWrite
 }
 
-//nolint:paralleltest // This test replaces the package-level command executor.
-func TestRun(t *testing.T) {
-	oldExecCommandContext := execCommandContext
-
-	t.Cleanup(func() {
-		execCommandContext = oldExecCommandContext
+// newTestServer returns a fake GitHub API server seeded with a gist.
+func newTestServer(t *testing.T) *gistytest.Server {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddGist(gistytest.Gist{
+		UpdatedAt:   time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Files:       map[string]string{"hello.md": "# Hello\n", "main.go": "package main\n"},
+		ID:          testGistID,
+		Description: "test gist",
+		Owner:       "",
+		Comments: []gisty.Comment{{
+			Author:    gisty.Author{AvatarURL: "", Login: "alice"},
+			BodyRaw:   "nice gist",
+			BodyText:  "nice gist",
+			CreatedAt: "2026-06-02T00:00:00Z",
+		}},
+		Stars:  3,
+		Forks:  0,
+		Public: true,
 	})
 
-	var gotArgs []string
+	return srv
+}
+
+// runApp runs the gisty command with the given Gisty constructor and returns
+// the standard output and error.
+func runApp(t *testing.T, newGisty func() *gisty.Gisty, args ...string) (string, string, error) {
+	t.Helper()
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	cmd := (&app{
+		newGisty: newGisty,
+		streams: ghcmd.Streams{
+			Stdin:  bytes.NewBuffer(nil),
+			Stdout: stdout,
+			Stderr: stderr,
+		},
+		setErrPos:  nil,
+		cacheDir:   t.TempDir(),
+		policyFile: "",
+		policies:   nil,
+		trustFile:  "",
+		debug:      false,
+	}).newRootCmd()
+	cmd.SetArgs(args)
+
+	err := cmd.Execute()
+
+	return stdout.String(), stderr.String(), err
+}
+
+// stubGH returns a Gisty constructor whose gh command executions are handled
+// by the given function.
+func stubGH(handle func(args []string) (string, error)) func() *gisty.Gisty {
+	return func() *gisty.Gisty {
+		obj := gisty.NewGisty()
+		obj.GHRunner = func(_ context.Context, cmd gisty.GHCommand) error {
+			out, err := handle(cmd.Args)
+
+			_, errPrint := fmt.Fprint(cmd.Stdout, out)
+			if errPrint != nil {
+				return errPrint
+			}
 
-	execCommandContext = func(ctx context.Context, _ string, args ...string) *exec.Cmd {
-		gotArgs = args
+			return err
+		}
 
-		return exec.CommandContext(ctx, "go", "version")
+		return obj
 	}
+}
+
+func TestExitCode(t *testing.T) {
+	t.Parallel()
+
+	for _, test := range []struct {
+		err  error
+		name string
+		want int
+	}{
+		{name: "nil", err: nil, want: exitOK},
+		{name: "usage", err: &usageError{err: errForcedWrite}, want: exitUsage},
+		{name: "invalid id", err: fmt.Errorf("wrap: %w", gisty.ErrInvalidGistID), want: exitUsage},
+		{name: "template vars", err: &gisty.TemplateVarsError{Missing: []string{"Name"}}, want: exitUsage},
+		{name: "not found", err: fmt.Errorf("wrap: %w", gisty.ErrNotFound), want: exitNotFound},
+		{name: "child", err: &gistytest.ExitError{Message: "failed", Code: 42}, want: 42},
+		{name: "other", err: errForcedWrite, want: exitError},
+	} {
+		require.Equal(t, test.want, exitCode(test.err), test.name)
+	}
+}
 
-	err := run([]string{commandList, "--limit=1"}, ghcmd.Streams{
-		Stdin:  bytes.NewBuffer(nil),
-		Stdout: new(bytes.Buffer),
-		Stderr: new(bytes.Buffer),
+//nolint:paralleltest // This test replaces package-level process dependencies.
+func TestMain_golden(t *testing.T) {
+	srv := newTestServer(t)
+
+	oldArgs := os.Args
+	oldNewGisty := newGisty
+	oldExit := exit
+	oldStdout := stdout
+
+	t.Cleanup(func() {
+		os.Args = oldArgs
+		newGisty = oldNewGisty
+		exit = oldExit
+		stdout = oldStdout
 	})
 
-	require.NoError(t, err)
-	require.Equal(t, []string{"gist", commandList, "--limit=1"}, gotArgs)
+	os.Args = []string{commandGisty, "stars", testGistID}
+	newGisty = srv.NewGisty
+
+	exitCalled := false
+	exit = func(int) {
+		exitCalled = true
+	}
+
+	stdoutBuffer := new(bytes.Buffer)
+	stdout = stdoutBuffer
+
+	main()
+
+	require.False(t, exitCalled, "exit should not be called on success")
+	require.Equal(t, "3\n", stdoutBuffer.String())
 }
 
 //nolint:paralleltest // This test replaces package-level process dependencies.
 func TestMain_error(t *testing.T) {
 	oldArgs := os.Args
-	oldExecCommandContext := execCommandContext
 	oldExit := exit
 	oldStderr := stderr
 
 	t.Cleanup(func() {
 		os.Args = oldArgs
-		execCommandContext = oldExecCommandContext
 		exit = oldExit
 		stderr = oldStderr
 	})
 
-	os.Args = []string{commandGisty, commandList}
-	execCommandContext = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
-		return exec.CommandContext(ctx, "go-gisty-command-not-found")
-	}
+	os.Args = []string{commandGisty, "unknown-command"}
 
 	var exitCode int
 
@@ -82,28 +186,23 @@ func TestMain_error(t *testing.T) {
 
 	main()
 
-	require.Equal(t, 1, exitCode)
-	require.Contains(t, stderrBuffer.String(), "executable file not found")
+	require.Equal(t, exitUsage, exitCode)
+	require.Contains(t, stderrBuffer.String(), "unknown-command")
 }
 
 //nolint:paralleltest // This test replaces package-level process dependencies.
 func TestMain_stderr_error(t *testing.T) {
 	oldArgs := os.Args
-	oldExecCommandContext := execCommandContext
 	oldExit := exit
 	oldStderr := stderr
 
 	t.Cleanup(func() {
 		os.Args = oldArgs
-		execCommandContext = oldExecCommandContext
 		exit = oldExit
 		stderr = oldStderr
 	})
 
-	os.Args = []string{commandGisty, commandList}
-	execCommandContext = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
-		return exec.CommandContext(ctx, "go-gisty-command-not-found")
-	}
+	os.Args = []string{commandGisty, commandList, "--unknown-flag"}
 
 	var exitCode int
 
@@ -115,28 +214,37 @@ func TestMain_stderr_error(t *testing.T) {
 
 	main()
 
-	require.Equal(t, 1, exitCode)
+	require.Equal(t, exitUsage, exitCode)
 }
 
 //nolint:paralleltest // This test replaces package-level process dependencies.
 func TestMain_preserves_child_exit_code(t *testing.T) {
 	oldArgs := os.Args
-	oldExecCommandContext := execCommandContext
+	oldNewGisty := newGisty
 	oldExit := exit
 	oldStderr := stderr
 	pathTestBinary := os.Args[0]
 
 	t.Cleanup(func() {
 		os.Args = oldArgs
-		execCommandContext = oldExecCommandContext
+		newGisty = oldNewGisty
 		exit = oldExit
 		stderr = oldStderr
 	})
 
 	os.Args = []string{commandGisty, commandList}
-	execCommandContext = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
-		//nolint:gosec // The path is the current controlled test binary.
-		return exec.CommandContext(ctx, pathTestBinary, "-test.run=TestHelperProcess", "--", "exit", "42")
+	newGisty = func() *gisty.Gisty {
+		obj := gisty.NewGisty()
+		obj.GHRunner = func(ctx context.Context, cmd gisty.GHCommand) error {
+			//nolint:gosec // The path is the current controlled test binary.
+			child := exec.CommandContext(ctx, pathTestBinary, "-test.run=TestHelperProcess", "--", "exit", "42")
+			child.Stdout = cmd.Stdout
+			child.Stderr = cmd.Stderr
+
+			return child.Run()
+		}
+
+		return obj
 	}
 
 	var exitCode int
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	buildinfo "github.com/KEINOS/go-gisty/gisty/buildinfos"
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/spf13/cobra"
+)
+
+// app holds the dependencies shared by the subcommands.
+type app struct {
+	// newGisty returns the Gisty instance used by each subcommand.
+	newGisty func() *gisty.Gisty
+	// streams are the standard streams of the command.
+	streams ghcmd.Streams
+	// setErrPos is called with the value of the --debug flag before running a
+	// subcommand. If nil, it is not called.
+	setErrPos func(enable bool)
+	// cacheDir is the directory of the gist list cached for the shell
+	// completion, the search index and the mirror of the gists. If empty,
+	// nothing is cached.
+	cacheDir string
+	// policyFile is the path of the JSON file of gisty.PolicyConfig applied to
+	// the Gisty instances. If empty, no policy is applied.
+	policyFile string
+	// policies are the policies loaded from policyFile.
+	policies []gisty.Policy
+	// trustFile is the path of the JSON file of the scripts trusted by
+	// "gisty run". If empty, no script can run.
+	trustFile string
+	// debug appends the file name and line number to the error messages.
+	debug bool
+}
+
+// usageError is returned when the command line arguments or flags are invalid.
+type usageError struct {
+	err error
+}
+
+// Error implements the error interface.
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// usageArgs wraps the positional arguments validator to return a usageError.
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		err := validate(cmd, args)
+		if err != nil {
+			return &usageError{err: err}
+		}
+
+		return nil
+	}
+}
+
+// newRootCmd returns the root command with all the subcommands.
+func (a *app) newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "gisty <command>",
+		Short: "Manage GitHub Gists",
+		Long: `Manage GitHub Gists from the command line.
+
+The "gh" command must be installed and authenticated, or the GH_TOKEN
+environment variable must be set with a token that has the "gist" scope.
+
+A <gist> argument is a gist ID or URL: the page, raw file or git clone URL of
+a gist on github.com or GitHub Enterprise Server. The URL must be of the host
+of gh, which is set by GH_HOST. "gisty run" also accepts a revision as
+"<id>@<revision>".
+
+The policies in the JSON file named by the GISTY_POLICY environment variable,
+or "gisty/policy.json" in the user config directory, are applied to all the
+commands which create or change gists. E.g. {"force_secret": true, "description_pattern": "OPS-\\d+"}.`,
+		Version:       buildinfo.Version,
+		Args:          usageArgs(cobra.NoArgs),
+		SilenceErrors: true,
+		SilenceUsage:  true,
+		PersistentPreRunE: func(*cobra.Command, []string) error {
+			if a.setErrPos != nil {
+				a.setErrPos(a.debug)
+			}
+
+			return a.loadPolicies()
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cmd.Help()
+		},
+	}
+
+	root.CompletionOptions.DisableDefaultCmd = true
+
+	root.SetIn(a.streams.Stdin)
+	root.SetOut(a.streams.Stdout)
+	root.SetErr(a.streams.Stderr)
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+
+	root.PersistentFlags().BoolVar(&a.debug, "debug", false,
+		"Show the source position of the errors")
+
+	root.AddCommand(
+		a.newListCmd(),
+		a.newReadCmd(),
+		a.newSearchCmd(),
+		a.newMirrorCmd(),
+		a.newCreateCmd(),
+		a.newTemplateCmd(),
+		a.newRunCmd(),
+		a.newVendorCmd(),
+		a.newDeleteCmd(),
+		a.newCloneCmd(),
+		a.newUpdateCmd(),
+		a.newPushCmd(),
+		a.newSyncCmd(),
+		a.newWatchCmd(),
+		a.newBackupCmd(),
+		a.newRestoreCmd(),
+		a.newMigrateCmd(),
+		a.newVisibilityCmd(),
+		a.newCommentsCmd(),
+		a.newStarsCmd(),
+		a.newStatsCmd(),
+		a.newVersionCmd(),
+		a.newCompletionCmd(),
+	)
+
+	return root
+}
+
+// gisty returns a new Gisty instance for a subcommand.
+func (a *app) gisty() *gisty.Gisty {
+	obj := a.newGisty()
+	obj.Policies = append(obj.Policies, a.policies...)
+
+	return obj
+}
+
+// loadPolicies loads the policies from a.policyFile.
+func (a *app) loadPolicies() error {
+	if a.policyFile == "" {
+		return nil
+	}
+
+	file, err := os.Open(a.policyFile)
+	if err != nil {
+		return fmt.Errorf("failed to open policy file: %w", err)
+	}
+
+	defer file.Close()
+
+	config, err := gisty.LoadPolicyConfig(file)
+	if err != nil {
+		return fmt.Errorf("failed to load policy file %s: %w", a.policyFile, err)
+	}
+
+	a.policies, err = config.Policies()
+	if err != nil {
+		return fmt.Errorf("failed to load policy file %s: %w", a.policyFile, err)
+	}
+
+	return nil
+}
+
+// relayStderr copies what the gh command wrote to the standard error of the
+// Gisty instance, so that its messages are not lost on failure.
+func (a *app) relayStderr(obj *gisty.Gisty, err error) error {
+	if obj.Stderr.Len() > 0 {
+		_, errPrint := fmt.Fprint(a.streams.Stderr, obj.Stderr.String())
+		if errPrint != nil && err == nil {
+			return fmt.Errorf("failed to write to stderr: %w", errPrint)
+		}
+	}
+
+	return err
+}
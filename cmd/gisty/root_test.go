@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRoot_help(t *testing.T) {
+	t.Parallel()
+
+	stdout, _, err := runApp(t, gisty.NewGisty, "--help")
+
+	require.NoError(t, err)
+
+	for _, name := range []string{"list", "read", "create", "delete", "clone", "update", "comments", "stars"} {
+		require.Contains(t, stdout, name, "help should list the %q command", name)
+	}
+
+	require.Contains(t, stdout, "--debug")
+}
+
+func TestRoot_no_args_prints_help(t *testing.T) {
+	t.Parallel()
+
+	stdout, _, err := runApp(t, gisty.NewGisty)
+
+	require.NoError(t, err)
+	require.Contains(t, stdout, "Available Commands")
+}
+
+func TestRoot_usage_errors(t *testing.T) {
+	t.Parallel()
+
+	for _, args := range [][]string{
+		{"unknown"},
+		{commandList, "--unknown-flag"},
+		{"read"},
+		{"stars", "a", "b"},
+	} {
+		_, _, err := runApp(t, gisty.NewGisty, args...)
+
+		require.Error(t, err, "args: %q", args)
+		require.Equal(t, exitUsage, exitCode(err), "args: %q, err: %v", args, err)
+	}
+}
+
+func TestRoot_policies(t *testing.T) {
+	t.Parallel()
+
+	srv := newTestServer(t)
+	dir := t.TempDir()
+	pathPolicy := filepath.Join(dir, "policy.json")
+	pathFile := filepath.Join(dir, "note.md")
+
+	require.NoError(t, os.WriteFile(pathPolicy, []byte(`{"force_secret": true, "description_pattern": "OPS-\\d+"}`), 0o600))
+	require.NoError(t, os.WriteFile(pathFile, []byte("note"), 0o600))
+
+	runWithPolicy := func(policyFile string, args ...string) error {
+		cmd := (&app{
+			newGisty: srv.NewGisty,
+			streams: ghcmd.Streams{
+				Stdin:  bytes.NewBuffer(nil),
+				Stdout: new(bytes.Buffer),
+				Stderr: new(bytes.Buffer),
+			},
+			setErrPos:  nil,
+			cacheDir:   "",
+			policyFile: policyFile,
+			policies:   nil,
+			trustFile:  "",
+			debug:      false,
+		}).newRootCmd()
+		cmd.SetArgs(args)
+
+		return cmd.Execute()
+	}
+
+	err := runWithPolicy(pathPolicy, "create", "--public", pathFile)
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.Len(t, srv.Gists(), 1)
+
+	err = runWithPolicy(pathPolicy, "create", "--public", "--desc", "OPS-42 note", pathFile)
+
+	require.NoError(t, err)
+
+	for _, gist := range srv.Gists() {
+		if gist.ID != testGistID {
+			require.False(t, gist.Public, "gist should be forced to be secret")
+		}
+	}
+
+	err = runWithPolicy(filepath.Join(dir, "missing.json"), "create", pathFile)
+
+	require.ErrorContains(t, err, "failed to open policy file")
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/spf13/cobra"
+)
+
+// secretScan holds the flags of the secret scanning for the commands which
+// publish files.
+type secretScan struct {
+	rules []string
+	allow bool
+}
+
+// addFlags adds the --allow-secrets and --secret-rule flags to cmd.
+func (s *secretScan) addFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&s.allow, "allow-secrets", false,
+		"Publish the files even if they seem to contain secrets such as tokens and keys")
+	cmd.Flags().StringArrayVar(&s.rules, "secret-rule", nil,
+		"Also treat the matches of the regular expression as secrets as `<name>=<regexp>`")
+}
+
+// apply sets the secret scanner with the custom rules to obj.
+func (s *secretScan) apply(obj *gisty.Gisty) error {
+	scanner := gisty.NewSecretScanner()
+
+	for _, rule := range s.rules {
+		name, pattern, ok := strings.Cut(rule, "=")
+		if !ok || name == "" || pattern == "" {
+			return &usageError{err: fmt.Errorf("invalid --secret-rule %q. <name>=<regexp> expected", rule)}
+		}
+
+		err := scanner.AddRule(name, pattern)
+		if err != nil {
+			return &usageError{err: err}
+		}
+	}
+
+	obj.SecretScanner = scanner
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package gisty
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+	ghauth "github.com/cli/go-gh/v2/pkg/auth"
+)
+
+// apiClient returns a client of the REST API of g.Host, or the default GitHub
+// host if empty, using the HTTP client of the factory.
+func (g *Gisty) apiClient() (*gistapi.Client, error) {
+	client, err := g.Factory.HttpClient()
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to create http client")
+	}
+
+	return gistapi.New(client, g.host()), nil
+}
+
+// host returns g.Host, or the default host of gh if empty. Which is GH_HOST,
+// the host logged in with gh or "github.com".
+func (g *Gisty) host() string {
+	if g.Host != "" {
+		return g.Host
+	}
+
+	// DefaultHost never fails. It returns the host and where it is set.
+	hostname, _ := ghauth.DefaultHost()
+	if hostname == "" {
+		return hostGitHub
+	}
+
+	return hostname
+}
+
+// wrapAPIErr wraps the error of the API client. Not found responses also wrap
+// ErrNotFound.
+func wrapAPIErr(err error, msgs ...any) error {
+	var errHTTP *gistapi.HTTPError
+
+	if errors.As(err, &errHTTP) && errHTTP.StatusCode == http.StatusNotFound {
+		err = errors.Join(ErrNotFound, err)
+	}
+
+	return WrapIfErr(err, msgs...)
+}
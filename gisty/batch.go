@@ -0,0 +1,170 @@
+package gisty
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/httpclient"
+	"github.com/cli/cli/v2/pkg/cmd/gist/shared"
+	"github.com/cli/cli/v2/pkg/iostreams"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: BatchOptions, BatchResult
+// ----------------------------------------------------------------------------
+
+// ConcurrencyDefault is the default number of the workers of the batch
+// operations.
+const ConcurrencyDefault = 4
+
+// BatchOptions are the options for the batch operations such as DeleteMany.
+type BatchOptions struct {
+	// Concurrency is the max number of gists processed at the same time. If
+	// less than 1, ConcurrencyDefault is used.
+	Concurrency int
+}
+
+// NewBatchOptions returns a new BatchOptions with the default values.
+func NewBatchOptions() BatchOptions {
+	return BatchOptions{
+		Concurrency: ConcurrencyDefault,
+	}
+}
+
+// BatchResult is the result of a batch operation for a gist.
+type BatchResult[T any] struct {
+	// Value is the result of the operation. Zero value on error.
+	Value T
+	// Err is the error of the operation. It is the error of the context if
+	// the operation was canceled before being started.
+	Err error
+	// GistID is the gist ID or URL given to the operation.
+	GistID string
+}
+
+// ----------------------------------------------------------------------------
+//  Methods
+// ----------------------------------------------------------------------------
+
+// DeleteMany deletes the given gists concurrently and returns the errors in the
+// same order as gistIDs. The error is nil for the deleted gists.
+//
+// Once ctx is canceled, the running deletions are stopped and the remaining
+// ones are not started.
+func (g *Gisty) DeleteMany(ctx context.Context, gistIDs []string, opts BatchOptions) []error {
+	results := runBatch(ctx, g, gistIDs, opts, func(obj *Gisty, gistID string) (struct{}, error) {
+		return struct{}{}, obj.Delete(gistID)
+	})
+
+	errs := make([]error, len(results))
+	for index, result := range results {
+		errs[index] = result.Err
+	}
+
+	return errs
+}
+
+// ReadMany reads the given gists concurrently and returns the results in the
+// same order as gistIDs.
+//
+// Once ctx is canceled, the running reads are stopped and the remaining ones
+// are not started.
+func (g *Gisty) ReadMany(ctx context.Context, gistIDs []string, opts BatchOptions) []BatchResult[*shared.Gist] {
+	return runBatch(ctx, g, gistIDs, opts, func(obj *Gisty, gistID string) (*shared.Gist, error) {
+		return obj.Read(gistID)
+	})
+}
+
+// StargazerMany returns the number of stars of the given gists concurrently in
+// the same order as gistIDs.
+//
+// Once ctx is canceled, the running requests are stopped and the remaining
+// ones are not started.
+func (g *Gisty) StargazerMany(ctx context.Context, gistIDs []string, opts BatchOptions) []BatchResult[int] {
+	return runBatch(ctx, g, gistIDs, opts, func(obj *Gisty, gistID string) (int, error) {
+		return obj.Stargazer(gistID)
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+// runBatch calls operate for each gist ID with a pool of workers. Each call
+// receives its own copy of g, since the I/O buffers of Gisty are not safe for
+// concurrent use.
+func runBatch[T any](
+	ctx context.Context,
+	g *Gisty,
+	gistIDs []string,
+	opts BatchOptions,
+	operate func(obj *Gisty, gistID string) (T, error),
+) []BatchResult[T] {
+	results := make([]BatchResult[T], len(gistIDs))
+	jobs := make(chan int)
+
+	workers := opts.Concurrency
+	if workers < 1 {
+		workers = ConcurrencyDefault
+	}
+
+	var waitGroup sync.WaitGroup
+
+	for range min(workers, len(gistIDs)) {
+		waitGroup.Go(func() {
+			for index := range jobs {
+				var zero T
+
+				results[index] = BatchResult[T]{Value: zero, Err: ctx.Err(), GistID: gistIDs[index]}
+				if ctx.Err() != nil {
+					continue
+				}
+
+				obj := g.fork(ctx)
+
+				value, err := operate(obj, gistIDs[index])
+				if err != nil {
+					value = zero
+
+					// Keep the message of the gh command, since the copy is discarded.
+					if msg := strings.TrimSpace(obj.Stderr.String()); msg != "" {
+						err = WrapIfErr(err, msg)
+					}
+				}
+
+				results[index].Value = value
+				results[index].Err = err
+			}
+		})
+	}
+
+	for index := range gistIDs {
+		jobs <- index
+	}
+
+	close(jobs)
+	waitGroup.Wait()
+
+	return results
+}
+
+// fork returns a copy of g with its own I/O streams, which runs the gh commands
+// and sends the HTTP requests with ctx.
+func (g *Gisty) fork(ctx context.Context) *Gisty {
+	ios, stdin, stdout, stderr := iostreams.Test()
+
+	factory := *g.Factory
+	factory.IOStreams = ios
+	factory.HttpClient = httpclient.WithContext(ctx, g.Factory.HttpClient)
+
+	obj := *g
+
+	obj.Factory = &factory
+	obj.Stdin = stdin
+	obj.Stdout = stdout
+	obj.Stderr = stderr
+	obj.ctx = ctx
+
+	return &obj
+}
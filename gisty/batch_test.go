@@ -0,0 +1,163 @@
+package gisty_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+// newBatchTestServer returns a fake server seeded with n gists and their IDs.
+func newBatchTestServer(t *testing.T, n int) (*gistytest.Server, []string) {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistIDs := make([]string, 0, n)
+
+	for index := range n {
+		gistIDs = append(gistIDs, srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			Description: "gist " + string(rune('A'+index)),
+			Files:       map[string]string{"file.txt": "content"},
+			Stars:       index,
+		}))
+	}
+
+	return srv, gistIDs
+}
+
+func TestGisty_ReadMany(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBatchTestServer(t, 5)
+	gistIDs = append(gistIDs, "ffffffffffffffffffffffffffffffff")
+
+	results := srv.NewGisty().ReadMany(context.Background(), gistIDs, gisty.BatchOptions{Concurrency: 3})
+
+	require.Len(t, results, len(gistIDs))
+
+	for index, result := range results[:5] {
+		require.NoError(t, result.Err)
+		require.Equal(t, gistIDs[index], result.GistID)
+		require.Equal(t, "gist "+string(rune('A'+index)), result.Value.Description, "results should be in order")
+	}
+
+	require.ErrorIs(t, results[5].Err, gisty.ErrNotFound)
+	require.Nil(t, results[5].Value)
+}
+
+// blockingTransport blocks the requests until their context is done.
+type blockingTransport struct {
+	started chan<- struct{}
+}
+
+func (b blockingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	b.started <- struct{}{}
+
+	<-req.Context().Done()
+
+	return nil, req.Context().Err()
+}
+
+func TestGisty_ReadMany_cancel_running(t *testing.T) {
+	t.Parallel()
+
+	started := make(chan struct{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj := gisty.NewGisty()
+	obj.Factory.HttpClient = func() (*http.Client, error) {
+		//nolint:exhaustruct // only the transport under test
+		return &http.Client{Transport: blockingTransport{started: started}}, nil
+	}
+
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	results := obj.ReadMany(ctx, []string{"0123456789abcdef"}, gisty.NewBatchOptions())
+
+	require.ErrorIs(t, results[0].Err, context.Canceled, "the running read should be stopped")
+}
+
+func TestGisty_StargazerMany(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBatchTestServer(t, 8)
+
+	results := srv.NewGisty().StargazerMany(context.Background(), gistIDs, gisty.NewBatchOptions())
+
+	for index, result := range results {
+		require.NoError(t, result.Err)
+		require.Equal(t, index, result.Value)
+	}
+}
+
+func TestGisty_DeleteMany(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBatchTestServer(t, 4)
+
+	errs := srv.NewGisty().DeleteMany(context.Background(), append(gistIDs, gistIDs[0]), gisty.BatchOptions{Concurrency: 0})
+
+	require.Len(t, errs, 5)
+	require.Empty(t, srv.Gists())
+
+	failed := 0
+
+	for _, err := range errs {
+		if err != nil {
+			failed++
+		}
+	}
+
+	require.Equal(t, 1, failed, "the duplicated gist should fail to delete once")
+}
+
+func TestGisty_DeleteMany_concurrency_and_cancel(t *testing.T) {
+	t.Parallel()
+
+	var running, maxRunning, started atomic.Int32
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj := gisty.NewGisty()
+	obj.GHRunner = func(ctx context.Context, _ gisty.GHCommand) error {
+		maxRunning.Store(max(maxRunning.Load(), running.Add(1)))
+		defer running.Add(-1)
+
+		if started.Add(1) == 4 {
+			cancel()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(10 * time.Millisecond):
+			return nil
+		}
+	}
+
+	gistIDs := make([]string, 20)
+	for index := range gistIDs {
+		gistIDs[index] = "0123456789abcdef"
+	}
+
+	errs := obj.DeleteMany(ctx, gistIDs, gisty.BatchOptions{Concurrency: 2})
+
+	require.Len(t, errs, len(gistIDs))
+	require.LessOrEqual(t, maxRunning.Load(), int32(2), "workers should be bounded")
+	require.LessOrEqual(t, started.Load(), int32(5), "no new deletion should start after cancellation")
+	require.NoError(t, errs[0])
+	require.ErrorIs(t, errs[len(errs)-1], context.Canceled)
+}
@@ -6,10 +6,20 @@ Sample command to build:
 
 	$ VER_APP="$(git describe --tag)"
 	$ go build -ldflags="-X 'main.Version=${VER_APP}'" ./path/to/main.go
+
+Info returns them along with the VCS, Go and dependency information embedded
+by the toolchain, and the version of the "gh" command on PATH.
 */
 package buildinfos
 
-import "runtime/debug"
+import (
+	"context"
+	"os/exec"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"time"
+)
 
 // Version is dynamically set by the toolchain or overridden by the Makefile.
 var Version = ""
@@ -39,3 +49,151 @@ func getVersion() string {
 
 	return "(devel)"
 }
+
+// ----------------------------------------------------------------------------
+//  Type: BuildInfo
+// ----------------------------------------------------------------------------
+
+// ModulePathCLI is the module path of the GitHub CLI that Gisty is built
+// against.
+const ModulePathCLI = "github.com/cli/cli/v2"
+
+// ghVersionTimeout is the time limit to get the version of the gh command.
+const ghVersionTimeout = 5 * time.Second
+
+// BuildInfo is the build and runtime information of Gisty.
+type BuildInfo struct {
+	Version      string   `json:"version"`
+	Date         string   `json:"date"`     // build date as YYYY-MM-DD. The commit date if not set.
+	Revision     string   `json:"revision"` // VCS revision of the build. Empty if unknown.
+	Dirty        bool     `json:"dirty"`    // true if built with uncommitted changes.
+	GoVersion    string   `json:"go_version"`
+	Platform     string   `json:"platform"`    // GOOS/GOARCH.
+	CLIVersion   string   `json:"cli_version"` // version of ModulePathCLI. Empty if unknown.
+	GHVersion    string   `json:"gh_version"`  // version of the gh command on PATH. Empty if not found.
+	Dependencies []Module `json:"dependencies"`
+}
+
+// Module is a module dependency of the build.
+type Module struct {
+	Path    string `json:"path"`
+	Version string `json:"version"`
+	// Replace is the path and version of the replacement, if replaced.
+	// E.g. "../cli" or "github.com/fork/cli/v2 v2.0.1".
+	Replace string `json:"replace,omitempty"`
+}
+
+// Info returns the build information of the current binary and the version of
+// the gh command on PATH, which is run with "--version".
+func Info() BuildInfo {
+	info := buildInfo()
+	info.GHVersion = ghVersion()
+
+	return info
+}
+
+// CLIVersion returns the version of ModulePathCLI that Gisty is built against,
+// or an empty string if unknown. E.g. in tests.
+func CLIVersion() string {
+	return buildInfo().CLIVersion
+}
+
+// UserAgent returns the agent of Gisty to send in the User-Agent header. E.g.
+// "go-gisty/v1.2.3 (0123abc; go1.26.1; linux/amd64)". It does not include the
+// gh version to avoid running gh.
+func UserAgent() string {
+	info := buildInfo()
+	details := []string{}
+
+	if info.Revision != "" {
+		revision := info.Revision[:min(len(info.Revision), 7)] //nolint:mnd // short SHA
+		if info.Dirty {
+			revision += "-dirty"
+		}
+
+		details = append(details, revision)
+	}
+
+	details = append(details, info.GoVersion, info.Platform)
+
+	return "go-gisty/" + info.Version + " (" + strings.Join(details, "; ") + ")"
+}
+
+// buildInfo returns the build information without the gh version.
+func buildInfo() BuildInfo {
+	info := BuildInfo{
+		Version:      getVersion(),
+		Date:         Date,
+		Revision:     "",
+		Dirty:        false,
+		GoVersion:    runtime.Version(),
+		Platform:     runtime.GOOS + "/" + runtime.GOARCH,
+		CLIVersion:   "",
+		GHVersion:    "",
+		Dependencies: []Module{},
+	}
+
+	debugInfo, ok := debugReadBuildInfo()
+	if !ok {
+		return info
+	}
+
+	if debugInfo.GoVersion != "" {
+		info.GoVersion = debugInfo.GoVersion
+	}
+
+	for _, setting := range debugInfo.Settings {
+		switch setting.Key {
+		case "vcs.revision":
+			info.Revision = setting.Value
+		case "vcs.modified":
+			info.Dirty = setting.Value == "true"
+		case "vcs.time":
+			if info.Date == "" {
+				info.Date, _, _ = strings.Cut(setting.Value, "T")
+			}
+		}
+	}
+
+	for _, dep := range debugInfo.Deps {
+		module := Module{Path: dep.Path, Version: dep.Version, Replace: ""}
+
+		if dep.Replace != nil {
+			module.Replace = strings.TrimSpace(dep.Replace.Path + " " + dep.Replace.Version)
+		}
+
+		if dep.Path == ModulePathCLI {
+			info.CLIVersion = dep.Version
+		}
+
+		info.Dependencies = append(info.Dependencies, module)
+	}
+
+	return info
+}
+
+// execCommandContext is a copy of exec.CommandContext to ease testing.
+var execCommandContext = exec.CommandContext
+
+// ghVersion returns the version of the gh command on PATH, or an empty string
+// if it is not found or fails. E.g. "2.40.1" from "gh version 2.40.1 (...)".
+func ghVersion() string {
+	ctx, cancel := context.WithTimeout(context.Background(), ghVersionTimeout)
+	defer cancel()
+
+	out, err := execCommandContext(ctx, "gh", "--version").Output()
+	if err != nil {
+		return ""
+	}
+
+	firstLine, _, _ := strings.Cut(string(out), "\n")
+
+	version, ok := strings.CutPrefix(firstLine, "gh version ")
+	if !ok {
+		return ""
+	}
+
+	version, _, _ = strings.Cut(version, " ")
+
+	return version
+}
@@ -1,6 +1,11 @@
 package buildinfos
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
 	"runtime/debug"
 	"testing"
 
@@ -89,3 +94,132 @@ func Test_getVersion_from_build_info_with_empty_version(t *testing.T) {
 
 	require.Equal(t, "(devel)", getVersion())
 }
+
+// mockBuildInfo replaces debugReadBuildInfo to return info during the test.
+func mockBuildInfo(t *testing.T, info *debug.BuildInfo) {
+	t.Helper()
+
+	oldDebugReadBuildInfo := debugReadBuildInfo
+
+	t.Cleanup(func() {
+		debugReadBuildInfo = oldDebugReadBuildInfo
+	})
+
+	debugReadBuildInfo = func() (*debug.BuildInfo, bool) {
+		return info, info != nil
+	}
+}
+
+// mockGH replaces execCommandContext to run TestGHHelperProcess, which prints
+// output as gh, during the test.
+func mockGH(t *testing.T, output string) {
+	t.Helper()
+
+	oldExecCommandContext := execCommandContext
+
+	t.Cleanup(func() {
+		execCommandContext = oldExecCommandContext
+	})
+
+	execCommandContext = func(ctx context.Context, _ string, _ ...string) *exec.Cmd {
+		//nolint:gosec // The helper executes the current test binary with controlled arguments.
+		cmd := exec.CommandContext(ctx, os.Args[0], "-test.run=TestGHHelperProcess")
+
+		cmd.Env = append(os.Environ(), "GO_WANT_GH_HELPER_PROCESS=1", "GH_HELPER_OUTPUT="+output)
+
+		return cmd
+	}
+}
+
+//nolint:paralleltest // This test is executed as a subprocess by mockGH.
+func TestGHHelperProcess(t *testing.T) {
+	if os.Getenv("GO_WANT_GH_HELPER_PROCESS") != "1" {
+		return
+	}
+
+	output := os.Getenv("GH_HELPER_OUTPUT")
+	if output == "" {
+		os.Exit(1)
+	}
+
+	_, err := fmt.Fprint(os.Stdout, output)
+	require.NoError(t, err)
+
+	os.Exit(0)
+}
+
+//nolint:paralleltest // This test is not parallel because it changes global variables.
+func TestInfo(t *testing.T) {
+	oldVersion, oldDate := Version, Date
+
+	t.Cleanup(func() {
+		Version, Date = oldVersion, oldDate
+	})
+
+	Version, Date = testVersion123, ""
+
+	mockGH(t, "gh version 2.40.1 (2023-12-13)\nhttps://github.com/cli/cli/releases/tag/v2.40.1\n")
+	//nolint:exhaustruct // this is a test
+	mockBuildInfo(t, &debug.BuildInfo{
+		GoVersion: "go1.26.1",
+		Deps: []*debug.Module{
+			{Path: ModulePathCLI, Version: "v2.97.0"},
+			{Path: "github.com/spf13/cobra", Version: "v1.9.1", Replace: &debug.Module{Path: "../cobra"}},
+		},
+		Settings: []debug.BuildSetting{
+			{Key: "vcs.revision", Value: "0123456789abcdef0123456789abcdef01234567"},
+			{Key: "vcs.time", Value: "2026-10-01T12:34:56Z"},
+			{Key: "vcs.modified", Value: "true"},
+		},
+	})
+
+	require.Equal(t, BuildInfo{
+		Version:    testVersion123,
+		Date:       "2026-10-01",
+		Revision:   "0123456789abcdef0123456789abcdef01234567",
+		Dirty:      true,
+		GoVersion:  "go1.26.1",
+		Platform:   runtime.GOOS + "/" + runtime.GOARCH,
+		CLIVersion: "v2.97.0",
+		GHVersion:  "2.40.1",
+		Dependencies: []Module{
+			{Path: ModulePathCLI, Version: "v2.97.0", Replace: ""},
+			{Path: "github.com/spf13/cobra", Version: "v1.9.1", Replace: "../cobra"},
+		},
+	}, Info())
+	require.Equal(t, "v2.97.0", CLIVersion())
+	require.Equal(t, "go-gisty/"+testVersion123+" (0123456-dirty; go1.26.1; "+runtime.GOOS+"/"+runtime.GOARCH+")",
+		UserAgent())
+}
+
+//nolint:paralleltest // This test is not parallel because it changes global variables.
+func TestInfo_unknown(t *testing.T) {
+	oldVersion, oldDate := Version, Date
+
+	t.Cleanup(func() {
+		Version, Date = oldVersion, oldDate
+	})
+
+	Version, Date = "", "2026-10-19"
+
+	mockGH(t, "") // gh fails
+	mockBuildInfo(t, nil)
+
+	info := Info()
+
+	require.Equal(t, "(devel)", info.Version)
+	require.Equal(t, "2026-10-19", info.Date, "the date set at build time should be kept")
+	require.Equal(t, runtime.Version(), info.GoVersion)
+	require.Empty(t, info.Revision)
+	require.Empty(t, info.CLIVersion)
+	require.Empty(t, info.GHVersion)
+	require.Empty(t, info.Dependencies)
+	require.Equal(t, "go-gisty/(devel) ("+runtime.Version()+"; "+runtime.GOOS+"/"+runtime.GOARCH+")", UserAgent())
+}
+
+//nolint:paralleltest // This test is not parallel because it changes global variables.
+func Test_ghVersion_unexpected_output(t *testing.T) {
+	mockGH(t, "hub version 2.14.2\n")
+
+	require.Empty(t, ghVersion())
+}
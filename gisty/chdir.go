@@ -13,7 +13,8 @@ var (
 // previous working directory.
 //
 // It is the callers choice to change the working directory back to the previous
-// working directory.
+// working directory. Since the working directory is shared by the whole
+// process, it is not safe to use while other goroutines depend on it.
 func ChDir(path string) (string, error) {
 	returnPath, err := osGetwd()
 	if err != nil {
@@ -0,0 +1,681 @@
+package gisty
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: BackupOptions, BackupManifest
+// ----------------------------------------------------------------------------
+
+// BackupFormat is the archive format of the backup.
+type BackupFormat string
+
+// Archive formats of the backup.
+const (
+	BackupTarGz BackupFormat = "tar.gz"
+	BackupZip   BackupFormat = "zip"
+)
+
+// BackupManifestVersion is the version of the manifest format written by
+// Backup.
+const BackupManifestVersion = 1
+
+// Paths in the backup archive.
+const (
+	backupManifestName = "manifest.json"
+	backupGistsDir     = "gists"
+	backupFilesDir     = "files"
+	backupRepoDir      = "repo"
+)
+
+// BackupOptions are the options for the Backup function.
+type BackupOptions struct {
+	// Format is the archive format. If empty, BackupTarGz is used.
+	Format BackupFormat
+	// WithComments includes the comments of the gists in the manifest.
+	WithComments bool
+	// WithHistory includes the git repositories of the gists, cloned with
+	// Clone. It requires git.
+	WithHistory bool
+}
+
+// NewBackupOptions returns a new BackupOptions with the default values.
+func NewBackupOptions() BackupOptions {
+	return BackupOptions{
+		Format:       BackupTarGz,
+		WithComments: false,
+		WithHistory:  false,
+	}
+}
+
+// BackupManifest is the content of the "manifest.json" file in the backup
+// archive.
+//
+// The files of each gist are stored in "gists/<id>/files/<name>" and, with
+// the history, the cloned repository in "gists/<id>/repo/".
+type BackupManifest struct {
+	CreatedAt time.Time    `json:"created_at"`
+	Gists     []BackupGist `json:"gists"`
+	Version   int          `json:"version"`
+}
+
+// BackupGist is a gist in the backup manifest.
+type BackupGist struct {
+	UpdatedAt   time.Time       `json:"updated_at"`
+	Files       []string        `json:"files"`
+	Comments    []BackupComment `json:"comments,omitempty"`
+	ID          string          `json:"id"`
+	Description string          `json:"description"`
+	HTMLURL     string          `json:"html_url"`
+	Public      bool            `json:"public"`
+	History     bool            `json:"history"` // true if the repository is archived.
+}
+
+// BackupComment is a comment of a gist in the backup manifest.
+type BackupComment struct {
+	CreatedAt time.Time `json:"created_at"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Backup
+// ----------------------------------------------------------------------------
+
+// Backup writes every gist owned by the authenticated user to w as an archive
+// with a JSON manifest and returns the manifest.
+//
+// The archive holds the files, the description and the visibility of the
+// gists. Optionally, the comments and the git repositories with the full
+// history. See BackupManifest for the layout of the archive.
+func (g *Gisty) Backup(w io.Writer, opts BackupOptions) (BackupManifest, error) {
+	ctx := context.Background()
+	manifest := BackupManifest{
+		CreatedAt: time.Now().UTC().Truncate(time.Second),
+		Gists:     []BackupGist{},
+		Version:   BackupManifestVersion,
+	}
+
+	archive, err := newArchiveWriter(w, opts.Format)
+	if err != nil {
+		return manifest, err
+	}
+
+	client, err := g.apiClient()
+	if err != nil {
+		return manifest, err
+	}
+
+	gists, err := client.ListGists(ctx)
+	if err != nil {
+		return manifest, wrapAPIErr(err, "failed to list gists")
+	}
+
+	for _, listed := range gists {
+		entry, err := g.backupGist(ctx, client, archive, listed.ID, opts)
+		if err != nil {
+			return manifest, err
+		}
+
+		manifest.Gists = append(manifest.Gists, entry)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return manifest, WrapIfErr(err, "failed to encode manifest")
+	}
+
+	err = archive.Add(backupManifestName, data)
+	if err != nil {
+		return manifest, err
+	}
+
+	return manifest, WrapIfErr(archive.Close(), "failed to close archive")
+}
+
+// backupGist adds the gist to the archive and returns its manifest entry.
+func (g *Gisty) backupGist(
+	ctx context.Context, client *gistapi.Client, archive archiveWriter, gistID string, opts BackupOptions,
+) (BackupGist, error) {
+	entry, contents, err := fetchGist(ctx, client, gistID, opts.WithComments)
+	if err != nil {
+		return entry, err
+	}
+
+	dirGist := path.Join(backupGistsDir, entry.ID)
+
+	for _, name := range entry.Files {
+		err = archive.Add(path.Join(dirGist, backupFilesDir, name), contents[name])
+		if err != nil {
+			return entry, err
+		}
+	}
+
+	if opts.WithHistory {
+		entry.History = true
+
+		err = g.backupRepo(archive, entry.ID, path.Join(dirGist, backupRepoDir))
+		if err != nil {
+			return entry, err
+		}
+	}
+
+	return entry, nil
+}
+
+// fetchGist returns the gist as a manifest entry and the contents of its files
+// by name. The comments are fetched if withComments is true.
+func fetchGist(
+	ctx context.Context, client *gistapi.Client, gistID string, withComments bool,
+) (BackupGist, map[string][]byte, error) {
+	gist, err := client.GetGist(ctx, gistID)
+	if err != nil {
+		return BackupGist{}, nil, wrapAPIErr(err, "failed to get gist: %s", gistID)
+	}
+
+	entry := BackupGist{
+		UpdatedAt:   gist.UpdatedAt,
+		Files:       sortedKeys(gist.Files),
+		Comments:    nil,
+		ID:          gist.ID,
+		Description: gist.Description,
+		HTMLURL:     gist.HTMLURL,
+		Public:      gist.Public,
+		History:     false,
+	}
+	contents := make(map[string][]byte, len(gist.Files))
+
+	for name, file := range gist.Files {
+		contents[name] = []byte(file.Content)
+
+		if file.Truncated {
+			contents[name], err = client.GetRaw(ctx, file.RawURL)
+			if err != nil {
+				return entry, nil, wrapAPIErr(err, "failed to get file %s of gist %s", name, gist.ID)
+			}
+		}
+	}
+
+	if withComments {
+		comments, err := client.ListComments(ctx, gist.ID)
+		if err != nil {
+			return entry, nil, wrapAPIErr(err, "failed to get comments of gist %s", gist.ID)
+		}
+
+		entry.Comments = make([]BackupComment, 0, len(comments))
+		for _, comment := range comments {
+			entry.Comments = append(entry.Comments, BackupComment{
+				CreatedAt: comment.CreatedAt,
+				Author:    comment.User.Login,
+				Body:      comment.Body,
+			})
+		}
+	}
+
+	return entry, contents, nil
+}
+
+// backupRepo clones the gist repository into a temporary directory and adds
+// it to the archive under dirArchive.
+func (g *Gisty) backupRepo(archive archiveWriter, gistID, dirArchive string) error {
+	dirTemp, err := os.MkdirTemp("", "gisty-backup-*")
+	if err != nil {
+		return WrapIfErr(err, "failed to create temporary directory")
+	}
+
+	defer os.RemoveAll(dirTemp)
+
+	args := NewCloneArgs(gistID)
+	args.Dir = filepath.Join(dirTemp, gistID)
+
+	cloned, err := g.Clone(args)
+	if err != nil {
+		return WrapIfErr(err, "failed to clone gist: %s", gistID)
+	}
+
+	dirRepo := cloned.Path
+
+	return WrapIfErr(filepath.WalkDir(dirRepo, func(pathFile string, entry fs.DirEntry, err error) error {
+		if err != nil || !entry.Type().IsRegular() {
+			return err
+		}
+
+		content, err := os.ReadFile(pathFile)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		rel, err := filepath.Rel(dirRepo, pathFile)
+		if err != nil {
+			return err //nolint:wrapcheck // wrapped below
+		}
+
+		return archive.Add(path.Join(dirArchive, filepath.ToSlash(rel)), content)
+	}), "failed to archive the repository of gist: %s", gistID)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: RestoreOptions, RestoreResult
+// ----------------------------------------------------------------------------
+
+// RestoreOptions are the options for the Restore function.
+type RestoreOptions struct {
+	// IDMap maps the IDs of the archived gists to the IDs of the existing gists
+	// to restore into. The description and the files of the mapped gists are
+	// replaced with the archived ones instead of creating new gists, and the
+	// comments already restored are not posted again. E.g. to resume an
+	// interrupted restore.
+	IDMap map[string]string
+	// Only is the list of the IDs of the archived gists to restore. If empty,
+	// all the gists are restored.
+	Only []string
+	// WithComments posts the archived comments to the restored gists as quoted
+	// text, since comments cannot be created on behalf of their authors.
+	WithComments bool
+	// AllowSecrets restores the gists even if Gisty.SecretScanner finds
+	// possible secrets in their files.
+	AllowSecrets bool
+}
+
+// NewRestoreOptions returns a new RestoreOptions with the default values.
+func NewRestoreOptions() RestoreOptions {
+	return RestoreOptions{
+		IDMap:        map[string]string{},
+		Only:         nil,
+		WithComments: false,
+		AllowSecrets: false,
+	}
+}
+
+// RestoreResult is the result of Restore.
+type RestoreResult struct {
+	// IDMap maps the IDs of the archived gists to the IDs of the restored ones.
+	IDMap map[string]string `json:"id_map"`
+	// URLs maps the IDs of the restored gists to their URLs.
+	URLs map[string]string `json:"urls"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Restore
+// ----------------------------------------------------------------------------
+
+// Restore recreates the gists from an archive written by Backup. The format of
+// the archive is detected from its content.
+//
+// Only the latest files, the descriptions and the visibility are restored. The
+// git history is not. On error, the result holds the gists restored so far.
+//
+// The gists are checked by g.Policies as a Create. If g.SecretScanner finds
+// possible secrets in the files of a gist, the gist is not restored and a
+// *SecretsError is returned, unless opts.AllowSecrets is true.
+func (g *Gisty) Restore(r io.Reader, opts RestoreOptions) (RestoreResult, error) {
+	result := RestoreResult{IDMap: map[string]string{}, URLs: map[string]string{}}
+
+	files, err := readArchive(r)
+	if err != nil {
+		return result, err
+	}
+
+	var manifest BackupManifest
+
+	err = json.Unmarshal(files[backupManifestName], &manifest)
+	if err != nil {
+		return result, WrapIfErr(err, "failed to parse %s of the archive", backupManifestName)
+	}
+
+	if manifest.Version != BackupManifestVersion {
+		return result, NewErr("unsupported manifest version: %d", manifest.Version)
+	}
+
+	client, err := g.apiClient()
+	if err != nil {
+		return result, err
+	}
+
+	for _, entry := range manifest.Gists {
+		if len(opts.Only) > 0 && !slices.Contains(opts.Only, entry.ID) {
+			continue
+		}
+
+		newGist, err := g.restoreGist(client, entry, files, opts)
+		if newGist != nil {
+			result.IDMap[entry.ID] = newGist.ID
+			result.URLs[newGist.ID] = newGist.HTMLURL
+		}
+
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// restoreGist creates or updates a gist from the archived entry.
+func (g *Gisty) restoreGist(
+	client *gistapi.Client, entry BackupGist, files map[string][]byte, opts RestoreOptions,
+) (*gistapi.Gist, error) {
+	contents := make(map[string][]byte, len(entry.Files))
+
+	for _, name := range entry.Files {
+		content, ok := files[path.Join(backupGistsDir, entry.ID, backupFilesDir, name)]
+		if !ok {
+			return nil, NewErr("file %s of gist %s is missing in the archive", name, entry.ID)
+		}
+
+		contents[name] = content
+	}
+
+	err := g.checkSecrets(contents, opts.AllowSecrets)
+	if err == nil {
+		err = g.checkPublishPolicies(&entry, contents)
+	}
+
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to restore gist: %s", entry.ID)
+	}
+
+	gist, err := publishGist(context.Background(), client, entry, contents, opts.IDMap[entry.ID], opts.WithComments)
+
+	return gist, WrapIfErr(err, "failed to restore gist: %s", entry.ID)
+}
+
+// checkPublishPolicies applies g.Policies to the gist of the entry as a Create
+// before it is published by publishGist. The entry is updated with the
+// description and the visibility rewritten by the policies.
+func (g *Gisty) checkPublishPolicies(entry *BackupGist, contents map[string][]byte) error {
+	args := CreateArgs{Description: entry.Description, FilePaths: nil, AsPublic: entry.Public, AllowSecrets: false}
+
+	err := g.checkCreateContents(&args, contents)
+	if err != nil {
+		return err
+	}
+
+	entry.Description = args.Description
+	entry.Public = args.AsPublic
+
+	return nil
+}
+
+// publishGist creates a gist with the files, the description and the
+// visibility of the entry. If targetID is not empty, the gist targetID is
+// updated instead to match the entry: the description and the files are
+// overwritten and the files not in contents are deleted. The visibility of an
+// existing gist cannot be changed.
+//
+// The comments of the entry are posted as quoted text if withComments is true.
+// The comments already posted to the gist targetID, by an interrupted restore
+// for example, are skipped.
+//
+// The returned gist is not nil if it was created or updated, even if posting
+// the comments failed.
+func publishGist(
+	ctx context.Context,
+	client *gistapi.Client,
+	entry BackupGist,
+	contents map[string][]byte,
+	targetID string,
+	withComments bool,
+) (*gistapi.Gist, error) {
+	edits := make(map[string]*gistapi.EditFile, len(contents))
+	for name, content := range contents {
+		edits[name] = &gistapi.EditFile{Content: string(content), Filename: ""}
+	}
+
+	var (
+		gist *gistapi.Gist
+		err  error
+	)
+
+	if targetID != "" {
+		gist, err = updateGist(ctx, client, targetID, entry.Description, edits)
+	} else {
+		gist, err = client.CreateGist(ctx, gistapi.NewGist{
+			Files:       edits,
+			Description: entry.Description,
+			Public:      entry.Public,
+		})
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !withComments {
+		return gist, nil
+	}
+
+	posted := map[string]int{}
+
+	if targetID != "" {
+		existing, err := client.ListComments(ctx, gist.ID)
+		if err != nil {
+			return gist, wrapAPIErr(err, "failed to get comments")
+		}
+
+		for _, comment := range existing {
+			posted[strings.TrimSpace(comment.Body)]++
+		}
+	}
+
+	for _, comment := range entry.Comments {
+		body := quoteComment(comment)
+
+		if key := strings.TrimSpace(body); posted[key] > 0 {
+			posted[key]--
+
+			continue
+		}
+
+		err = client.CreateComment(ctx, gist.ID, body)
+		if err != nil {
+			return gist, wrapAPIErr(err, "failed to post comments")
+		}
+	}
+
+	return gist, nil
+}
+
+// updateGist overwrites the description and the files of the gist with edits
+// and deletes the other files of the gist.
+func updateGist(
+	ctx context.Context, client *gistapi.Client, gistID, description string, edits map[string]*gistapi.EditFile,
+) (*gistapi.Gist, error) {
+	current, err := client.GetGist(ctx, gistID)
+	if err != nil {
+		return nil, wrapAPIErr(err)
+	}
+
+	for name := range current.Files {
+		if _, ok := edits[name]; !ok {
+			edits[name] = nil
+		}
+	}
+
+	gist, err := client.UpdateGist(ctx, gistID, description, edits)
+
+	return gist, wrapAPIErr(err)
+}
+
+// quoteComment returns the comment as a quoted text with its author and date.
+func quoteComment(comment BackupComment) string {
+	lines := strings.Split(strings.TrimRight(comment.Body, "\n"), "\n")
+
+	var body strings.Builder
+
+	body.WriteString("@" + comment.Author + " commented on " + comment.CreatedAt.Format(time.RFC3339) + ":\n\n")
+
+	for _, line := range lines {
+		body.WriteString(strings.TrimRight("> "+line, " ") + "\n")
+	}
+
+	return body.String()
+}
+
+// ----------------------------------------------------------------------------
+//  Archive formats
+// ----------------------------------------------------------------------------
+
+// archiveWriter adds files to an archive.
+type archiveWriter interface {
+	Add(name string, content []byte) error
+	Close() error
+}
+
+func newArchiveWriter(w io.Writer, format BackupFormat) (archiveWriter, error) {
+	switch format {
+	case BackupTarGz, "":
+		gzipWriter := gzip.NewWriter(w)
+
+		return &tarGzWriter{gzip: gzipWriter, tar: tar.NewWriter(gzipWriter)}, nil
+	case BackupZip:
+		return &zipWriter{zip: zip.NewWriter(w)}, nil
+	default:
+		return nil, NewErr("unsupported backup format: %s", format)
+	}
+}
+
+type tarGzWriter struct {
+	gzip *gzip.Writer
+	tar  *tar.Writer
+}
+
+func (a *tarGzWriter) Add(name string, content []byte) error {
+	err := a.tar.WriteHeader(&tar.Header{ //nolint:exhaustruct // the other fields are optional
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Size:     int64(len(content)),
+		Mode:     0o644, //nolint:mnd // regular file permission
+		ModTime:  time.Now(),
+	})
+	if err != nil {
+		return WrapIfErr(err, "failed to add %s to archive", name)
+	}
+
+	_, err = a.tar.Write(content)
+
+	return WrapIfErr(err, "failed to add %s to archive", name)
+}
+
+func (a *tarGzWriter) Close() error {
+	err := a.tar.Close()
+	if err != nil {
+		return WrapIfErr(err, "failed to close tar")
+	}
+
+	return WrapIfErr(a.gzip.Close(), "failed to close gzip")
+}
+
+type zipWriter struct {
+	zip *zip.Writer
+}
+
+func (a *zipWriter) Add(name string, content []byte) error {
+	writer, err := a.zip.Create(name)
+	if err != nil {
+		return WrapIfErr(err, "failed to add %s to archive", name)
+	}
+
+	_, err = writer.Write(content)
+
+	return WrapIfErr(err, "failed to add %s to archive", name)
+}
+
+func (a *zipWriter) Close() error {
+	return WrapIfErr(a.zip.Close(), "failed to close zip")
+}
+
+// readArchive returns the regular files in the tar.gz or zip archive by their
+// path.
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to read archive")
+	}
+
+	files := map[string][]byte{}
+
+	switch {
+	case bytes.HasPrefix(data, []byte{0x1f, 0x8b}): // gzip
+		gzipReader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to read gzip")
+		}
+
+		tarReader := tar.NewReader(gzipReader)
+
+		for {
+			header, err := tarReader.Next()
+			if err == io.EOF {
+				return files, nil
+			}
+
+			if err != nil {
+				return nil, WrapIfErr(err, "failed to read tar")
+			}
+
+			if header.Typeflag != tar.TypeReg {
+				continue
+			}
+
+			files[header.Name], err = io.ReadAll(tarReader)
+			if err != nil {
+				return nil, WrapIfErr(err, "failed to read %s in tar", header.Name)
+			}
+		}
+	case bytes.HasPrefix(data, []byte("PK")): // zip
+		zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to read zip")
+		}
+
+		for _, file := range zipReader.File {
+			if file.FileInfo().IsDir() {
+				continue
+			}
+
+			reader, err := file.Open()
+			if err != nil {
+				return nil, WrapIfErr(err, "failed to open %s in zip", file.Name)
+			}
+
+			files[file.Name], err = io.ReadAll(reader)
+			reader.Close()
+
+			if err != nil {
+				return nil, WrapIfErr(err, "failed to read %s in zip", file.Name)
+			}
+		}
+
+		return files, nil
+	default:
+		return nil, NewErr("unknown archive format. tar.gz or zip expected")
+	}
+}
+
+func sortedKeys[T any](items map[string]T) []string {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
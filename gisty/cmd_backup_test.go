@@ -0,0 +1,231 @@
+package gisty_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+// newBackupTestServer returns a fake server with two gists to back up and
+// their IDs.
+func newBackupTestServer(t *testing.T) (*gistytest.Server, []string) {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistIDs := []string{
+		srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			Description: "public gist",
+			Files:       map[string]string{"main.go": "package main\n", "README.md": "# Hello\n"},
+			Public:      true,
+			Comments: []gisty.Comment{{ //nolint:exhaustruct // only the fields under test
+				Author:    gisty.Author{AvatarURL: "", Login: "alice"},
+				BodyRaw:   "Nice!\nThanks.",
+				CreatedAt: "2026-01-02T03:04:05Z",
+			}},
+		}),
+		srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			Description: "secret gist",
+			Files:       map[string]string{"secret.txt": "s3cr3t"},
+		}),
+	}
+
+	return srv, gistIDs
+}
+
+// stubClone makes the gh clone command create a repository with a single file
+// in the given directory, and the git command print the commit of the clone.
+func stubClone(t *testing.T, obj *gisty.Gisty) {
+	t.Helper()
+
+	obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+		require.Equal(t, []string{"rev-parse", "HEAD"}, cmd.Args)
+
+		_, err := fmt.Fprintln(cmd.Stdout, "0123456789abcdef0123456789abcdef01234567")
+
+		return err
+	}
+
+	obj.GHRunner = func(_ context.Context, cmd gisty.GHCommand) error {
+		require.Equal(t, []string{"gist", "clone"}, cmd.Args[:2])
+
+		dir := cmd.Args[3]
+
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o700))
+
+		return os.WriteFile(filepath.Join(dir, ".git", "HEAD"), []byte("ref: refs/heads/main\n"), 0o600)
+	}
+}
+
+func archivedNames(t *testing.T, archive []byte) []string {
+	t.Helper()
+
+	gzipReader, err := gzip.NewReader(bytes.NewReader(archive))
+	require.NoError(t, err)
+
+	tarReader := tar.NewReader(gzipReader)
+	names := []string{}
+
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			return names
+		}
+
+		require.NoError(t, err)
+
+		names = append(names, header.Name)
+	}
+}
+
+func TestGisty_Backup_and_Restore(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []gisty.BackupFormat{gisty.BackupTarGz, gisty.BackupZip} {
+		t.Run(string(format), func(t *testing.T) {
+			t.Parallel()
+
+			srcSrv, gistIDs := newBackupTestServer(t)
+
+			var archive bytes.Buffer
+
+			opts := gisty.NewBackupOptions()
+			opts.Format = format
+			opts.WithComments = true
+
+			manifest, err := srcSrv.NewGisty().Backup(&archive, opts)
+
+			require.NoError(t, err)
+			require.Len(t, manifest.Gists, 2)
+			require.Equal(t, gisty.BackupManifestVersion, manifest.Version)
+
+			// Restore to another account.
+			dstSrv := gistytest.NewServer()
+			t.Cleanup(dstSrv.Close)
+
+			restoreOpts := gisty.NewRestoreOptions()
+			restoreOpts.WithComments = true
+
+			result, err := dstSrv.NewGisty().Restore(&archive, restoreOpts)
+
+			require.NoError(t, err)
+			require.Len(t, result.IDMap, 2)
+
+			for _, gistID := range gistIDs {
+				src, ok := srcSrv.Gist(gistID)
+				require.True(t, ok)
+
+				dst, ok := dstSrv.Gist(result.IDMap[gistID])
+				require.True(t, ok, "gist %s should be restored", gistID)
+				require.Equal(t, src.Files, dst.Files)
+				require.Equal(t, src.Description, dst.Description)
+				require.Equal(t, src.Public, dst.Public)
+				require.Len(t, dst.Comments, len(src.Comments))
+			}
+
+			dst, _ := dstSrv.Gist(result.IDMap[gistIDs[0]])
+			require.Equal(t,
+				"@alice commented on 2026-01-02T03:04:05Z:\n\n> Nice!\n> Thanks.\n",
+				dst.Comments[0].BodyRaw)
+		})
+	}
+}
+
+func TestGisty_Backup_with_history(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	stubClone(t, obj)
+
+	var archive bytes.Buffer
+
+	opts := gisty.NewBackupOptions()
+	opts.WithHistory = true
+
+	manifest, err := obj.Backup(&archive, opts)
+
+	require.NoError(t, err)
+	require.True(t, manifest.Gists[0].History)
+	require.Nil(t, manifest.Gists[0].Comments, "comments should not be included by default")
+	require.Contains(t, archivedNames(t, archive.Bytes()), "gists/"+gistIDs[1]+"/repo/.git/HEAD")
+	require.Contains(t, archivedNames(t, archive.Bytes()), "gists/"+gistIDs[1]+"/files/secret.txt")
+	require.Contains(t, archivedNames(t, archive.Bytes()), "manifest.json")
+}
+
+func TestGisty_Restore_id_map(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	var archive bytes.Buffer
+
+	backupOpts := gisty.NewBackupOptions()
+	backupOpts.WithComments = true
+
+	_, err := obj.Backup(&archive, backupOpts)
+	require.NoError(t, err)
+
+	// Resume the restore into the same account: the first gist is already
+	// restored as the existing one, the second is skipped.
+	target := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Description: "outdated",
+		Files:       map[string]string{"main.go": "outdated", "removed.txt": "not in the backup"},
+	})
+
+	opts := gisty.NewRestoreOptions()
+	opts.IDMap[gistIDs[0]] = target
+	opts.Only = []string{gistIDs[0]}
+	opts.WithComments = true
+
+	// The second restore resumes the first one.
+	for range 2 {
+		result, err := obj.Restore(bytes.NewReader(archive.Bytes()), opts)
+
+		require.NoError(t, err)
+		require.Equal(t, map[string]string{gistIDs[0]: target}, result.IDMap)
+	}
+
+	require.Len(t, srv.Gists(), 3)
+
+	gist, _ := srv.Gist(target)
+	require.Equal(t, "public gist", gist.Description)
+	require.Equal(t, map[string]string{"main.go": "package main\n", "README.md": "# Hello\n"}, gist.Files,
+		"the files not in the backup should be deleted")
+	require.Len(t, gist.Comments, 1, "the restored comments should not be posted again")
+}
+
+func TestGisty_Restore_invalid_archive(t *testing.T) {
+	t.Parallel()
+
+	obj := gisty.NewGisty()
+
+	_, err := obj.Restore(bytes.NewBufferString("not an archive"), gisty.NewRestoreOptions())
+
+	require.ErrorContains(t, err, "unknown archive format")
+}
+
+func TestGisty_Backup_unsupported_format(t *testing.T) {
+	t.Parallel()
+
+	_, err := gisty.NewGisty().Backup(io.Discard, gisty.BackupOptions{
+		Format:       "rar",
+		WithComments: false,
+		WithHistory:  false,
+	})
+
+	require.ErrorContains(t, err, "unsupported backup format: rar")
+}
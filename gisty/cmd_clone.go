@@ -1,23 +1,209 @@
 package gisty
 
 import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
 	"github.com/cli/cli/v2/pkg/cmd/gist/clone"
 )
 
-// Clone clones a gist with the given args.
-//
-//	[]string{
-//	  <gist>,             // required
-//	  [<directory>],      // optional
-//	  [-- <gitflags>...], // optional
-//	}
-//
-// <gist> is a gist ID or URL and <directory> is the directory to clone the gist
-// into. <gitflags> listed after '--' are the additional flags to pass directly
-// as a 'git clone' flags.
-func (g *Gisty) Clone(args []string) error {
-	return g.clone(args, g.AltFunctions.Clone)
+// CloneProtocol is the protocol to clone the gist repository with.
+type CloneProtocol string
+
+// Protocols of CloneArgs.Protocol.
+const (
+	// CloneProtocolDefault uses the "git_protocol" setting of gh.
+	CloneProtocolDefault CloneProtocol = ""
+	CloneProtocolHTTPS   CloneProtocol = "https"
+	CloneProtocolSSH     CloneProtocol = "ssh"
+)
+
+// CloneArgs are the arguments for the Clone function.
+type CloneArgs struct {
+	// Gist is the gist to clone, as accepted by ParseGistRef. The revision of
+	// the reference, if any, is checked out unless Revision is set.
+	Gist string
+	// Dir is the destination directory. It must not exist or be empty. If
+	// empty, the gist ID in the current directory is used.
+	Dir string
+	// Branch is the branch to check out instead of the default one.
+	Branch string
+	// Revision is the commit SHA, full or abbreviated, to check out after
+	// cloning, which leaves the repository in a detached HEAD state.
+	Revision string
+	// Protocol is the protocol of the clone URL.
+	Protocol CloneProtocol
+	// GitFlags are the additional flags to pass to "git clone".
+	// E.g. []string{"--quiet"}.
+	GitFlags []string
+	// Depth creates a shallow clone with the given number of commits. The full
+	// history is cloned if less than 1. It cannot be used with a revision.
+	Depth int
+}
+
+// NewCloneArgs returns a new CloneArgs to clone the given gist into the
+// default directory.
+func NewCloneArgs(gist string) CloneArgs {
+	return CloneArgs{
+		Gist:     gist,
+		Dir:      "",
+		Branch:   "",
+		Revision: "",
+		Protocol: CloneProtocolDefault,
+		GitFlags: nil,
+		Depth:    0,
+	}
+}
+
+// CloneResult is the result of Clone.
+type CloneResult struct {
+	Path   string `json:"path"`   // absolute path of the checkout.
+	Commit string `json:"commit"` // SHA of the checked-out commit.
+}
+
+// Clone clones a gist with the given args and returns where and at which
+// commit it was checked out.
+func (g *Gisty) Clone(args CloneArgs) (CloneResult, error) {
+	result := CloneResult{Path: "", Commit: ""}
+
+	ref, err := ParseGistRef(args.Gist)
+	if err != nil {
+		return result, err
+	}
+
+	revision := args.Revision
+
+	switch {
+	case revision != "" && !reGistRevision.MatchString(revision):
+		// Also keeps the revision from being taken as an option of git.
+		return result, WrapIfErr(ErrInvalidGistID, "invalid revision to check out: %q", revision)
+	case revision == "":
+		revision = ref.Revision
+	case ref.Revision != "" && ref.Revision != revision:
+		return result, NewErr("revision %s conflicts with the revision of the gist: %q", revision, args.Gist)
+	}
+
+	if revision != "" && args.Depth > 0 {
+		return result, NewErr("depth cannot be used with a revision, since the revision may not be in a shallow clone")
+	}
+
+	cloneURL, err := g.cloneURL(ref, args.Protocol)
+	if err != nil {
+		return result, err
+	}
+
+	dir := args.Dir
+	if dir == "" {
+		dir = ref.ID
+	}
+
+	result.Path, err = filepath.Abs(dir)
+	if err != nil {
+		return result, WrapIfErr(err, "failed to get absolute path of: %s", dir)
+	}
+
+	err = checkCloneDir(result.Path)
+	if err != nil {
+		return result, err
+	}
+
+	gitFlags := []string{}
+
+	if args.Depth > 0 {
+		gitFlags = append(gitFlags, "--depth="+strconv.Itoa(args.Depth))
+	}
+
+	if args.Branch != "" {
+		gitFlags = append(gitFlags, "--branch="+args.Branch)
+	}
+
+	gitFlags = append(gitFlags, args.GitFlags...)
+
+	argsClone := []string{cloneURL, result.Path}
+	if len(gitFlags) > 0 {
+		argsClone = append(append(argsClone, "--"), gitFlags...)
+	}
+
+	err = g.clone(argsClone, g.AltFunctions.Clone)
+	if err != nil {
+		return result, err
+	}
+
+	if revision != "" {
+		_, err = g.runGit(result.Path, "checkout", "--quiet", "--detach", revision)
+		if err != nil {
+			return result, WrapIfErr(err, "failed to check out revision %s of gist %s", revision, ref.ID)
+		}
+	}
+
+	result.Commit, err = g.runGit(result.Path, "rev-parse", "HEAD")
+
+	return result, WrapIfErr(err, "failed to get the checked-out commit of gist: %s", ref.ID)
+}
+
+// cloneURL returns the gist reference to pass to "gh gist clone". It is the
+// gist ID, so that gh chooses the protocol, unless the protocol or the host is
+// given.
+func (g *Gisty) cloneURL(ref GistRef, protocol CloneProtocol) (string, error) {
+	host := ref.Host
+	if host == "" {
+		if protocol == CloneProtocolDefault {
+			return ref.ID, nil
+		}
+
+		host = g.host()
+	}
+
+	switch {
+	case protocol == CloneProtocolSSH && host == hostGitHub:
+		return "git@gist.github.com:" + ref.ID + ".git", nil
+	case protocol == CloneProtocolSSH:
+		return "git@" + host + ":gist/" + ref.ID + ".git", nil
+	case protocol != CloneProtocolDefault && protocol != CloneProtocolHTTPS:
+		return "", NewErr("unsupported clone protocol: %q", protocol)
+	case host == hostGitHub:
+		return "https://gist.github.com/" + ref.ID + ".git", nil
+	}
+
+	return "https://" + host + "/gist/" + ref.ID + ".git", nil
+}
+
+// checkCloneDir returns an error if dir exists and is not an empty directory.
+func checkCloneDir(dir string) error {
+	info, err := os.Stat(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	if err != nil {
+		return WrapIfErr(err, "failed to check the destination directory")
+	}
+
+	if !info.IsDir() {
+		return NewErr("destination is not a directory: %s", dir)
+	}
+
+	dirFile, err := os.Open(dir)
+	if err != nil {
+		return WrapIfErr(err, "failed to check the destination directory")
+	}
+
+	defer dirFile.Close()
+
+	_, err = dirFile.Readdirnames(1)
+	if errors.Is(err, io.EOF) {
+		return nil
+	}
+
+	if err != nil {
+		return WrapIfErr(err, "failed to check the destination directory")
+	}
+
+	return NewErr("destination directory is not empty: %s", dir)
 }
 
 // clone is a wrapper around the clone command from the gh cli.
@@ -1,27 +1,174 @@
 package gisty
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/cli/cli/v2/pkg/cmd/gist/clone"
 	"github.com/stretchr/testify/require"
 )
 
+// stubCloneCommands records the gh and git commands run by Clone. The gh clone
+// command creates the destination directory and "git rev-parse" prints commit.
+func stubCloneCommands(t *testing.T, obj *Gisty, commit string) *[][]string {
+	t.Helper()
+
+	calls := [][]string{}
+
+	obj.GHRunner = func(_ context.Context, cmd GHCommand) error {
+		calls = append(calls, cmd.Args)
+
+		return os.MkdirAll(cmd.Args[3], 0o700)
+	}
+	obj.GitRunner = func(_ context.Context, cmd GitCommand) error {
+		calls = append(calls, cmd.Args)
+
+		if cmd.Args[0] == "rev-parse" {
+			_, err := fmt.Fprintln(cmd.Stdout, commit)
+
+			return err
+		}
+
+		return nil
+	}
+
+	return &calls
+}
+
+func TestGisty_Clone(t *testing.T) {
+	t.Parallel()
+
+	const (
+		gistID = "7101f542be23e5048198e2a27c3cfda8"
+		commit = "0123456789abcdef0123456789abcdef01234567"
+	)
+
+	dir := filepath.Join(t.TempDir(), "out")
+
+	for _, test := range []struct {
+		want []string
+		args CloneArgs
+	}{
+		{
+			args: CloneArgs{Gist: gistID, Dir: dir, Branch: "", Revision: "", Protocol: "", GitFlags: nil, Depth: 0},
+			want: []string{"gist", "clone", gistID, dir},
+		},
+		{
+			args: CloneArgs{
+				Gist: "https://gist.github.com/octocat/" + gistID, Dir: dir, Branch: "dev",
+				Revision: "", Protocol: CloneProtocolSSH, GitFlags: []string{"--quiet"}, Depth: 1,
+			},
+			want: []string{
+				"gist", "clone", "git@gist.github.com:" + gistID + ".git", dir,
+				"--", "--depth=1", "--branch=dev", "--quiet",
+			},
+		},
+		{
+			args: CloneArgs{
+				Gist: "https://github.example.com/gist/octocat/" + gistID, Dir: dir, Branch: "",
+				Revision: "", Protocol: CloneProtocolDefault, GitFlags: nil, Depth: 0,
+			},
+			want: []string{"gist", "clone", "https://github.example.com/gist/" + gistID + ".git", dir},
+		},
+	} {
+		require.NoError(t, os.RemoveAll(dir))
+
+		obj := NewGisty()
+		calls := stubCloneCommands(t, obj, commit)
+
+		result, err := obj.Clone(test.args)
+
+		require.NoError(t, err)
+		require.Equal(t, CloneResult{Path: dir, Commit: commit}, result)
+		require.Equal(t, [][]string{test.want, {"rev-parse", "HEAD"}}, *calls)
+	}
+}
+
+func TestGisty_Clone_revision(t *testing.T) {
+	t.Parallel()
+
+	const revision = "abcdef0123456789abcdef0123456789abcdef01"
+
+	obj := NewGisty()
+	obj.Host = "github.com"
+	calls := stubCloneCommands(t, obj, revision)
+
+	args := NewCloneArgs("7101f542be23e5048198e2a27c3cfda8@" + revision)
+	args.Dir = t.TempDir() // empty directory
+	args.Protocol = CloneProtocolHTTPS
+
+	result, err := obj.Clone(args)
+
+	require.NoError(t, err)
+	require.Equal(t, revision, result.Commit)
+	require.Equal(t, [][]string{
+		{"gist", "clone", "https://gist.github.com/7101f542be23e5048198e2a27c3cfda8.git", args.Dir},
+		{"checkout", "--quiet", "--detach", revision},
+		{"rev-parse", "HEAD"},
+	}, *calls)
+}
+
+func TestGisty_Clone_invalid_args(t *testing.T) {
+	t.Parallel()
+
+	dirNotEmpty := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dirNotEmpty, "file"), nil, 0o600))
+
+	obj := NewGisty()
+	calls := stubCloneCommands(t, obj, "")
+
+	for _, test := range []struct {
+		wantErr string
+		args    CloneArgs
+	}{
+		{"invalid gist ID", CloneArgs{Gist: "", Dir: "", Branch: "", Revision: "", Protocol: "", GitFlags: nil, Depth: 0}},
+		{"destination directory is not empty", CloneArgs{
+			Gist: "abc", Dir: dirNotEmpty, Branch: "", Revision: "", Protocol: "", GitFlags: nil, Depth: 0,
+		}},
+		{"destination is not a directory", CloneArgs{
+			Gist: "abc", Dir: filepath.Join(dirNotEmpty, "file"), Branch: "", Revision: "", Protocol: "", GitFlags: nil, Depth: 0,
+		}},
+		{"depth cannot be used with a revision", CloneArgs{
+			Gist: "abc", Dir: "", Branch: "", Revision: "1234", Protocol: "", GitFlags: nil, Depth: 1,
+		}},
+		{"conflicts with the revision", CloneArgs{
+			Gist: "abc@5678", Dir: "", Branch: "", Revision: "1234", Protocol: "", GitFlags: nil, Depth: 0,
+		}},
+		{"invalid revision to check out: \"--orphan=x\"", CloneArgs{
+			Gist: "abc", Dir: "", Branch: "", Revision: "--orphan=x", Protocol: "", GitFlags: nil, Depth: 0,
+		}},
+		{"invalid revision to check out: \"main\"", CloneArgs{
+			Gist: "abc", Dir: "", Branch: "", Revision: "main", Protocol: "", GitFlags: nil, Depth: 0,
+		}},
+		{"unsupported clone protocol", CloneArgs{
+			Gist: "abc", Dir: "", Branch: "", Revision: "", Protocol: "ftp", GitFlags: nil, Depth: 0,
+		}},
+	} {
+		_, err := obj.Clone(test.args)
+
+		require.ErrorContains(t, err, test.wantErr)
+	}
+
+	require.Empty(t, *calls, "nothing should be run on invalid args")
+}
+
 func TestGisty_Clone_msg_on_error(t *testing.T) {
 	t.Parallel()
 
 	obj := NewGisty()
 
 	// Execute the clone command.
-	targetGist := "https://gist.github.com/7101f542be23e5048198e2a27c3cfda8.git"
-	outDir := t.TempDir()
-	args := []string{targetGist, outDir}
+	args := NewCloneArgs("https://gist.github.com/7101f542be23e5048198e2a27c3cfda8.git")
+	args.Dir = t.TempDir()
 
 	obj.AltFunctions.Clone = func(*clone.CloneOptions) error {
 		return NewErr("forced error for cloning")
 	}
 
-	err := obj.Clone(args)
+	_, err := obj.Clone(args)
 
 	// Assert that the clone command failed.
 	require.Error(t, err)
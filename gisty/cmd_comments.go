@@ -13,10 +13,15 @@ import (
 // ----------------------------------------------------------------------------
 
 // DummyID is the ID of the dummy gist used in the example of the test.
+//
+// Deprecated: Comments no longer returns DummyComment for DummyID. Use the fake
+// server of the gistytest package to test the code that uses Gisty.
 const DummyID = "42f5f23053ab59ca480f480b8d01e1fd"
 
 // DummyComment is the dummy comment used in the example of the test.
 //
+// Deprecated: Use the fake server of the gistytest package instead.
+//
 //nolint:lll // long line is intentional
 var DummyComment = Comment{
 	Author: Author{
@@ -71,14 +76,24 @@ type Comment struct {
 // ----------------------------------------------------------------------------
 
 // Comments returns the comments in the gist.
+//
+// If g.Store is set, the comments are read from the store when g.ReadFromCache
+// is true or when GitHub cannot be reached. See readStoreComments for the fields
+// available offline.
 func (g *Gisty) Comments(gistID string) ([]Comment, error) {
-	// Return dummy data if the gist ID is the dummy ID to avoid unwanted request
-	// in the example of the test.
-	if gistID == DummyID {
-		return []Comment{DummyComment}, nil
+	if g.Store != nil && g.ReadFromCache {
+		return g.readStoreComments(gistID)
 	}
 
-	return g.comments(gistID, g.AltFunctions.Comments)
+	comments, err := g.comments(gistID, g.AltFunctions.Comments)
+	if err != nil && g.Store != nil && isNetworkErr(err) {
+		cached, errStore := g.readStoreComments(gistID)
+		if errStore == nil {
+			return cached, nil
+		}
+	}
+
+	return comments, err
 }
 
 const tplQueryComments = `
@@ -113,15 +128,16 @@ const tplQueryComments = `
 //
 // If altF is not nil, it will be used instead of the default function.
 func (g *Gisty) comments(gistID string, runF func(*api.ApiOptions) error) ([]Comment, error) {
-	gistID = SanitizeGistID(gistID) // sanitize to avoid unwanted query to request
-	if gistID == "" {
-		return nil, NewErr("invalid gist ID")
+	gistID, err := g.gistIDFromArg(gistID) // validated to avoid unwanted query to request
+	if err != nil {
+		return nil, err
 	}
 
 	query := heredoc.Docf(tplQueryComments, gistID, g.MaxComment)
 
 	argv := []string{
 		"graphql",
+		"--hostname", g.host(),
 		"-f", "query=" + query,
 		"--jq", "[.data.viewer.gist.comments.edges[].node]",
 	}
@@ -144,7 +160,7 @@ func (g *Gisty) comments(gistID string, runF func(*api.ApiOptions) error) ([]Com
 
 	var nodes []Comment
 
-	err := json.Unmarshal(response, &nodes)
+	err = json.Unmarshal(response, &nodes)
 	if err != nil {
 		return nil, WrapIfErr(err, "failed to parse GitHub API response. malformed JSON")
 	}
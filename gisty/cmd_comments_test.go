@@ -22,6 +22,7 @@ func TestGisty_Comments_bad_id(t *testing.T) {
 		"invalid gist ID (non hexdecimal characters) should return error")
 	require.Nil(t, listComments,
 		"returned slice of comment objects should be nil on error")
+	require.ErrorIs(t, err, ErrInvalidGistID)
 }
 
 func TestGisty_comments_golden(t *testing.T) {
@@ -53,6 +54,29 @@ func TestGisty_comments_golden(t *testing.T) {
 		"returned slice of comment objects should not be nil")
 }
 
+func TestGisty_comments_host(t *testing.T) {
+	t.Parallel()
+
+	obj := NewGisty()
+	obj.Host = "ghe.example.com"
+
+	hostname := ""
+
+	obj.AltFunctions.Comments = func(opts *api.ApiOptions) error {
+		hostname = opts.Hostname
+
+		fmt.Fprint(obj.Stdout, "[]")
+
+		return nil
+	}
+
+	_, err := obj.Comments("abcdef1234567890")
+
+	require.NoError(t, err)
+	require.Equal(t, "ghe.example.com", hostname,
+		"the comments should be requested to the host of Gisty")
+}
+
 func TestGisty_comments_execute_error(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package gisty
 
 import (
 	"net/url"
+	"os"
 	"strings"
 
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
@@ -17,10 +18,34 @@ type CreateArgs struct {
 	// AsPublic indicates whether this gist should be public or secret.
 	// By default, it is secret.
 	AsPublic bool
+	// AllowSecrets creates the gist even if Gisty.SecretScanner finds possible
+	// secrets in the files.
+	AllowSecrets bool
 }
 
 // Create creates a new gist with the given args and returns the URL of the gist.
+//
+// The args are checked by g.Policies first. Then, if g.SecretScanner is set,
+// the files are scanned and a *SecretsError is returned if possible secrets are
+// found, unless args.AllowSecrets is true.
 func (g *Gisty) Create(args CreateArgs) (*url.URL, error) {
+	err := g.checkCreatePolicies(&args)
+	if err != nil {
+		return nil, err
+	}
+
+	if g.SecretScanner != nil && !args.AllowSecrets {
+		files, err := g.readFilesToCreate(args.FilePaths)
+		if err != nil {
+			return nil, err
+		}
+
+		err = g.checkSecrets(files, args.AllowSecrets)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	argsCreate := []string{}
 
 	if args.AsPublic {
@@ -63,3 +88,26 @@ func (g *Gisty) create(args []string, altF func(*create.CreateOptions) error) (*
 
 	return gistURL, WrapIfErr(err, "failed to parse gist URL")
 }
+
+// readFilesToCreate returns the contents of the files by path. "-" is the
+// standard input.
+func (g *Gisty) readFilesToCreate(filePaths []string) (map[string][]byte, error) {
+	files := make(map[string][]byte, len(filePaths))
+
+	for _, filePath := range filePaths {
+		if filePath == "-" {
+			files[filePath] = g.Stdin.Bytes()
+
+			continue
+		}
+
+		content, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to read file to scan")
+		}
+
+		files[filePath] = content
+	}
+
+	return files, nil
+}
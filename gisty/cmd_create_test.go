@@ -24,7 +24,8 @@ func TestGisty_Create_msg_on_error(t *testing.T) {
 			filepath.Join("testdata", "foo.md"),
 			filepath.Join("testdata", "bar.md"),
 		},
-		AsPublic: true,
+		AsPublic:     true,
+		AllowSecrets: false,
 	}
 
 	gistURL, err := obj.Create(argsCreate)
@@ -56,7 +57,8 @@ func TestGisty_Create_unexpected_response(t *testing.T) {
 			filepath.Join("testdata", "foo.md"),
 			filepath.Join("testdata", "bar.md"),
 		},
-		AsPublic: true,
+		AsPublic:     true,
+		AllowSecrets: false,
 	}
 
 	gistURL, err := obj.Create(argsCreate)
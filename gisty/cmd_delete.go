@@ -9,7 +9,8 @@ import (
 // interactively. It confirms deletion without prompting.
 const argOptYes = "--yes"
 
-// Delete deletes a gist for a given gist ID or URL.
+// Delete deletes a gist for a given gist ID or URL. See ParseGistRef for the
+// accepted forms.
 //
 // Note that it will remove the gist right away, without any confirmation.
 func (g *Gisty) Delete(gist string) error {
@@ -20,6 +21,13 @@ func (g *Gisty) Delete(gist string) error {
 //
 // If altF is not nil, it will be used instead of the default delete function.
 func (g *Gisty) delete(gist string, altF func(*delete.DeleteOptions) error) error {
+	gistID, err := g.gistIDFromArg(gist)
+	if err != nil {
+		return err
+	}
+
+	gist = gistID
+
 	if altF == nil {
 		return WrapIfErr(g.runGH(commandGist, "delete", argOptYes, gist), "failed to delete gist")
 	}
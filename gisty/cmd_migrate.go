@@ -0,0 +1,275 @@
+package gisty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: MigrateOptions, MigrateReport
+// ----------------------------------------------------------------------------
+
+// MigrateOptions are the options for the Migrate function.
+type MigrateOptions struct {
+	// OnMigrate is called after each gist is migrated or failed to migrate.
+	// Optional.
+	OnMigrate func(entry MigrateEntry)
+	// ReportFile is the path of the JSON file of the MigrateReport. It is
+	// written after each gist, and if it exists on start, the migration is
+	// resumed from it: the migrated gists are skipped. Optional.
+	ReportFile string
+	// Only is the list of the IDs of the source gists to migrate. If empty,
+	// all the gists of the source account are migrated.
+	Only []string
+	// WithComments posts the comments of the source gists to the target gists
+	// as quoted text, since comments cannot be created on behalf of their
+	// authors.
+	WithComments bool
+	// AllowSecrets migrates the gists even if the Gisty.SecretScanner of the
+	// target finds possible secrets in their files.
+	AllowSecrets bool
+}
+
+// NewMigrateOptions returns a new MigrateOptions with the default values.
+func NewMigrateOptions() MigrateOptions {
+	return MigrateOptions{
+		OnMigrate:    nil,
+		ReportFile:   "",
+		Only:         nil,
+		WithComments: false,
+		AllowSecrets: false,
+	}
+}
+
+// MigrateReport is the ID mapping report of Migrate.
+type MigrateReport struct {
+	StartedAt  time.Time      `json:"started_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	Gists      []MigrateEntry `json:"gists"`
+	SourceHost string         `json:"source_host"`
+	TargetHost string         `json:"target_host"`
+}
+
+// MigrateEntry is a gist in the migration report.
+type MigrateEntry struct {
+	MigratedAt time.Time `json:"migrated_at"`
+	SourceID   string    `json:"source_id"`
+	SourceURL  string    `json:"source_url"`
+	// TargetID is the ID of the created gist. Empty if the creation failed.
+	TargetID  string `json:"target_id"`
+	TargetURL string `json:"target_url"`
+	// Error is the error message if the migration of the gist failed. If the
+	// target gist was created, it is updated instead of created on resume.
+	Error string `json:"error,omitempty"`
+}
+
+// Done returns true if the gist was migrated successfully.
+func (e MigrateEntry) Done() bool {
+	return e.TargetID != "" && e.Error == ""
+}
+
+// TargetIDOf returns the ID of the target gist of the source gist. It returns
+// false if the gist was not migrated successfully.
+func (r MigrateReport) TargetIDOf(sourceID string) (string, bool) {
+	for _, entry := range r.Gists {
+		if entry.SourceID == sourceID && entry.Done() {
+			return entry.TargetID, true
+		}
+	}
+
+	return "", false
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Migrate
+// ----------------------------------------------------------------------------
+
+// Migrate recreates the gists owned by the account of g in the account of
+// target, with the same files, description and visibility. The host and the
+// account of each side are set with the Host field and SetToken.
+//
+// It stops on the first error or when ctx is canceled, and returns the report
+// of the gists migrated so far. Run it again with the same opts.ReportFile to
+// resume. The comments already posted to a resumed gist are not posted again.
+// A report of other hosts than the ones of g and target is rejected.
+//
+// The gists are checked by the Policies of target as a Create. If the
+// SecretScanner of target finds possible secrets in the files of a gist, the
+// migration stops with a *SecretsError before the gist is copied, unless
+// opts.AllowSecrets is true.
+func (g *Gisty) Migrate(ctx context.Context, target *Gisty, opts MigrateOptions) (MigrateReport, error) {
+	report, err := readMigrateReport(opts.ReportFile)
+	if err != nil {
+		return report, err
+	}
+
+	// The IDs of the report are meaningless on other hosts. Resuming would skip
+	// the gists never copied there and update unrelated gists.
+	if (report.SourceHost != "" && !strings.EqualFold(report.SourceHost, g.host())) ||
+		(report.TargetHost != "" && !strings.EqualFold(report.TargetHost, target.host())) {
+		return report, NewErr("migration report %s is from %s to %s, but migrating from %s to %s",
+			opts.ReportFile, report.SourceHost, report.TargetHost, g.host(), target.host())
+	}
+
+	report.SourceHost = g.host()
+	report.TargetHost = target.host()
+
+	source, err := g.apiClient()
+	if err != nil {
+		return report, err
+	}
+
+	dest, err := target.apiClient()
+	if err != nil {
+		return report, err
+	}
+
+	gists, err := source.ListGists(ctx)
+	if err != nil {
+		return report, wrapAPIErr(err, "failed to list gists of the source account")
+	}
+
+	for _, listed := range gists {
+		if len(opts.Only) > 0 && !slices.Contains(opts.Only, listed.ID) {
+			continue
+		}
+
+		if ctx.Err() != nil {
+			return report, WrapIfErr(ctx.Err(), "migration interrupted")
+		}
+
+		index := slices.IndexFunc(report.Gists, func(entry MigrateEntry) bool {
+			return entry.SourceID == listed.ID
+		})
+		if index >= 0 && report.Gists[index].Done() {
+			continue
+		}
+
+		if index < 0 {
+			report.Gists = append(report.Gists, MigrateEntry{
+				MigratedAt: time.Time{},
+				SourceID:   listed.ID,
+				SourceURL:  listed.HTMLURL,
+				TargetID:   "",
+				TargetURL:  "",
+				Error:      "",
+			})
+			index = len(report.Gists) - 1
+		}
+
+		errMigrate := target.migrateGist(ctx, source, dest, &report.Gists[index], opts)
+
+		report.UpdatedAt = time.Now().UTC()
+
+		err = writeMigrateReport(opts.ReportFile, report)
+		if err != nil {
+			return report, err
+		}
+
+		if opts.OnMigrate != nil {
+			opts.OnMigrate(report.Gists[index])
+		}
+
+		if errMigrate != nil {
+			return report, errMigrate
+		}
+	}
+
+	return report, nil
+}
+
+// migrateGist copies the gist of the entry from source to dest, the API client
+// of g, and updates the entry with the result. g is the target of Migrate.
+func (g *Gisty) migrateGist(
+	ctx context.Context, source, dest *gistapi.Client, entry *MigrateEntry, opts MigrateOptions,
+) error {
+	gist, contents, err := fetchGist(ctx, source, entry.SourceID, opts.WithComments)
+	if err == nil {
+		err = g.checkSecrets(contents, opts.AllowSecrets)
+	}
+
+	if err == nil {
+		err = g.checkPublishPolicies(&gist, contents)
+	}
+
+	if err == nil {
+		var created *gistapi.Gist
+
+		created, err = publishGist(ctx, dest, gist, contents, entry.TargetID, opts.WithComments)
+		if created != nil {
+			entry.TargetID = created.ID
+			entry.TargetURL = created.HTMLURL
+		}
+	}
+
+	entry.MigratedAt = time.Now().UTC()
+	entry.Error = ""
+
+	if err != nil {
+		entry.Error = err.Error()
+
+		return WrapIfErr(err, "failed to migrate gist: %s", entry.SourceID)
+	}
+
+	return nil
+}
+
+func readMigrateReport(pathFile string) (MigrateReport, error) {
+	report := MigrateReport{
+		StartedAt:  time.Now().UTC(),
+		UpdatedAt:  time.Time{},
+		Gists:      []MigrateEntry{},
+		SourceHost: "",
+		TargetHost: "",
+	}
+
+	if pathFile == "" {
+		return report, nil
+	}
+
+	data, err := os.ReadFile(pathFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return report, nil
+	}
+
+	if err != nil {
+		return report, WrapIfErr(err, "failed to read migration report")
+	}
+
+	err = json.Unmarshal(data, &report)
+	if err != nil {
+		return report, WrapIfErr(err, "failed to parse migration report: %s", pathFile)
+	}
+
+	return report, nil
+}
+
+// writeMigrateReport writes the report to pathFile through a temporary file,
+// so that an interruption does not leave a broken report.
+func writeMigrateReport(pathFile string, report MigrateReport) error {
+	if pathFile == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return WrapIfErr(err, "failed to encode migration report")
+	}
+
+	pathTemp := filepath.Join(filepath.Dir(pathFile), "."+filepath.Base(pathFile)+".tmp")
+
+	err = os.WriteFile(pathTemp, append(data, '\n'), 0o600)
+	if err != nil {
+		return WrapIfErr(err, "failed to write migration report")
+	}
+
+	return WrapIfErr(os.Rename(pathTemp, pathFile), "failed to write migration report")
+}
@@ -0,0 +1,108 @@
+package gisty_test
+
+import (
+	"context"
+	"net/http"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGisty_Migrate(t *testing.T) {
+	t.Parallel()
+
+	srcSrv, gistIDs := newBackupTestServer(t)
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	reportFile := filepath.Join(t.TempDir(), "report.json")
+
+	opts := gisty.NewMigrateOptions()
+	opts.ReportFile = reportFile
+	opts.WithComments = true
+
+	// The second gist fails to migrate.
+	srcSrv.FailNext(http.MethodGet, "/gists/"+gistIDs[1], http.StatusBadGateway, nil)
+
+	report, err := srcSrv.NewGisty().Migrate(context.Background(), dstSrv.NewGisty(), opts)
+
+	require.ErrorContains(t, err, "failed to migrate gist: "+gistIDs[1])
+
+	_, ok := report.TargetIDOf(gistIDs[1])
+	require.False(t, ok)
+	require.FileExists(t, reportFile)
+
+	// Resume.
+	migrated := []string{}
+	opts.OnMigrate = func(entry gisty.MigrateEntry) {
+		migrated = append(migrated, entry.SourceID)
+	}
+
+	report, err = srcSrv.NewGisty().Migrate(context.Background(), dstSrv.NewGisty(), opts)
+
+	require.NoError(t, err)
+	require.Len(t, dstSrv.Gists(), 2, "migrated gists should not be duplicated")
+	require.Contains(t, migrated, gistIDs[1])
+	require.Len(t, report.Gists, 2)
+
+	for _, gistID := range gistIDs {
+		targetID, ok := report.TargetIDOf(gistID)
+		require.True(t, ok, "gist %s should be migrated", gistID)
+
+		src, _ := srcSrv.Gist(gistID)
+		dst, _ := dstSrv.Gist(targetID)
+
+		require.Equal(t, src.Files, dst.Files)
+		require.Equal(t, src.Description, dst.Description)
+		require.Equal(t, src.Public, dst.Public)
+		require.Len(t, dst.Comments, len(src.Comments))
+	}
+
+	// Nothing left to migrate.
+	migrated = []string{}
+
+	_, err = srcSrv.NewGisty().Migrate(context.Background(), dstSrv.NewGisty(), opts)
+
+	require.NoError(t, err)
+	require.Empty(t, migrated)
+
+	// The report of another target host is not resumed.
+	dstSrv.ResetRequests()
+
+	other := dstSrv.NewGisty()
+	other.Host = "ghe.example.com"
+
+	_, err = srcSrv.NewGisty().Migrate(context.Background(), other, opts)
+
+	require.ErrorContains(t, err, "but migrating from github.com to ghe.example.com")
+	require.Empty(t, dstSrv.Requests(), "nothing should be migrated with the report of another host")
+}
+
+func TestGisty_Migrate_only_and_cancel(t *testing.T) {
+	t.Parallel()
+
+	srcSrv, gistIDs := newBackupTestServer(t)
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	opts := gisty.NewMigrateOptions()
+	opts.Only = []string{gistIDs[0]}
+
+	report, err := srcSrv.NewGisty().Migrate(context.Background(), dstSrv.NewGisty(), opts)
+
+	require.NoError(t, err)
+	require.Len(t, report.Gists, 1)
+	require.Equal(t, gistIDs[0], report.Gists[0].SourceID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = srcSrv.NewGisty().Migrate(ctx, dstSrv.NewGisty(), gisty.NewMigrateOptions())
+
+	require.ErrorIs(t, err, context.Canceled)
+}
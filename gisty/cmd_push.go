@@ -0,0 +1,216 @@
+package gisty
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// PushMessageDefault is the commit message of Push if PushOptions.Message is
+// empty.
+const PushMessageDefault = "Update gist files"
+
+// PushOptions are the options of Push.
+type PushOptions struct {
+	Message      string // commit message of the pending changes. PushMessageDefault if empty.
+	Rebase       bool   // if true, rebases the local commits onto the remote branch before pushing.
+	AllowSecrets bool   // if true, pushes the files even if Gisty.SecretScanner finds secrets.
+}
+
+// NewPushOptions returns a new PushOptions with the default values.
+func NewPushOptions() PushOptions {
+	return PushOptions{
+		Message:      "",
+		Rebase:       false,
+		AllowSecrets: false,
+	}
+}
+
+// PushResult is the result of Push.
+type PushResult struct {
+	Remote    string `json:"remote"`    // name of the remote pushed to. E.g. "origin".
+	Branch    string `json:"branch"`    // name of the remote branch pushed to.
+	Commit    string `json:"commit"`    // SHA of the local HEAD after the push.
+	Committed bool   `json:"committed"` // true if the pending changes were committed.
+	Rebased   bool   `json:"rebased"`   // true if the local commits were rebased.
+	Pushed    bool   `json:"pushed"`    // false if there was nothing to push.
+}
+
+// Push publishes the local changes of the cloned gist in dir. The pending
+// changes, including the untracked files, are committed with opts.Message and
+// the local commits are pushed to the upstream branch.
+//
+// If the remote branch has commits that are not in the local branch, a
+// *PushRejectedError is returned unless opts.Rebase is true. In that case, the
+// local commits are rebased onto the remote branch first, and the rebase is
+// aborted on conflicts.
+//
+// The files in dir are checked by g.Policies as an Update before committing,
+// and the files to publish by g.SecretScanner. If secrets are found, nothing is
+// committed but the changes are left staged.
+func (g *Gisty) Push(dir string, opts PushOptions) (PushResult, error) {
+	result := PushResult{Remote: "", Branch: "", Commit: "", Committed: false, Rebased: false, Pushed: false}
+
+	dirRepo, err := localRepoDir(dir)
+	if err != nil {
+		return result, err
+	}
+
+	dirRepo, err = g.runGit(dirRepo, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return result, WrapIfErr(err, "not a cloned gist: %s", dir)
+	}
+
+	upstream, err := g.runGit(dirRepo, "rev-parse", "--abbrev-ref", "--symbolic-full-name", "@{upstream}")
+	if err != nil {
+		return result, WrapIfErr(err, "no upstream branch to push to. Check out a branch of the gist")
+	}
+
+	result.Remote, result.Branch, _ = strings.Cut(upstream, "/")
+
+	err = g.checkUpdateDir(dirRepo, "Push")
+	if err != nil {
+		return result, err
+	}
+
+	result.Committed, err = g.commitPending(dirRepo, opts)
+	if err != nil {
+		return result, err
+	}
+
+	if opts.Rebase {
+		_, err = g.runGit(dirRepo, append(gitAuthArgs(), "pull", "--rebase", "--quiet")...)
+		if err != nil {
+			_, _ = g.runGit(dirRepo, "rebase", "--abort")
+
+			return result, WrapIfErr(err, "failed to rebase onto %s. The rebase was aborted", upstream)
+		}
+
+		result.Rebased = true
+	}
+
+	ahead, err := g.runGit(dirRepo, "rev-list", "--count", "@{upstream}..HEAD")
+	if err != nil {
+		return result, WrapIfErr(err, "failed to count the commits to push")
+	}
+
+	if ahead != "0" {
+		out, err := g.runGitOutput(dirRepo,
+			append(gitAuthArgs(), "push", "--porcelain", "--quiet", result.Remote, "HEAD:"+result.Branch)...)
+		if err != nil && isPushRejected(out) {
+			return result, &PushRejectedError{Remote: result.Remote, Branch: result.Branch}
+		}
+
+		if err != nil {
+			return result, WrapIfErr(err, "failed to push to %s", upstream)
+		}
+
+		result.Pushed = true
+	}
+
+	result.Commit, err = g.runGit(dirRepo, "rev-parse", "HEAD")
+
+	return result, WrapIfErr(err, "failed to get the pushed commit")
+}
+
+// commitPending stages all the changes in dirRepo and commits them if any. It
+// returns false if there was nothing to commit.
+func (g *Gisty) commitPending(dirRepo string, opts PushOptions) (bool, error) {
+	_, err := g.runGit(dirRepo, "add", "--all")
+	if err != nil {
+		return false, WrapIfErr(err, "failed to stage the changes")
+	}
+
+	if g.SecretScanner != nil && !opts.AllowSecrets {
+		err = g.checkPushFiles(dirRepo)
+		if err != nil {
+			return false, err
+		}
+	}
+
+	staged, err := g.runGit(dirRepo, "diff", "--cached", "--name-only")
+	if err != nil {
+		return false, WrapIfErr(err, "failed to list the staged changes")
+	}
+
+	if staged == "" {
+		return false, nil
+	}
+
+	msg := opts.Message
+	if msg == "" {
+		msg = PushMessageDefault
+	}
+
+	_, err = g.runGit(dirRepo, "commit", "--quiet", "--message", msg)
+
+	return err == nil, WrapIfErr(err, "failed to commit the changes")
+}
+
+// checkPushFiles scans the files which differ from the upstream branch, either
+// staged or committed, for secrets.
+func (g *Gisty) checkPushFiles(dirRepo string) error {
+	names, err := g.runGit(dirRepo, "diff", "--cached", "--name-only", "-z",
+		"--no-renames", "--diff-filter=d", "@{upstream}")
+	if err != nil {
+		return WrapIfErr(err, "failed to list the files to push")
+	}
+
+	files := map[string][]byte{}
+
+	for _, name := range strings.Split(names, "\x00") {
+		if name == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dirRepo, name))
+		if err != nil {
+			return WrapIfErr(err, "failed to read file to push")
+		}
+
+		files[name] = content
+	}
+
+	return g.checkSecrets(files, false)
+}
+
+// isPushRejected returns true if the output of "git push --porcelain" reports
+// a ref rejected since it was not a fast-forward. Unlike the messages to the
+// standard error, the porcelain output is not translated. E.g.
+//
+//	To https://gist.github.com/<id>.git
+//	!	HEAD:refs/heads/main	[rejected] (fetch first)
+//	Done
+func isPushRejected(out string) bool {
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) == 3 && fields[0] == "!" && strings.HasPrefix(fields[2], "[rejected]") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ----------------------------------------------------------------------------
+//  Type: PushRejectedError
+// ----------------------------------------------------------------------------
+
+// PushRejectedError is returned by Push when the remote branch has commits that
+// are not in the local branch. It wraps ErrPushRejected.
+type PushRejectedError struct {
+	Remote string // name of the remote. E.g. "origin".
+	Branch string // name of the remote branch.
+}
+
+// Error implements the error interface.
+func (e *PushRejectedError) Error() string {
+	return fmt.Sprintf("%s: %s/%s has commits that are not in the local branch. Rebase onto them and push again",
+		ErrPushRejected, e.Remote, e.Branch)
+}
+
+// Unwrap returns ErrPushRejected.
+func (e *PushRejectedError) Unwrap() error {
+	return ErrPushRejected
+}
@@ -1,18 +1,33 @@
 package gisty
 
 import (
+	"errors"
 	"strings"
 
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
 	"github.com/cli/cli/v2/pkg/cmd/gist/shared"
 	"github.com/cli/cli/v2/pkg/cmd/gist/view"
-	ghauth "github.com/cli/go-gh/v2/pkg/auth"
 )
 
 // Read returns a list of GistInfo objects. The returned list depends on the
 // arguments passed to the function.
+//
+// If g.Store is set, the gist is read from the store when g.ReadFromCache is
+// true or when GitHub cannot be reached.
 func (g *Gisty) Read(gist string) (*shared.Gist, error) {
-	return g.read(gist, g.AltFunctions.Read)
+	if g.Store != nil && g.ReadFromCache {
+		return g.readStore(gist)
+	}
+
+	result, err := g.read(gist, g.AltFunctions.Read)
+	if err != nil && g.Store != nil && isNetworkErr(err) {
+		cached, errStore := g.readStore(gist)
+		if errStore == nil {
+			return cached, nil
+		}
+	}
+
+	return result, err
 }
 
 // read is a wrapper around the read command from the gh cli.
@@ -23,7 +38,7 @@ func (g *Gisty) read(gist string, altF func(*view.ViewOptions) error) (*shared.G
 
 	//nolint:nonamedreturns // Named return is intentional.
 	runView := func(opts *view.ViewOptions) (err error) {
-		resultGist, err = readRun(opts)
+		resultGist, err = g.readRun(opts)
 		if err != nil {
 			return WrapIfErr(err, "failed to execute readRun function")
 		}
@@ -52,20 +67,47 @@ var sharedGetGist = shared.GetGist
 // the configuration.
 var forceFailReadConf = false
 
-func readRun(opts *view.ViewOptions) (*shared.Gist, error) {
-	gistID := opts.Selector
+// gistIDFromArg returns the gist ID of the given gist reference. See
+// ParseGistRef. The reference must not be at a revision, since the commands
+// using it work on the latest revision. Nor on another host than g.host(),
+// since the commands request g.host() with the ID only.
+func (g *Gisty) gistIDFromArg(gist string) (string, error) {
+	ref, err := ParseGistRef(gist)
+	if err != nil {
+		return "", err
+	}
 
-	if strings.Contains(gistID, "/") {
-		id, err := shared.GistIDFromURL(gistID)
-		if err != nil {
-			return nil, WrapIfErr(err, "failed to parse gist ID from URL")
-		}
+	if ref.Revision != "" {
+		return "", WrapIfErr(ErrInvalidGistID, "revision %s given, but only the latest revision is supported: %q",
+			ref.Revision, gist)
+	}
+
+	err = g.checkGistHost(ref, gist)
+	if err != nil {
+		return "", err
+	}
+
+	return ref.ID, nil
+}
 
-		gistID = id
+// checkGistHost returns an error if the gist reference is of another host than
+// g.host(). The requests carry the token of g.host(), so they must not be sent
+// to the host named by an argument.
+func (g *Gisty) checkGistHost(ref GistRef, gist string) error {
+	if ref.Host != "" && !strings.EqualFold(ref.Host, g.host()) {
+		return WrapIfErr(ErrInvalidGistID, "gist on host %s given, but the host is %s: %q",
+			ref.Host, g.host(), gist)
 	}
 
-	if gistID == "" {
-		return nil, NewErr("no gist specified")
+	return nil
+}
+
+// readRun gets the gist selected in opts from g.Host, or the default host of gh
+// if empty.
+func (g *Gisty) readRun(opts *view.ViewOptions) (*shared.Gist, error) {
+	gistID, err := g.gistIDFromArg(opts.Selector)
+	if err != nil {
+		return nil, err
 	}
 
 	client, err := opts.HttpClient()
@@ -77,9 +119,11 @@ func readRun(opts *view.ViewOptions) (*shared.Gist, error) {
 		return nil, WrapIfErr(NewErr("forced error"), "failed to read option config")
 	}
 
-	hostname, _ := ghauth.DefaultHost()
+	gist, err := sharedGetGist(client, g.host(), gistID)
+	if errors.Is(err, shared.NotFoundErr) {
+		return nil, WrapIfErr(ErrNotFound, "failed to get gist: %s", gistID)
+	}
 
-	gist, err := sharedGetGist(client, hostname, gistID)
 	if err != nil {
 		return nil, WrapIfErr(err, "failed to get gist")
 	}
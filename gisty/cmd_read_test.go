@@ -104,6 +104,7 @@ func TestGisty_Read_invalid_url(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to read gist")
 	require.Contains(t, err.Error(), "failed to execute readRun function")
 	require.Contains(t, err.Error(), "failed to parse gist ID from URL")
+	require.ErrorIs(t, err, ErrInvalidGistID)
 }
 
 //nolint:paralleltest // Do not parallelize due to mocking global function variables.
@@ -129,6 +130,25 @@ func TestGisty_Read_empty_gist(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to read gist")
 	require.Contains(t, err.Error(), "failed to execute readRun function")
 	require.Contains(t, err.Error(), "no gist specified")
+	require.ErrorIs(t, err, ErrInvalidGistID)
+}
+
+//nolint:paralleltest // Do not parallelize due to mocking global function variables.
+func TestGisty_Read_not_found(t *testing.T) {
+	oldSharedGetGist := sharedGetGist
+
+	defer func() {
+		sharedGetGist = oldSharedGetGist
+	}()
+
+	sharedGetGist = func(_ *http.Client, _ string, _ string) (*shared.Gist, error) {
+		return nil, shared.NotFoundErr
+	}
+
+	gist, err := NewGisty().Read(readTestGistID)
+
+	require.ErrorIs(t, err, ErrNotFound)
+	require.Nil(t, gist, "returned gist object should be nil on error")
 }
 
 // ----------------------------------------------------------------------------
@@ -146,7 +166,7 @@ func Test_readRun_fail_create_http_client(t *testing.T) {
 		},
 	}
 
-	gist, err := readRun(opts)
+	gist, err := NewGisty().readRun(opts)
 
 	require.Error(t, err)
 	require.Nil(t, gist, "returned gist object should be nil on error")
@@ -203,3 +223,40 @@ func Test_readRun_fail_get_gist(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to get gist")
 	require.Contains(t, err.Error(), "forced error")
 }
+
+//nolint:paralleltest // Do not parallelize due to mocking global function variables.
+func Test_readRun_host(t *testing.T) {
+	oldSharedGetGist := sharedGetGist
+
+	defer func() {
+		sharedGetGist = oldSharedGetGist
+	}()
+
+	var hosts []string
+
+	// Mock the shared.GetGist(sharedGetGist) function to record the host.
+	sharedGetGist = func(_ *http.Client, hostname string, _ string) (*shared.Gist, error) {
+		hosts = append(hosts, hostname)
+
+		return new(shared.Gist), nil
+	}
+
+	obj := NewGisty()
+	obj.Host = "ghe.example.com"
+
+	_, err := obj.Read(readTestGistID)
+
+	require.NoError(t, err)
+
+	_, err = obj.Read("https://ghe.example.com/gist/octocat/" + readTestGistID)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"ghe.example.com", "ghe.example.com"}, hosts,
+		"the gist should be read from the host of Gisty")
+
+	_, err = obj.Read("https://gist.github.com/octocat/" + readTestGistID)
+
+	require.ErrorIs(t, err, ErrInvalidGistID, "the gist on another host should not be read")
+	require.ErrorContains(t, err, "gist on host github.com given, but the host is ghe.example.com")
+	require.Len(t, hosts, 2, "no request should be made for the gist on another host")
+}
@@ -0,0 +1,460 @@
+package gisty
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Script
+// ----------------------------------------------------------------------------
+
+// Script is a gist file to run, pinned to a revision of the gist.
+type Script struct {
+	GistID   string `json:"gist_id"`
+	Revision string `json:"revision"` // SHA of the gist revision the content belongs to.
+	File     string `json:"file"`
+	Content  string `json:"content"`
+	SHA256   string `json:"sha256"` // hex SHA-256 of the content.
+	// Interpreter is the command and its arguments to run the script with,
+	// followed by the path to the script. E.g. ["python3"].
+	Interpreter []string `json:"interpreter"`
+}
+
+// FetchScript returns the file of the gist to run and its interpreter.
+//
+// gist is a gist reference as accepted by ParseGistRef. Its revision and file,
+// e.g. of a raw file URL, are used if revision and file are empty. A URL of
+// another host than g.Host is rejected without requesting it.
+//
+// If file is empty, the only file of the gist or the only one with a shebang
+// line is used. If revision is empty, the file is pinned to the latest
+// revision of the gist. Otherwise, the file at the given revision is used.
+//
+// The interpreter is taken from the shebang line, such as "#!/usr/bin/env
+// python3", or from the file extension, such as ".sh" for "sh".
+func (g *Gisty) FetchScript(gist, file, revision string) (Script, error) {
+	script := Script{GistID: "", Revision: revision, File: file, Content: "", SHA256: "", Interpreter: nil}
+
+	pinned, err := g.fetchPinnedFile(gist, file, revision, selectScriptFile)
+	if err != nil {
+		return script, err
+	}
+
+	script.GistID = pinned.GistID
+	script.Revision = pinned.Revision
+	script.File = pinned.File
+	script.Content = pinned.Content
+	script.SHA256 = pinned.SHA256
+
+	script.Interpreter, err = detectInterpreter(script.File, script.Content)
+
+	return script, err
+}
+
+// pinnedFile is a gist file pinned to a revision of the gist.
+type pinnedFile struct {
+	GistID   string
+	Revision string
+	File     string
+	Content  string
+	SHA256   string // hex SHA-256 of the content.
+}
+
+// fetchPinnedFile returns the file of the gist at the revision. The revision
+// and the file default to the ones of the gist reference. selectFile chooses
+// the file from the gist when file is still empty.
+//
+// The gist is fetched through the REST API of g.host() only, and the reference
+// must not be of another host. Neither Read nor g.Store is used, so that the
+// file is always the one at the pinned revision.
+func (g *Gisty) fetchPinnedFile(
+	gist, file, revision string,
+	selectFile func(*gistapi.Gist, string) (string, error),
+) (pinnedFile, error) {
+	ctx := context.Background()
+	result := pinnedFile{GistID: "", Revision: revision, File: file, Content: "", SHA256: ""}
+
+	ref, err := ParseGistRef(gist)
+	if err != nil {
+		return result, err
+	}
+
+	err = g.checkGistHost(ref, gist)
+	if err != nil {
+		return result, err
+	}
+
+	switch {
+	case revision != "" && ref.Revision != "" && revision != ref.Revision:
+		return result, NewErr("revision %s conflicts with the revision of the gist: %q", revision, gist)
+	case file != "" && ref.File != "" && file != ref.File:
+		return result, NewErr("file %s conflicts with the file of the gist: %q", file, gist)
+	}
+
+	if revision == "" {
+		revision = ref.Revision
+		result.Revision = revision
+	}
+
+	if file == "" {
+		file = ref.File
+	}
+
+	gistID := ref.ID
+	result.GistID = gistID
+
+	client, err := g.apiClient()
+	if err != nil {
+		return result, err
+	}
+
+	pinned, err := g.gistAtRevision(ctx, client, gistID, revision)
+	if err != nil {
+		return result, err
+	}
+
+	if revision == "" {
+		result.Revision = pinned.Revision()
+	}
+
+	result.File, err = selectFile(pinned, file)
+	if err != nil {
+		return result, err
+	}
+
+	apiFile := pinned.Files[result.File]
+
+	result.Content, err = fileContent(ctx, client, apiFile)
+	if err != nil {
+		return result, wrapAPIErr(err, "failed to get file %s of gist %s", result.File, gistID)
+	}
+
+	result.SHA256 = contentSHA256(result.Content)
+
+	return result, nil
+}
+
+// contentSHA256 returns the hex SHA-256 of the content.
+func contentSHA256(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// gistAtRevision returns the gist at the revision, or the latest one if the
+// revision is empty.
+func (g *Gisty) gistAtRevision(ctx context.Context, client *gistapi.Client, gistID, revision string) (*gistapi.Gist, error) {
+	if revision == "" {
+		gist, err := client.GetGist(ctx, gistID)
+
+		return gist, wrapAPIErr(err, "failed to get the revision of gist: %s", gistID)
+	}
+
+	gist, err := client.GetGistRevision(ctx, gistID, revision)
+
+	return gist, wrapAPIErr(err, "failed to get gist %s at revision %s", gistID, revision)
+}
+
+// selectScriptFile returns the name of the file to run.
+func selectScriptFile(gist *gistapi.Gist, file string) (string, error) {
+	names := sortedKeys(gist.Files)
+
+	if file != "" {
+		if _, ok := gist.Files[file]; !ok {
+			return "", WrapIfErr(ErrNotFound, "no file named %q in gist %s. Files: %s",
+				file, gist.ID, strings.Join(names, ", "))
+		}
+
+		return file, nil
+	}
+
+	if len(names) == 1 {
+		return names[0], nil
+	}
+
+	withShebang := slices.DeleteFunc(slices.Clone(names), func(name string) bool {
+		return !strings.HasPrefix(gist.Files[name].Content, "#!")
+	})
+
+	if len(withShebang) == 1 {
+		return withShebang[0], nil
+	}
+
+	return "", NewErr("gist %s has %d files. Specify the file to run: %s",
+		gist.ID, len(names), strings.Join(names, ", "))
+}
+
+// fileContent returns the content of the file, requesting the raw content if
+// it is truncated in the API response.
+func fileContent(ctx context.Context, client *gistapi.Client, file *gistapi.File) (string, error) {
+	if !file.Truncated {
+		return file.Content, nil
+	}
+
+	raw, err := client.GetRaw(ctx, file.RawURL)
+
+	return string(raw), err //nolint:wrapcheck // wrapped by the caller
+}
+
+// scriptExtensions are the interpreters by file extension, in lower case.
+var scriptExtensions = map[string][]string{
+	".bash": {"bash"},
+	".go":   {"go", "run"},
+	".js":   {"node"},
+	".lua":  {"lua"},
+	".mjs":  {"node"},
+	".php":  {"php"},
+	".pl":   {"perl"},
+	".ps1":  {"pwsh", "-File"},
+	".py":   {"python3"},
+	".r":    {"Rscript"},
+	".rb":   {"ruby"},
+	".sh":   {"sh"},
+	".zsh":  {"zsh"},
+}
+
+// detectInterpreter returns the interpreter of the script from its shebang
+// line or its file extension. "/usr/bin/env" is removed from the shebang, so
+// that the interpreter is looked up in PATH.
+func detectInterpreter(name, content string) ([]string, error) {
+	if line, ok := strings.CutPrefix(content, "#!"); ok {
+		line, _, _ = strings.Cut(line, "\n")
+		fields := strings.Fields(strings.TrimSuffix(line, "\r"))
+
+		if len(fields) > 0 && path.Base(fields[0]) == "env" {
+			fields = fields[1:]
+			if len(fields) > 0 && fields[0] == "-S" {
+				fields = fields[1:]
+			}
+		}
+
+		if len(fields) > 0 {
+			return fields, nil
+		}
+	}
+
+	if interpreter, ok := scriptExtensions[strings.ToLower(path.Ext(name))]; ok {
+		return slices.Clone(interpreter), nil
+	}
+
+	return nil, NewErr("failed to detect the interpreter of %s. Add a shebang line such as \"#!/bin/sh\"", name)
+}
+
+// ----------------------------------------------------------------------------
+//  Type: RunOptions
+// ----------------------------------------------------------------------------
+
+// RunOptions are the options for the RunScript function.
+type RunOptions struct {
+	// Stdin, Stdout and Stderr are the standard streams of the script. If nil,
+	// the script reads nothing and its output is discarded.
+	Stdin  io.Reader
+	Stdout io.Writer
+	Stderr io.Writer
+	// TrustFile is the path to the JSON file of the trusted scripts. See
+	// TrustRecord.
+	TrustFile string
+	// Args are the arguments to the script.
+	Args []string
+	// Trust records the script as trusted and runs it, even if it was never
+	// trusted or changed since it was trusted.
+	Trust bool
+}
+
+// NewRunOptions returns a new RunOptions with the given trust file.
+func NewRunOptions(trustFile string) RunOptions {
+	return RunOptions{
+		Stdin:     nil,
+		Stdout:    nil,
+		Stderr:    nil,
+		TrustFile: trustFile,
+		Args:      nil,
+		Trust:     false,
+	}
+}
+
+// TrustRecord is a script trusted by the user, stored in the trust file.
+type TrustRecord struct {
+	TrustedAt time.Time `json:"trusted_at"`
+	Host      string    `json:"host"`
+	GistID    string    `json:"gist_id"`
+	File      string    `json:"file"`
+	Revision  string    `json:"revision"`
+	SHA256    string    `json:"sha256"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: RunScript
+// ----------------------------------------------------------------------------
+
+// RunScript runs the script in a new temporary directory, which is removed
+// afterwards.
+//
+// The script runs only if its SHA-256 matches the one recorded in
+// opts.TrustFile for the gist file. Otherwise, an *UntrustedScriptError is
+// returned, unless opts.Trust is set, which records the script as trusted.
+//
+// If the script fails, the returned error wraps the *exec.ExitError with the
+// exit code of the script.
+func (g *Gisty) RunScript(script Script, opts RunOptions) error {
+	if opts.TrustFile == "" {
+		return NewErr("no trust file to check the script against")
+	}
+
+	if len(script.Interpreter) == 0 {
+		return NewErr("no interpreter to run the script: %s", script.File)
+	}
+
+	trusted, err := readTrustFile(opts.TrustFile)
+	if err != nil {
+		return err
+	}
+
+	key := g.host() + "/" + script.GistID + "/" + script.File
+	record, ok := trusted[key]
+
+	switch {
+	case opts.Trust:
+		trusted[key] = TrustRecord{
+			TrustedAt: time.Now().UTC(),
+			Host:      g.host(),
+			GistID:    script.GistID,
+			File:      script.File,
+			Revision:  script.Revision,
+			SHA256:    script.SHA256,
+		}
+
+		err = writeTrustFile(opts.TrustFile, trusted)
+		if err != nil {
+			return err
+		}
+	case !ok:
+		return &UntrustedScriptError{Script: script, Trusted: nil}
+	case record.SHA256 != script.SHA256:
+		return &UntrustedScriptError{Script: script, Trusted: &record}
+	}
+
+	return g.execScript(script, opts)
+}
+
+// execScript writes the script to a temporary directory and runs it there.
+func (g *Gisty) execScript(script Script, opts RunOptions) error {
+	dirTemp, err := os.MkdirTemp("", "gisty-run-*")
+	if err != nil {
+		return WrapIfErr(err, "failed to create temporary directory")
+	}
+
+	defer os.RemoveAll(dirTemp)
+
+	pathScript := filepath.Join(dirTemp, filepath.Base(script.File))
+
+	//nolint:gosec // the script must be executable for the shebang-less interpreters
+	err = os.WriteFile(pathScript, []byte(script.Content), 0o700)
+	if err != nil {
+		return WrapIfErr(err, "failed to write script")
+	}
+
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	args := slices.Concat(script.Interpreter[1:], []string{pathScript}, opts.Args)
+	command := execCommandContext(ctx, script.Interpreter[0], args...)
+
+	command.Dir = dirTemp
+	command.Stdin = opts.Stdin
+	command.Stdout = opts.Stdout
+	command.Stderr = opts.Stderr
+
+	return WrapIfErr(command.Run(), "failed to run script %s of gist %s", script.File, script.GistID)
+}
+
+// readTrustFile reads the trusted scripts keyed by "host/gistID/file". It is
+// empty if the file does not exist.
+func readTrustFile(pathFile string) (map[string]TrustRecord, error) {
+	trusted := map[string]TrustRecord{}
+
+	data, err := os.ReadFile(pathFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return trusted, nil
+	}
+
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to read trust file")
+	}
+
+	err = json.Unmarshal(data, &trusted)
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to parse trust file: %s", pathFile)
+	}
+
+	return trusted, nil
+}
+
+// writeTrustFile writes the trusted scripts to pathFile through a temporary
+// file, so that an interruption does not leave a broken file.
+func writeTrustFile(pathFile string, trusted map[string]TrustRecord) error {
+	data, err := json.MarshalIndent(trusted, "", "  ")
+	if err != nil {
+		return WrapIfErr(err, "failed to encode trust file")
+	}
+
+	err = os.MkdirAll(filepath.Dir(pathFile), 0o700)
+	if err != nil {
+		return WrapIfErr(err, "failed to create directory of trust file")
+	}
+
+	pathTemp := filepath.Join(filepath.Dir(pathFile), "."+filepath.Base(pathFile)+".tmp")
+
+	err = os.WriteFile(pathTemp, append(data, '\n'), 0o600)
+	if err != nil {
+		return WrapIfErr(err, "failed to write trust file")
+	}
+
+	return WrapIfErr(os.Rename(pathTemp, pathFile), "failed to write trust file")
+}
+
+// ----------------------------------------------------------------------------
+//  Type: UntrustedScriptError
+// ----------------------------------------------------------------------------
+
+// UntrustedScriptError is returned by RunScript when the script was never
+// trusted or changed since it was trusted. It wraps ErrUntrustedScript.
+type UntrustedScriptError struct {
+	// Trusted is the record of the previously trusted content. Nil if the
+	// script was never trusted.
+	Trusted *TrustRecord
+	Script  Script
+}
+
+// Error implements the error interface.
+func (e *UntrustedScriptError) Error() string {
+	if e.Trusted == nil {
+		return fmt.Sprintf("%s: %s of gist %s was never trusted (revision %s, sha256 %s)",
+			ErrUntrustedScript, e.Script.File, e.Script.GistID, e.Script.Revision, e.Script.SHA256)
+	}
+
+	return fmt.Sprintf("%s: %s of gist %s changed since trusted at revision %s (sha256 %s, was %s)",
+		ErrUntrustedScript, e.Script.File, e.Script.GistID, e.Trusted.Revision, e.Script.SHA256, e.Trusted.SHA256)
+}
+
+// Unwrap returns ErrUntrustedScript.
+func (e *UntrustedScriptError) Unwrap() error {
+	return ErrUntrustedScript
+}
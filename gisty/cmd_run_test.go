@@ -0,0 +1,202 @@
+package gisty_test
+
+import (
+	"bytes"
+	"net/http"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+const testScript = "#!/usr/bin/env sh\necho \"hello $1\"\nbasename \"$(pwd)\"\nexit \"${2:-0}\"\n"
+
+func newScriptTestServer(t *testing.T, files map[string]string) (*gistytest.Server, string) {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{Files: files}) //nolint:exhaustruct // only the fields under test
+
+	return srv, gistID
+}
+
+func TestGisty_FetchScript(t *testing.T) {
+	t.Parallel()
+
+	srv, gistID := newScriptTestServer(t, map[string]string{"hello.sh": testScript, "README.md": "# Hello"})
+	obj := srv.NewGisty()
+
+	script, err := obj.FetchScript("https://gist.github.com/octocat/"+gistID, "", "")
+
+	require.NoError(t, err)
+
+	gist, _ := srv.Gist(gistID)
+
+	require.Equal(t, gistID, script.GistID)
+	require.Equal(t, gist.Revision(), script.Revision)
+	require.Equal(t, "hello.sh", script.File, "the only file with a shebang should be selected")
+	require.Equal(t, testScript, script.Content)
+	require.Len(t, script.SHA256, 64)
+	require.Equal(t, []string{"sh"}, script.Interpreter)
+
+	// Pinned to a revision.
+	srv.ResetRequests()
+
+	pinned, err := obj.FetchScript(gistID, "hello.sh", script.Revision)
+
+	require.NoError(t, err)
+	require.Equal(t, script, pinned)
+	srv.AssertRequested(t, http.MethodGet, "/gists/"+gistID+"/"+script.Revision)
+
+	_, err = obj.FetchScript(gistID, "hello.sh", "0000000")
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+}
+
+func TestGisty_FetchScript_host_and_cache(t *testing.T) {
+	t.Parallel()
+
+	srv, gistID := newScriptTestServer(t, map[string]string{"hello.sh": "#!/bin/sh\necho old\n"})
+	obj := srv.NewGisty()
+	obj.Store = openTestStore(t)
+
+	_, err := obj.Mirror(gisty.NewMirrorOptions())
+	require.NoError(t, err)
+
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    gistID,
+		Files: map[string]string{"hello.sh": "#!/bin/sh\necho new\n"},
+	})
+
+	// The stale mirror is not used.
+	obj.ReadFromCache = true
+
+	script, err := obj.FetchScript(gistID, "", "")
+
+	require.NoError(t, err)
+	require.Equal(t, "#!/bin/sh\necho new\n", script.Content)
+
+	gist, _ := srv.Gist(gistID)
+
+	require.Equal(t, gist.Revision(), script.Revision)
+
+	// The gist of another host is rejected before requesting it, since the
+	// requests carry the token.
+	srv.ResetRequests()
+
+	_, err = obj.FetchScript("https://gist.attacker.example/octocat/"+gistID, "", "")
+
+	require.ErrorIs(t, err, gisty.ErrInvalidGistID)
+	require.ErrorContains(t, err, "gist on host attacker.example given")
+	require.Empty(t, srv.Requests(), "no request should be sent for the gist of another host")
+}
+
+func TestGisty_FetchScript_select_file_and_interpreter(t *testing.T) {
+	t.Parallel()
+
+	srv, gistID := newScriptTestServer(t, map[string]string{
+		"tool.py":  "print('hi')\n",
+		"run":      "#!/bin/bash -eu\necho hi\n",
+		"notes":    "no shebang\n",
+		"task.ps1": "Write-Output hi\n",
+	})
+	obj := srv.NewGisty()
+
+	for file, want := range map[string][]string{
+		"tool.py":  {"python3"},
+		"run":      {"/bin/bash", "-eu"},
+		"task.ps1": {"pwsh", "-File"},
+	} {
+		script, err := obj.FetchScript(gistID, file, "")
+
+		require.NoError(t, err)
+		require.Equal(t, want, script.Interpreter, file)
+	}
+
+	_, err := obj.FetchScript(gistID, "notes", "")
+
+	require.ErrorContains(t, err, "failed to detect the interpreter of notes")
+
+	_, err = obj.FetchScript(gistID, "missing.sh", "")
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    gistID,
+		Files: map[string]string{"a.sh": "#!/bin/sh\n", "b.sh": "#!/bin/sh\n"},
+	})
+
+	_, err = obj.FetchScript(gistID, "", "")
+
+	require.ErrorContains(t, err, "has 2 files. Specify the file to run: a.sh, b.sh")
+}
+
+func TestGisty_RunScript(t *testing.T) {
+	t.Parallel()
+
+	if runtime.GOOS == "windows" {
+		t.Skip("sh is not available on Windows")
+	}
+
+	srv, gistID := newScriptTestServer(t, map[string]string{"hello.sh": testScript})
+	obj := srv.NewGisty()
+
+	script, err := obj.FetchScript(gistID, "", "")
+	require.NoError(t, err)
+
+	var stdout bytes.Buffer
+
+	opts := gisty.NewRunOptions(filepath.Join(t.TempDir(), "config", "trust.json"))
+	opts.Stdout = &stdout
+	opts.Args = []string{"world"}
+
+	// Never trusted.
+	err = obj.RunScript(script, opts)
+
+	var errUntrusted *gisty.UntrustedScriptError
+
+	require.ErrorIs(t, err, gisty.ErrUntrustedScript)
+	require.ErrorAs(t, err, &errUntrusted)
+	require.Nil(t, errUntrusted.Trusted)
+	require.Empty(t, stdout.String(), "untrusted script should not run")
+
+	// Trusted explicitly, then trusted by the record.
+	opts.Trust = true
+
+	require.NoError(t, obj.RunScript(script, opts))
+	require.Regexp(t, `^hello world\ngisty-run-\d+\n$`, stdout.String(), "script should run in a temp dir")
+	require.FileExists(t, opts.TrustFile)
+
+	stdout.Reset()
+
+	opts.Trust = false
+	opts.Args = []string{"again", "3"}
+
+	err = obj.RunScript(script, opts)
+
+	var errExit *exec.ExitError
+
+	require.ErrorAs(t, err, &errExit)
+	require.Equal(t, 3, errExit.ExitCode())
+	require.Contains(t, stdout.String(), "hello again")
+
+	// Changed since trusted.
+	changed := script
+	changed.Content += "rm -rf /\n"
+	changed.SHA256 = "changed"
+
+	err = obj.RunScript(changed, opts)
+
+	require.ErrorAs(t, err, &errUntrusted)
+	require.NotNil(t, errUntrusted.Trusted)
+	require.Equal(t, script.SHA256, errUntrusted.Trusted.SHA256)
+	require.ErrorContains(t, err, "changed since trusted at revision "+script.Revision)
+
+	require.ErrorContains(t, obj.RunScript(script, gisty.NewRunOptions("")), "no trust file")
+}
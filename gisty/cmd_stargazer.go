@@ -12,7 +12,7 @@ import (
 
 // Stargazer returns the number of stars in the gist for a given gist ID.
 //
-// Note that gistID should not be the gist URL.
+// gistID may also be a gist URL. See ParseGistRef.
 func (g *Gisty) Stargazer(gistID string) (int, error) {
 	return g.stargazer(gistID, g.AltFunctions.Stargazer)
 }
@@ -22,15 +22,20 @@ func (g *Gisty) Stargazer(gistID string) (int, error) {
 //
 // If altF is not nil, it will be used instead of the default function.
 func (g *Gisty) stargazer(gistID string, runF func(*api.ApiOptions) error) (int, error) {
-	gistID = SanitizeGistID(gistID)
+	gistID, err := g.gistIDFromArg(gistID) // validated to avoid unwanted query to request
+	if err != nil {
+		return 0, err
+	}
+
 	query := fmt.Sprintf(
 		"query { viewer { gist (name: \"%s\" ) { name, stargazerCount } } }",
-		gistID, // sanitize to avoid unwanted query to request
+		gistID,
 	)
 	template := "{{.data.viewer.gist.stargazerCount}}"
 
 	argv := []string{
 		"graphql",
+		"--hostname", g.host(),
 		"-f", "query=" + query,
 		"--template=" + shellescape.Quote(template),
 	}
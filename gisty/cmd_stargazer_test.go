@@ -49,3 +49,12 @@ func TestGisty_Stargazer_unexpected_response(t *testing.T) {
 	require.Contains(t, err.Error(), "failed to parse GitHub API response")
 	require.Contains(t, err.Error(), "unexpected response")
 }
+
+func TestGisty_Stargazer_invalid_id(t *testing.T) {
+	t.Parallel()
+
+	count, err := NewGisty().Stargazer("\n\t")
+
+	require.ErrorIs(t, err, ErrInvalidGistID)
+	require.Equal(t, 0, count)
+}
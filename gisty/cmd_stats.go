@@ -0,0 +1,207 @@
+package gisty
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/internal/ghcmd"
+	"github.com/cli/cli/v2/pkg/cmd/api"
+)
+
+// MaxStatsPerQueryDefault is the default max number of gists fetched in a
+// single GraphQL query by Stats.
+const MaxStatsPerQueryDefault = 50
+
+// ----------------------------------------------------------------------------
+//  Type: GistStats
+// ----------------------------------------------------------------------------
+
+// GistStats holds the popularity and the file information of a gist.
+type GistStats struct {
+	Files    []FileStats `json:"files"`
+	GistID   string      `json:"id"`
+	Stars    int         `json:"stars"`
+	Comments int         `json:"comments"`
+	Forks    int         `json:"forks"`
+}
+
+// FileStats holds the information of a file in a gist.
+type FileStats struct {
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Language  string `json:"language"`
+	Size      int    `json:"size"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Stats
+// ----------------------------------------------------------------------------
+
+// Stats returns the number of stars, comments and forks, and the file
+// information of the given gists, keyed by gist ID.
+//
+// Unlike calling Stargazer for each gist, the gists are fetched with a single
+// aliased GraphQL query per g.MaxStatsPerQuery gists. The gists that are not
+// found are not included in the returned map.
+func (g *Gisty) Stats(gistIDs []string) (map[string]GistStats, error) {
+	return g.stats(gistIDs, g.AltFunctions.Stats)
+}
+
+const tplQueryStatsField = `
+		g%d: gist(name: "%s") {
+			name
+			stargazerCount
+			forks { totalCount }
+			comments { totalCount }
+			files { name extension size language { name } }
+		}`
+
+type statsNode struct {
+	Name           string `json:"name"`
+	StargazerCount int    `json:"stargazerCount"`
+	Forks          struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"forks"`
+	Comments struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"comments"`
+	Files []struct {
+		Language *struct {
+			Name string `json:"name"`
+		} `json:"language"`
+		Name      string `json:"name"`
+		Extension string `json:"extension"`
+		Size      int    `json:"size"`
+	} `json:"files"`
+}
+
+// stats is the actual function that gets the stats of the gists.
+//
+// If runF is not nil, it will be used instead of the default function.
+func (g *Gisty) stats(gistIDs []string, runF func(*api.ApiOptions) error) (map[string]GistStats, error) {
+	sanitized := make([]string, 0, len(gistIDs))
+
+	for _, gistID := range gistIDs {
+		gistID, err := g.gistIDFromArg(gistID) // validated to avoid unwanted query to request
+		if err != nil {
+			return nil, err
+		}
+
+		if !slices.Contains(sanitized, gistID) {
+			sanitized = append(sanitized, gistID)
+		}
+	}
+
+	chunkSize := g.MaxStatsPerQuery
+	if chunkSize < 1 {
+		chunkSize = MaxStatsPerQueryDefault
+	}
+
+	result := make(map[string]GistStats, len(sanitized))
+
+	for chunk := range slices.Chunk(sanitized, chunkSize) {
+		nodes, err := g.queryStats(chunk, runF)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, node := range nodes {
+			result[node.Name] = node.toGistStats()
+		}
+	}
+
+	return result, nil
+}
+
+// queryStats requests the stats of the gists in a single GraphQL query.
+func (g *Gisty) queryStats(gistIDs []string, runF func(*api.ApiOptions) error) ([]statsNode, error) {
+	var query strings.Builder
+
+	query.WriteString("query {\n\tviewer {")
+
+	for index, gistID := range gistIDs {
+		fmt.Fprintf(&query, tplQueryStatsField, index, gistID)
+	}
+
+	query.WriteString("\n\t}\n}")
+
+	argv := []string{
+		"graphql",
+		"--hostname", g.host(),
+		"-f", "query=" + query.String(),
+		"--jq", ".data.viewer",
+	}
+
+	// Each query uses its own output, since the Stdout is shared by the calls.
+	g.Stdout.Reset()
+
+	if runF == nil {
+		err := WrapIfErr(g.runGH(append([]string{"api"}, argv...)...), "failed to execute GitHub API request")
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		cmdAPI := api.NewCmdApi(g.Factory, runF)
+
+		err := WrapIfErr(ghcmd.Execute(cmdAPI, argv, g.streams()), "failed to execute GitHub API request")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var viewer map[string]json.RawMessage
+
+	err := json.Unmarshal(g.Stdout.Bytes(), &viewer)
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to parse GitHub API response. malformed JSON")
+	}
+
+	nodes := make([]statsNode, 0, len(gistIDs))
+
+	for index := range gistIDs {
+		raw, ok := viewer["g"+strconv.Itoa(index)]
+		if !ok || string(raw) == "null" { // not found
+			continue
+		}
+
+		var node statsNode
+
+		err = json.Unmarshal(raw, &node)
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to parse GitHub API response. malformed gist node")
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
+func (n statsNode) toGistStats() GistStats {
+	files := make([]FileStats, 0, len(n.Files))
+
+	for _, file := range n.Files {
+		language := ""
+		if file.Language != nil {
+			language = file.Language.Name
+		}
+
+		files = append(files, FileStats{
+			Name:      file.Name,
+			Extension: file.Extension,
+			Language:  language,
+			Size:      file.Size,
+		})
+	}
+
+	return GistStats{
+		Files:    files,
+		GistID:   n.Name,
+		Stars:    n.StargazerCount,
+		Comments: n.Comments.TotalCount,
+		Forks:    n.Forks.TotalCount,
+	}
+}
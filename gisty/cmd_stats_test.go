@@ -0,0 +1,80 @@
+package gisty_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGisty_Stats(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBatchTestServer(t, 3)
+	statsID := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files:    map[string]string{"main.go": "package main\n", "README": "readme"},
+		Comments: []gisty.Comment{{}, {}}, //nolint:exhaustruct // only the count matters
+		Stars:    5,
+		Forks:    3,
+	})
+
+	obj := srv.NewGisty()
+	obj.MaxStatsPerQuery = 2
+
+	missingID := "ffffffffffffffffffffffffffffffff"
+
+	stats, err := obj.Stats(append(gistIDs, statsID, missingID, gistIDs[0]))
+
+	require.NoError(t, err)
+	require.Len(t, stats, 4, "missing gists should be omitted and duplicates merged")
+	require.Equal(t, 2, stats[gistIDs[2]].Stars)
+	require.Equal(t, gisty.GistStats{
+		Files: []gisty.FileStats{
+			{Name: "README", Extension: "", Language: "Text", Size: 6},
+			{Name: "main.go", Extension: ".go", Language: "Go", Size: 13},
+		},
+		GistID:   statsID,
+		Stars:    5,
+		Comments: 2,
+		Forks:    3,
+	}, stats[statsID])
+	require.Equal(t, 3, srv.Requested(http.MethodPost, "/graphql"), "5 unique gists should be fetched by 2 per query")
+}
+
+func TestGisty_Stats_errors(t *testing.T) {
+	t.Parallel()
+
+	obj := gisty.NewGisty()
+
+	_, err := obj.Stats([]string{"abc", "<>"})
+	require.ErrorIs(t, err, gisty.ErrInvalidGistID)
+
+	obj.GHRunner = func(_ context.Context, cmd gisty.GHCommand) error {
+		_, err := fmt.Fprint(cmd.Stdout, "not json")
+
+		return err
+	}
+
+	_, err = obj.Stats([]string{"abc"})
+	require.ErrorContains(t, err, "malformed JSON")
+
+	obj.GHRunner = func(_ context.Context, cmd gisty.GHCommand) error {
+		_, err := fmt.Fprint(cmd.Stdout, `{"g0": {"stargazerCount": "many"}}`)
+
+		return err
+	}
+
+	_, err = obj.Stats([]string{"abc"})
+	require.ErrorContains(t, err, "malformed gist node")
+
+	obj.GHRunner = func(context.Context, gisty.GHCommand) error {
+		return context.Canceled
+	}
+
+	_, err = obj.Stats([]string{"abc"})
+	require.ErrorContains(t, err, "failed to execute GitHub API request")
+}
@@ -0,0 +1,414 @@
+package gisty
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// SyncStateFile is the name of the file in the synced directory that keeps the
+// state of the last sync.
+const SyncStateFile = ".gisty-sync.json"
+
+// ----------------------------------------------------------------------------
+//  Type: SyncOptions
+// ----------------------------------------------------------------------------
+
+// SyncPrefer is the side that wins when a file is changed on both sides.
+type SyncPrefer string
+
+// Conflict resolutions of SyncOptions.
+const (
+	SyncPreferNone   SyncPrefer = ""       // report the conflicts and leave the files as is.
+	SyncPreferLocal  SyncPrefer = "local"  // push the local file.
+	SyncPreferRemote SyncPrefer = "remote" // pull the remote file.
+)
+
+// SyncOptions are the options for the Sync function.
+type SyncOptions struct {
+	Prefer       SyncPrefer // side to keep on conflicts. Conflicts are not resolved if empty.
+	DryRun       bool       // if true, only plans the actions without changing anything.
+	AllowSecrets bool       // if true, pushes the files even if Gisty.SecretScanner finds secrets.
+}
+
+// NewSyncOptions returns a new SyncOptions with the default values.
+func NewSyncOptions() SyncOptions {
+	return SyncOptions{
+		Prefer:       SyncPreferNone,
+		DryRun:       false,
+		AllowSecrets: false,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Type: SyncResult
+// ----------------------------------------------------------------------------
+
+// SyncActionType is the kind of change made to a file by Sync.
+type SyncActionType string
+
+// Actions of the sync.
+const (
+	SyncPush         SyncActionType = "push"          // uploads the local file.
+	SyncPull         SyncActionType = "pull"          // downloads the remote file.
+	SyncDeleteRemote SyncActionType = "delete-remote" // deletes the file from the gist.
+	SyncDeleteLocal  SyncActionType = "delete-local"  // deletes the local file.
+	SyncRenameRemote SyncActionType = "rename-remote" // renames the file in the gist. Used by Watch.
+	SyncConflict     SyncActionType = "conflict"      // both sides changed. Nothing is done.
+)
+
+// SyncAction is an action on a file planned or done by Sync.
+type SyncAction struct {
+	File string         `json:"file"`
+	From string         `json:"from,omitempty"` // previous name of the file on rename.
+	Type SyncActionType `json:"type"`
+}
+
+// String returns the action in the "<type> <file>" form. Or "<type> <from> ->
+// <file>" on rename.
+func (a SyncAction) String() string {
+	if a.From != "" {
+		return string(a.Type) + " " + a.From + " -> " + a.File
+	}
+
+	return string(a.Type) + " " + a.File
+}
+
+// SyncResult is the result of Sync.
+type SyncResult struct {
+	Revision string       `json:"revision"` // revision of the gist after the sync.
+	Actions  []SyncAction `json:"actions"`  // actions sorted by file name.
+	DryRun   bool         `json:"dry_run"`  // true if the actions were not applied.
+}
+
+// syncState is the content of SyncStateFile.
+type syncState struct {
+	Files    map[string]string `json:"files"` // file name to the SHA-256 of the content.
+	GistID   string            `json:"gist_id"`
+	Revision string            `json:"revision"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Sync
+// ----------------------------------------------------------------------------
+
+// Sync synchronizes the regular files in dir with the files of the gist in
+// both directions, without git.
+//
+// Files are compared by their content hash against the state of the last sync,
+// which is kept in SyncStateFile in dir. Changes on one side are applied to the
+// other side. Files changed differently on both sides are reported as conflicts
+// unless opts.Prefer is set, and Sync returns ErrSyncConflict along with the
+// result of the other files. Hidden files, on both sides, and subdirectories
+// are ignored. So a gist file named SyncStateFile is never pulled.
+//
+// If g.SecretScanner finds possible secrets in the files to push, nothing is
+// changed and a *SecretsError is returned, unless opts.AllowSecrets is true.
+// Likewise, if there are files to push, g.Policies check dir as an Update.
+func (g *Gisty) Sync(dir, gistID string, opts SyncOptions) (SyncResult, error) {
+	ctx := context.Background()
+	result := SyncResult{Revision: "", Actions: []SyncAction{}, DryRun: opts.DryRun}
+
+	gistID, err := g.gistIDFromArg(gistID)
+	if err != nil {
+		return result, err
+	}
+
+	state, err := readSyncState(dir, gistID)
+	if err != nil {
+		return result, err
+	}
+
+	local, err := readLocalFiles(dir)
+	if err != nil {
+		return result, err
+	}
+
+	client, err := g.apiClient()
+	if err != nil {
+		return result, err
+	}
+
+	gist, err := client.GetGist(ctx, gistID)
+	if err != nil {
+		return result, wrapAPIErr(err, "failed to get gist: %s", gistID)
+	}
+
+	remote := make(map[string]string, len(gist.Files))
+
+	for name, file := range gist.Files {
+		if isHiddenFile(name) {
+			continue
+		}
+
+		if file.Truncated {
+			return result, NewErr("file too large to sync: %s", name)
+		}
+
+		remote[name] = file.Content
+	}
+
+	result.Revision = gist.Revision()
+	result.Actions = planSync(local, remote, state.Files, opts.Prefer)
+
+	pushes := map[string][]byte{}
+
+	for _, action := range result.Actions {
+		if action.Type == SyncPush {
+			pushes[action.File] = []byte(local[action.File])
+		}
+	}
+
+	err = g.checkSecrets(pushes, opts.AllowSecrets)
+	if err != nil {
+		return result, err
+	}
+
+	if len(pushes) > 0 {
+		err = g.checkUpdateDir(dir, "Sync")
+		if err != nil {
+			return result, err
+		}
+	}
+
+	if opts.DryRun {
+		return result, nil
+	}
+
+	edits := map[string]*gistapi.EditFile{}
+
+	for _, action := range result.Actions {
+		switch action.Type {
+		case SyncPush:
+			edits[action.File] = &gistapi.EditFile{Content: local[action.File], Filename: ""}
+		case SyncDeleteRemote:
+			edits[action.File] = nil
+		case SyncPull:
+			//nolint:gosec // the gist files are written with the same permission as git does
+			err = os.WriteFile(filepath.Join(dir, action.File), []byte(remote[action.File]), 0o644)
+			local[action.File] = remote[action.File]
+		case SyncDeleteLocal:
+			err = os.Remove(filepath.Join(dir, action.File))
+			delete(local, action.File)
+		case SyncRenameRemote, SyncConflict:
+		}
+
+		if err != nil {
+			return result, WrapIfErr(err, "failed to %s", action)
+		}
+	}
+
+	if len(edits) > 0 {
+		gist, err = client.EditGist(ctx, gistID, edits)
+		if err != nil {
+			return result, wrapAPIErr(err, "failed to push files to gist: %s", gistID)
+		}
+
+		result.Revision = gist.Revision()
+	}
+
+	err = writeSyncState(dir, newSyncState(gistID, result, local, state.Files))
+	if err != nil {
+		return result, err
+	}
+
+	if conflicts := countActions(result.Actions, SyncConflict); conflicts > 0 {
+		return result, WrapIfErr(ErrSyncConflict, "%d file(s) changed on both sides", conflicts)
+	}
+
+	return result, nil
+}
+
+// planSync returns the actions to synchronize the local and remote files given
+// as file names to contents. base maps the file names to the hashes of the last
+// sync.
+func planSync(local, remote, base map[string]string, prefer SyncPrefer) []SyncAction {
+	names := []string{}
+
+	for _, files := range []map[string]string{local, remote, base} {
+		for name := range files {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	slices.Sort(names)
+
+	actions := []SyncAction{}
+
+	for _, name := range names {
+		localHash := hashIfExists(local, name)
+		remoteHash := hashIfExists(remote, name)
+		baseHash := base[name]
+
+		var actionType SyncActionType
+
+		switch {
+		case localHash == remoteHash:
+			continue
+		case localHash == baseHash, prefer == SyncPreferRemote && remoteHash != baseHash:
+			actionType = SyncPull
+			if remoteHash == "" {
+				actionType = SyncDeleteLocal
+			}
+		case remoteHash == baseHash, prefer == SyncPreferLocal:
+			actionType = SyncPush
+			if localHash == "" {
+				actionType = SyncDeleteRemote
+			}
+		default:
+			actionType = SyncConflict
+		}
+
+		actions = append(actions, SyncAction{File: name, From: "", Type: actionType})
+	}
+
+	return actions
+}
+
+// newSyncState returns the state after the sync. The conflicted files keep the
+// hashes of the previous sync so that they are detected again.
+func newSyncState(gistID string, result SyncResult, local, base map[string]string) syncState {
+	state := syncState{
+		Files:    map[string]string{},
+		GistID:   gistID,
+		Revision: result.Revision,
+	}
+
+	for name, content := range local {
+		state.Files[name] = hashContent(content)
+	}
+
+	for _, action := range result.Actions {
+		delete(state.Files, action.File)
+
+		if action.Type == SyncConflict && base[action.File] != "" {
+			state.Files[action.File] = base[action.File]
+		}
+
+		if action.Type == SyncPush || action.Type == SyncPull {
+			state.Files[action.File] = hashContent(local[action.File])
+		}
+	}
+
+	return state
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+func countActions(actions []SyncAction, actionType SyncActionType) int {
+	count := 0
+
+	for _, action := range actions {
+		if action.Type == actionType {
+			count++
+		}
+	}
+
+	return count
+}
+
+func hashContent(content string) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func hashIfExists(files map[string]string, name string) string {
+	content, ok := files[name]
+	if !ok {
+		return ""
+	}
+
+	return hashContent(content)
+}
+
+// isHiddenFile returns true if the file name starts with a dot. The hidden
+// files, including SyncStateFile, are neither synced nor watched.
+func isHiddenFile(name string) bool {
+	return strings.HasPrefix(name, ".")
+}
+
+// readLocalFiles returns the contents of the regular files in dir that are not
+// hidden.
+func readLocalFiles(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to read directory: %s", dir)
+	}
+
+	files := map[string]string{}
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || isHiddenFile(entry.Name()) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to read file: %s", entry.Name())
+		}
+
+		files[entry.Name()] = string(content)
+	}
+
+	return files, nil
+}
+
+// readSyncState reads the state of the last sync in dir. An empty state is
+// returned if dir was never synced.
+func readSyncState(dir, gistID string) (syncState, error) {
+	state := syncState{Files: map[string]string{}, GistID: gistID, Revision: ""}
+
+	data, err := os.ReadFile(filepath.Join(dir, SyncStateFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+
+	if err != nil {
+		return state, WrapIfErr(err, "failed to read sync state")
+	}
+
+	err = json.Unmarshal(data, &state)
+	if err != nil {
+		return state, WrapIfErr(err, "failed to parse sync state: %s", SyncStateFile)
+	}
+
+	if state.GistID != gistID {
+		return state, NewErr("directory %s is synced with another gist: %s", dir, state.GistID)
+	}
+
+	if state.Files == nil {
+		state.Files = map[string]string{}
+	}
+
+	// The hidden files synced by older versions must not look deleted locally.
+	maps.DeleteFunc(state.Files, func(name, _ string) bool {
+		return isHiddenFile(name)
+	})
+
+	return state, nil
+}
+
+func writeSyncState(dir string, state syncState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return WrapIfErr(err, "failed to encode sync state")
+	}
+
+	err = os.WriteFile(filepath.Join(dir, SyncStateFile), append(data, '\n'), 0o600)
+
+	return WrapIfErr(err, "failed to write sync state")
+}
@@ -0,0 +1,243 @@
+package gisty_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+const syncTestGistID = "0123456789abcdef0123456789abcdef"
+
+// newSyncTestServer returns a fake server with a gist to sync and a Gisty
+// pointed at it.
+func newSyncTestServer(t *testing.T, files map[string]string) (*gistytest.Server, *gisty.Gisty) {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    syncTestGistID,
+		Files: files,
+	})
+
+	return srv, srv.NewGisty()
+}
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600))
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	return string(content)
+}
+
+func TestGisty_Sync_first_sync(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"remote.txt": "remote", "both.txt": "same"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"local.txt": "local", "both.txt": "same", ".hidden": "ignored"})
+
+	result, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, []gisty.SyncAction{
+		{File: "local.txt", From: "", Type: gisty.SyncPush},
+		{File: "remote.txt", From: "", Type: gisty.SyncPull},
+	}, result.Actions)
+
+	gist, ok := srv.Gist(syncTestGistID)
+	require.True(t, ok)
+	require.Equal(t, map[string]string{"local.txt": "local", "both.txt": "same", "remote.txt": "remote"}, gist.Files)
+	require.Equal(t, gist.Revision(), result.Revision)
+	require.Equal(t, "remote", readFile(t, filepath.Join(dir, "remote.txt")))
+	require.FileExists(t, filepath.Join(dir, gisty.SyncStateFile))
+
+	// Nothing to do on the second sync.
+	srv.ResetRequests()
+
+	result, err = obj.Sync(dir, "https://gist.github.com/octocat/"+syncTestGistID, gisty.NewSyncOptions())
+
+	require.NoError(t, err)
+	require.Empty(t, result.Actions)
+	srv.AssertNotRequested(t, http.MethodPatch, "/gists/"+syncTestGistID)
+}
+
+func TestGisty_Sync_remote_dotfile(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{
+		".env": "SECRET=1", gisty.SyncStateFile: `{"gist_id":"other"}`, "a.txt": "a",
+	})
+	dir := t.TempDir()
+
+	for range 2 {
+		_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+		require.NoError(t, err, "the hidden gist files should neither be pulled nor overwrite the sync state")
+	}
+
+	require.NoFileExists(t, filepath.Join(dir, ".env"))
+	require.Contains(t, readFile(t, filepath.Join(dir, gisty.SyncStateFile)), syncTestGistID)
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, "SECRET=1", gist.Files[".env"], "the hidden gist files should not be deleted")
+
+	result, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.NoError(t, err)
+	require.Empty(t, result.Actions)
+}
+
+func TestGisty_Sync_changes_and_deletions(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{
+		"edit-local.txt": "v1", "edit-remote.txt": "v1", "del-local.txt": "v1", "del-remote.txt": "v1",
+	})
+	dir := t.TempDir()
+
+	_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+	require.NoError(t, err)
+
+	// Change the local side.
+	writeFiles(t, dir, map[string]string{"edit-local.txt": "v2"})
+	require.NoError(t, os.Remove(filepath.Join(dir, "del-local.txt")))
+
+	// Change the remote side.
+	other := srv.NewGisty()
+	otherDir := t.TempDir()
+
+	_, err = other.Sync(otherDir, syncTestGistID, gisty.NewSyncOptions())
+	require.NoError(t, err)
+
+	writeFiles(t, otherDir, map[string]string{"edit-remote.txt": "v2"})
+	require.NoError(t, os.Remove(filepath.Join(otherDir, "del-remote.txt")))
+
+	_, err = other.Sync(otherDir, syncTestGistID, gisty.NewSyncOptions())
+	require.NoError(t, err)
+
+	// Dry run does not change anything.
+	plan, err := obj.Sync(dir, syncTestGistID, gisty.SyncOptions{Prefer: gisty.SyncPreferNone, DryRun: true, AllowSecrets: false})
+
+	require.NoError(t, err)
+	require.True(t, plan.DryRun)
+	require.Equal(t, []string{
+		"delete-remote del-local.txt",
+		"delete-local del-remote.txt",
+		"push edit-local.txt",
+		"pull edit-remote.txt",
+	}, actionStrings(plan.Actions))
+	require.Equal(t, "v1", readFile(t, filepath.Join(dir, "edit-remote.txt")))
+
+	result, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, plan.Actions, result.Actions)
+	require.NoFileExists(t, filepath.Join(dir, "del-remote.txt"))
+	require.Equal(t, "v2", readFile(t, filepath.Join(dir, "edit-remote.txt")))
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, map[string]string{"edit-local.txt": "v2", "edit-remote.txt": "v2"}, gist.Files)
+}
+
+func TestGisty_Sync_conflict(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"file.txt": "v1"})
+	dir := t.TempDir()
+
+	_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+	require.NoError(t, err)
+
+	writeFiles(t, dir, map[string]string{"file.txt": "local"})
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    syncTestGistID,
+		Files: map[string]string{"file.txt": "remote"},
+	})
+
+	result, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.ErrorIs(t, err, gisty.ErrSyncConflict)
+	require.Equal(t, []string{"conflict file.txt"}, actionStrings(result.Actions))
+	require.Equal(t, "local", readFile(t, filepath.Join(dir, "file.txt")), "conflicts should be left as is")
+
+	// The conflict is detected until it is resolved.
+	_, err = obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+	require.ErrorIs(t, err, gisty.ErrSyncConflict)
+
+	result, err = obj.Sync(dir, syncTestGistID, gisty.SyncOptions{Prefer: gisty.SyncPreferRemote, DryRun: false, AllowSecrets: false})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"pull file.txt"}, actionStrings(result.Actions))
+	require.Equal(t, "remote", readFile(t, filepath.Join(dir, "file.txt")))
+
+	writeFiles(t, dir, map[string]string{"file.txt": "local again"})
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    syncTestGistID,
+		Files: map[string]string{"file.txt": "remote again"},
+	})
+
+	result, err = obj.Sync(dir, syncTestGistID, gisty.SyncOptions{Prefer: gisty.SyncPreferLocal, DryRun: false, AllowSecrets: false})
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"push file.txt"}, actionStrings(result.Actions))
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, "local again", gist.Files["file.txt"])
+}
+
+func TestGisty_Sync_errors(t *testing.T) {
+	t.Parallel()
+
+	_, obj := newSyncTestServer(t, map[string]string{"file.txt": "v1"})
+
+	// Not found.
+	_, err := obj.Sync(t.TempDir(), "ffffffffffffffffffffffffffffffff", gisty.NewSyncOptions())
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+
+	// Invalid gist ID.
+	_, err = obj.Sync(t.TempDir(), "", gisty.NewSyncOptions())
+	require.ErrorIs(t, err, gisty.ErrInvalidGistID)
+
+	// Missing directory.
+	_, err = obj.Sync(filepath.Join(t.TempDir(), "missing"), syncTestGistID, gisty.NewSyncOptions())
+	require.ErrorContains(t, err, "failed to read directory")
+
+	// Directory synced with another gist.
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{gisty.SyncStateFile: `{"gist_id":"another"}`})
+
+	_, err = obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+	require.ErrorContains(t, err, "synced with another gist")
+
+	// Broken state file.
+	writeFiles(t, dir, map[string]string{gisty.SyncStateFile: `{`})
+
+	_, err = obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+	require.ErrorContains(t, err, "failed to parse sync state")
+}
+
+func actionStrings(actions []gisty.SyncAction) []string {
+	result := make([]string, 0, len(actions))
+	for _, action := range actions {
+		result = append(result, action.String())
+	}
+
+	return result
+}
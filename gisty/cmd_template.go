@@ -0,0 +1,386 @@
+package gisty
+
+import (
+	"bytes"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateDescriptionFile is the name of the file in the template directory
+// that holds the template of the gist description. It is not a gist file.
+const TemplateDescriptionFile = ".description"
+
+// ----------------------------------------------------------------------------
+//  Type: TemplateOptions, TemplateResult, RenderedFile
+// ----------------------------------------------------------------------------
+
+// TemplateOptions are the options for the CreateFromTemplate function.
+type TemplateOptions struct {
+	// Description is the template of the gist description. If empty, the
+	// TemplateDescriptionFile in the template directory is used, if any.
+	Description string
+	// AsPublic creates a public gist. By default, it is secret.
+	AsPublic bool
+	// AllowSecrets creates the gist even if Gisty.SecretScanner finds possible
+	// secrets in the rendered files.
+	AllowSecrets bool
+	// DryRun renders the template without creating the gist.
+	DryRun bool
+}
+
+// NewTemplateOptions returns a new TemplateOptions with the default values.
+func NewTemplateOptions() TemplateOptions {
+	return TemplateOptions{
+		Description:  "",
+		AsPublic:     false,
+		AllowSecrets: false,
+		DryRun:       false,
+	}
+}
+
+// TemplateResult is the result of CreateFromTemplate.
+type TemplateResult struct {
+	// URL is the URL of the created gist. It is nil on dry run.
+	URL *url.URL `json:"url"`
+	// Files are the rendered files sorted by name.
+	Files       []RenderedFile `json:"files"`
+	Description string         `json:"description"`
+}
+
+// RenderedFile is a file rendered from a template.
+type RenderedFile struct {
+	Name     string `json:"name"`
+	Template string `json:"template"` // name of the template file in the template directory.
+	Content  string `json:"content"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: CreateFromTemplate
+// ----------------------------------------------------------------------------
+
+// CreateFromTemplate renders the files of the template directory dir with vars
+// and creates a gist from them.
+//
+// Each regular file in dir, except TemplateDescriptionFile, is a Go
+// text/template, and so is its name. E.g. "{{.Name}}_test.go". The templates
+// refer to the variables as "{{.Name}}". All the variables used by the
+// templates must be in vars, otherwise a *TemplateVarsError is returned before
+// rendering anything.
+//
+// The rendered files are created with Create, so g.Policies and
+// g.SecretScanner apply to them. With opts.DryRun, the rendered files are
+// returned without creating the gist.
+func (g *Gisty) CreateFromTemplate(dir string, vars map[string]string, opts TemplateOptions) (TemplateResult, error) {
+	result := TemplateResult{URL: nil, Files: []RenderedFile{}, Description: ""}
+
+	tmpl, err := loadGistTemplate(dir, opts.Description)
+	if err != nil {
+		return result, err
+	}
+
+	if missing := tmpl.missingVars(vars); len(missing) > 0 {
+		return result, &TemplateVarsError{Missing: missing}
+	}
+
+	result.Description, result.Files, err = tmpl.render(vars)
+	if err != nil || opts.DryRun {
+		return result, err
+	}
+
+	dirTemp, err := os.MkdirTemp("", "gisty-template-*")
+	if err != nil {
+		return result, WrapIfErr(err, "failed to create temporary directory")
+	}
+
+	defer os.RemoveAll(dirTemp)
+
+	filePaths := make([]string, 0, len(result.Files))
+
+	for _, file := range result.Files {
+		pathFile := filepath.Join(dirTemp, file.Name)
+
+		err = os.WriteFile(pathFile, []byte(file.Content), 0o600)
+		if err != nil {
+			return result, WrapIfErr(err, "failed to write rendered file: %s", file.Name)
+		}
+
+		filePaths = append(filePaths, pathFile)
+	}
+
+	result.URL, err = g.Create(CreateArgs{
+		Description:  result.Description,
+		FilePaths:    filePaths,
+		AsPublic:     opts.AsPublic,
+		AllowSecrets: opts.AllowSecrets,
+	})
+
+	return result, WrapIfErr(err, "failed to create gist from template: %s", dir)
+}
+
+// TemplateVars returns the names of the variables used by the template
+// directory dir, sorted. descTemplate is the template of the description as
+// in TemplateOptions.Description.
+func TemplateVars(dir, descTemplate string) ([]string, error) {
+	tmpl, err := loadGistTemplate(dir, descTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return tmpl.vars(), nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: TemplateVarsError
+// ----------------------------------------------------------------------------
+
+// TemplateVarsError is returned when variables used by a template are not
+// given. It wraps ErrTemplateVars.
+type TemplateVarsError struct {
+	Missing []string // names of the missing variables, sorted.
+}
+
+// Error implements the error interface.
+func (e *TemplateVarsError) Error() string {
+	return fmt.Sprintf("%s: missing %s", ErrTemplateVars, strings.Join(e.Missing, ", "))
+}
+
+// Unwrap returns ErrTemplateVars.
+func (e *TemplateVarsError) Unwrap() error {
+	return ErrTemplateVars
+}
+
+// ----------------------------------------------------------------------------
+//  Type: gistTemplate
+// ----------------------------------------------------------------------------
+
+// templateOption makes the missing variables fail, in case they are not found
+// by missingVars. E.g. in a nested template.
+const templateOption = "missingkey=error"
+
+// gistTemplate is a parsed template directory.
+type gistTemplate struct {
+	description *template.Template // nil if there is no description.
+	names       []*template.Template
+	contents    []*template.Template
+}
+
+// loadGistTemplate parses the file names and contents of the template
+// directory, and the description.
+func loadGistTemplate(dir, descTemplate string) (*gistTemplate, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, WrapIfErr(err, "failed to read template directory")
+	}
+
+	tmpl := new(gistTemplate)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		name := entry.Name()
+
+		content, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to read template file")
+		}
+
+		if name == TemplateDescriptionFile {
+			if descTemplate == "" {
+				descTemplate = strings.TrimSpace(string(content))
+			}
+
+			continue
+		}
+
+		nameTmpl, err := template.New(name).Option(templateOption).Parse(name)
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to parse template of file name: %s", name)
+		}
+
+		contentTmpl, err := template.New(name).Option(templateOption).Parse(string(content))
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to parse template file: %s", name)
+		}
+
+		tmpl.names = append(tmpl.names, nameTmpl)
+		tmpl.contents = append(tmpl.contents, contentTmpl)
+	}
+
+	if len(tmpl.names) == 0 {
+		return nil, NewErr("no template file in: %s", dir)
+	}
+
+	if descTemplate != "" {
+		tmpl.description, err = template.New(TemplateDescriptionFile).Option(templateOption).Parse(descTemplate)
+		if err != nil {
+			return nil, WrapIfErr(err, "failed to parse template of description")
+		}
+	}
+
+	return tmpl, nil
+}
+
+// all returns all the templates.
+func (t *gistTemplate) all() []*template.Template {
+	all := slices.Concat(t.names, t.contents)
+	if t.description != nil {
+		all = append(all, t.description)
+	}
+
+	return all
+}
+
+// vars returns the sorted names of the variables used by the templates.
+func (t *gistTemplate) vars() []string {
+	names := map[string]bool{}
+
+	for _, tmpl := range t.all() {
+		for _, tree := range tmpl.Templates() {
+			if tree.Tree != nil {
+				collectVars(tree.Root, names, true)
+			}
+		}
+	}
+
+	return sortedKeys(names)
+}
+
+// missingVars returns the sorted names of the variables used by the templates
+// but not in vars.
+func (t *gistTemplate) missingVars(vars map[string]string) []string {
+	missing := []string{}
+
+	for _, name := range t.vars() {
+		if _, ok := vars[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+
+	return missing
+}
+
+// render executes the templates with vars. The files are sorted by the
+// rendered name.
+func (t *gistTemplate) render(vars map[string]string) (string, []RenderedFile, error) {
+	description := ""
+
+	if t.description != nil {
+		rendered, err := execTemplate(t.description, vars)
+		if err != nil {
+			return "", nil, err
+		}
+
+		description = strings.TrimSpace(rendered)
+	}
+
+	files := make([]RenderedFile, 0, len(t.names))
+	seen := map[string]string{}
+
+	for index, nameTmpl := range t.names {
+		name, err := execTemplate(nameTmpl, vars)
+		if err != nil {
+			return "", nil, err
+		}
+
+		if name == "" || name == "." || name == ".." ||
+			strings.ContainsAny(name, `/\`) || name == TemplateDescriptionFile {
+			return "", nil, NewErr("invalid file name %q rendered from: %s", name, nameTmpl.Name())
+		}
+
+		if other, ok := seen[name]; ok {
+			return "", nil, NewErr("file name %q is rendered from both %s and %s", name, other, nameTmpl.Name())
+		}
+
+		seen[name] = nameTmpl.Name()
+
+		content, err := execTemplate(t.contents[index], vars)
+		if err != nil {
+			return "", nil, err
+		}
+
+		files = append(files, RenderedFile{Name: name, Template: nameTmpl.Name(), Content: content})
+	}
+
+	slices.SortFunc(files, func(a, b RenderedFile) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return description, files, nil
+}
+
+func execTemplate(tmpl *template.Template, vars map[string]string) (string, error) {
+	var buf bytes.Buffer
+
+	err := tmpl.Execute(&buf, vars)
+	if err != nil {
+		return "", WrapIfErr(err, "failed to render template: %s", tmpl.Name())
+	}
+
+	return buf.String(), nil
+}
+
+// collectVars adds the names of the fields of dot, such as "Name" of
+// "{{.Name}}" or "{{$.Name}}", used under node to names. isRoot tells if dot
+// is the variables under node. Dot is changed inside "{{range}}" and "{{with}}",
+// so only "{{$.Name}}" is collected there.
+func collectVars(node parse.Node, names map[string]bool, isRoot bool) {
+	switch node := node.(type) {
+	case *parse.ListNode:
+		if node == nil {
+			return
+		}
+
+		for _, child := range node.Nodes {
+			collectVars(child, names, isRoot)
+		}
+	case *parse.ActionNode:
+		collectVars(node.Pipe, names, isRoot)
+	case *parse.PipeNode:
+		if node == nil {
+			return
+		}
+
+		for _, cmd := range node.Cmds {
+			collectVars(cmd, names, isRoot)
+		}
+	case *parse.CommandNode:
+		for _, arg := range node.Args {
+			collectVars(arg, names, isRoot)
+		}
+	case *parse.FieldNode:
+		if isRoot {
+			names[node.Ident[0]] = true
+		}
+	case *parse.VariableNode:
+		if node.Ident[0] == "$" && len(node.Ident) > 1 {
+			names[node.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		collectVars(node.Node, names, isRoot)
+	case *parse.IfNode:
+		collectBranchVars(&node.BranchNode, names, isRoot, isRoot)
+	case *parse.RangeNode:
+		collectBranchVars(&node.BranchNode, names, isRoot, false)
+	case *parse.WithNode:
+		collectBranchVars(&node.BranchNode, names, isRoot, false)
+	case *parse.TemplateNode:
+		collectVars(node.Pipe, names, isRoot)
+	}
+}
+
+// collectBranchVars collects the variables of the branch. isListRoot tells if
+// dot is still the variables in the body of the branch. The else branch keeps
+// the dot of the pipeline.
+func collectBranchVars(node *parse.BranchNode, names map[string]bool, isRoot, isListRoot bool) {
+	collectVars(node.Pipe, names, isRoot)
+	collectVars(node.List, names, isListRoot)
+	collectVars(node.ElseList, names, isRoot)
+}
@@ -0,0 +1,145 @@
+package gisty_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+// newTemplateDir returns a template directory of a repro case.
+func newTemplateDir(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		".description":      "Repro for {{.Ticket}}\n",
+		"{{.Name}}_test.go": "package {{.Package}}\n\n// Repro for {{$.Ticket}}.\n",
+		"README.md":         "{{if .Note}}{{.Note}}{{else}}No note.{{end}}\n",
+		"{{.Name}}.go":      "package {{.Package}}\n",
+	})
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "subdir"), 0o700))
+
+	return dir
+}
+
+func TestGisty_CreateFromTemplate(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	vars := map[string]string{"Ticket": "OPS-1", "Name": "parser", "Package": "demo", "Note": ""}
+
+	result, err := srv.NewGisty().CreateFromTemplate(newTemplateDir(t), vars, gisty.NewTemplateOptions())
+
+	require.NoError(t, err)
+	require.NotNil(t, result.URL)
+	require.Equal(t, "Repro for OPS-1", result.Description)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 1)
+	require.False(t, gists[0].Public)
+	require.Equal(t, "Repro for OPS-1", gists[0].Description)
+	require.Equal(t, map[string]string{
+		"README.md":      "No note.\n",
+		"parser.go":      "package demo\n",
+		"parser_test.go": "package demo\n\n// Repro for OPS-1.\n",
+	}, gists[0].Files)
+}
+
+func TestGisty_CreateFromTemplate_dry_run(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	opts := gisty.NewTemplateOptions()
+	opts.DryRun = true
+	opts.Description = "{{.Name}} checklist"
+
+	vars := map[string]string{"Ticket": "OPS-1", "Name": "onboarding", "Package": "demo", "Note": "Hi"}
+
+	result, err := srv.NewGisty().CreateFromTemplate(newTemplateDir(t), vars, opts)
+
+	require.NoError(t, err)
+	require.Nil(t, result.URL)
+	require.Equal(t, "onboarding checklist", result.Description, "option should take precedence over the file")
+	require.Equal(t, gisty.RenderedFile{Name: "README.md", Template: "README.md", Content: "Hi\n"}, result.Files[0])
+	require.Equal(t, "{{.Name}}_test.go", result.Files[2].Template)
+	require.Empty(t, srv.Gists(), "gist should not be created on dry run")
+}
+
+func TestGisty_CreateFromTemplate_missing_vars(t *testing.T) {
+	t.Parallel()
+
+	dir := newTemplateDir(t)
+
+	vars, err := gisty.TemplateVars(dir, "")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Name", "Note", "Package", "Ticket"}, vars)
+
+	_, err = gisty.NewGisty().CreateFromTemplate(dir, map[string]string{"Name": "x"}, gisty.NewTemplateOptions())
+
+	var errVars *gisty.TemplateVarsError
+
+	require.ErrorIs(t, err, gisty.ErrTemplateVars)
+	require.ErrorAs(t, err, &errVars)
+	require.Equal(t, []string{"Note", "Package", "Ticket"}, errVars.Missing)
+	require.EqualError(t, err, "template variables not given: missing Note, Package, Ticket")
+}
+
+func TestTemplateVars_range_and_with(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"list.md": "{{range .Items}}- {{.Title}} by {{$.Author}}\n{{else}}{{.Empty}}{{end}}",
+		"note.md": "{{with .Note}}{{.Text}}{{end}}",
+	})
+
+	vars, err := gisty.TemplateVars(dir, "")
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"Author", "Empty", "Items", "Note"}, vars,
+		"fields of the changed dot inside range and with should not be collected")
+}
+
+func TestGisty_CreateFromTemplate_errors(t *testing.T) {
+	t.Parallel()
+
+	obj := gisty.NewGisty()
+	opts := gisty.NewTemplateOptions()
+
+	for name, files := range map[string]map[string]string{
+		"no template file":      {".description": "desc"},
+		"failed to parse":       {"main.go": "{{.Name"},
+		"invalid file name":     {"{{.Name}}": ""},
+		"is rendered from both": {"{{.Name}}.go": "", "{{.Name}}{{.Ext}}": ""},
+	} {
+		dir := t.TempDir()
+		writeFiles(t, dir, files)
+
+		_, err := obj.CreateFromTemplate(dir, map[string]string{"Name": "", "Ext": ".go"}, opts)
+
+		require.ErrorContains(t, err, name)
+	}
+
+	// The names rendered to empty, "." or ".." would escape the file itself.
+	for _, nameTmpl := range []string{"{{.Name}}", "{{.Name}}.", "{{.Name}}.."} {
+		dir := t.TempDir()
+		writeFiles(t, dir, map[string]string{nameTmpl: ""})
+
+		_, err := obj.CreateFromTemplate(dir, map[string]string{"Name": ""}, opts)
+
+		require.ErrorContains(t, err, "invalid file name", nameTmpl)
+	}
+
+	_, err := obj.CreateFromTemplate(filepath.Join(t.TempDir(), "missing"), nil, opts)
+
+	require.ErrorContains(t, err, "failed to read template directory")
+}
@@ -1,6 +1,8 @@
 package gisty
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
@@ -27,27 +29,61 @@ func NewUpdateArgs(pathDirRepo string) UpdateArgs {
 	}
 }
 
-// Update syncs the gist repository with the given args. The returned string is
-// the output of the command on success.
+// UpdateMode is how Update moved the local branch.
+type UpdateMode string
+
+// Modes of UpdateResult.Mode.
+const (
+	UpdateUpToDate    UpdateMode = "up-to-date"   // the branch did not move.
+	UpdateFastForward UpdateMode = "fast-forward" // the branch was fast-forwarded or created.
+	UpdateReset       UpdateMode = "reset"        // the branch was hard reset, discarding local commits.
+)
+
+// UpdateResult is the result of Update about the synced local branch.
+type UpdateResult struct {
+	Branch       string     `json:"branch"`          // name of the synced local branch.
+	PrevCommit   string     `json:"previous_commit"` // SHA of the branch before the sync. Empty if it did not exist.
+	Commit       string     `json:"commit"`          // SHA of the branch after the sync.
+	Mode         UpdateMode `json:"mode"`            // how the branch moved.
+	ChangedFiles []string   `json:"changed_files"`   // paths of the files changed by the sync.
+}
+
+// Update syncs the gist repository with the given args and returns how the
+// local branch changed. The args are checked by g.Policies first.
 //
-//nolint:nonamedreturns // named retrun is intentional due to the error from defer
-func (g *Gisty) Update(args UpdateArgs) (msg string, err error) {
+// The synced branch is args.Branch or, if empty, the default branch of the
+// "origin" remote. If args.Destination is set, the remote repository is synced
+// instead and the local branch is reported as up to date.
+//
+// The command runs in args.PathDirRepo without changing the working directory
+// of the process, so Gisty instances can update different repositories
+// concurrently.
+func (g *Gisty) Update(args UpdateArgs) (UpdateResult, error) {
+	result := UpdateResult{Branch: "", PrevCommit: "", Commit: "", Mode: UpdateUpToDate, ChangedFiles: []string{}}
+
 	if args.PathDirRepo == "" {
-		return "", NewErr("path to local repository is required")
+		return result, NewErr("path to local repository is required")
 	}
 
-	// Change the working directory to the local repository.
-	returnPath, err := ChDir(args.PathDirRepo)
+	err := g.checkUpdatePolicies(&args)
 	if err != nil {
-		return "", WrapIfErr(err, "failed to change working directory to %s", args.PathDirRepo)
+		return result, err
 	}
 
-	defer func() {
-		_, errChDir := ChDir(returnPath)
-		if err == nil {
-			err = WrapIfErr(errChDir, "failed to change working directory back to %s", returnPath)
-		}
-	}()
+	dirRepo, err := localRepoDir(args.PathDirRepo)
+	if err != nil {
+		return result, err
+	}
+
+	result.Branch, err = g.syncBranch(dirRepo, args.Branch)
+	if err != nil {
+		return result, err
+	}
+
+	result.PrevCommit, err = g.branchCommit(dirRepo, result.Branch)
+	if err != nil {
+		return result, err
+	}
 
 	argsUpdate := []string{}
 
@@ -67,35 +103,126 @@ func (g *Gisty) Update(args UpdateArgs) (msg string, err error) {
 		argsUpdate = append(argsUpdate, "--force")
 	}
 
-	return g.update(argsUpdate, g.AltFunctions.Update)
+	err = g.update(dirRepo, argsUpdate, g.AltFunctions.Update)
+	if err != nil {
+		return result, err
+	}
+
+	result.Commit, err = g.branchCommit(dirRepo, result.Branch)
+	if err != nil {
+		return result, err
+	}
+
+	return g.updateResult(dirRepo, result)
 }
 
-// update is a wrapper around the repo.sync command from the gh cli.
-//
-// If altF is not nil, it will be used instead of the default function.
-func (g *Gisty) update(args []string, altF func(*sync.SyncOptions) error) (string, error) {
-	if altF == nil {
-		err := WrapIfErr(g.runGH(append([]string{"repo", "sync"}, args...)...), "failed to execute update/sync command")
+// syncBranch returns the branch synced by "gh repo sync" in dirRepo. It is
+// branch if set, the default branch of "origin" otherwise, or the current
+// branch if the default one is unknown.
+func (g *Gisty) syncBranch(dirRepo, branch string) (string, error) {
+	if branch != "" {
+		return branch, nil
+	}
+
+	remoteHead, err := g.runGit(dirRepo, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD")
+	if err == nil && strings.HasPrefix(remoteHead, "origin/") {
+		return strings.TrimPrefix(remoteHead, "origin/"), nil
+	}
+
+	branch, err = g.runGit(dirRepo, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return "", WrapIfErr(err, "failed to get the branch of local repository: %s", dirRepo)
+	}
+
+	return branch, nil
+}
+
+// branchCommit returns the SHA of the local branch in dirRepo, or an empty
+// string if the branch does not exist.
+func (g *Gisty) branchCommit(dirRepo, branch string) (string, error) {
+	commit, err := g.runGit(dirRepo, "for-each-ref", "--format=%(objectname)", "refs/heads/"+branch)
+
+	return commit, WrapIfErr(err, "failed to get the commit of branch %s in: %s", branch, dirRepo)
+}
+
+// updateResult fills the mode and the changed files of result from its
+// commits.
+func (g *Gisty) updateResult(dirRepo string, result UpdateResult) (UpdateResult, error) {
+	if result.Commit == result.PrevCommit {
+		return result, nil
+	}
+
+	result.Mode = UpdateFastForward
+	argsDiff := []string{"ls-tree", "-r", "--name-only", "-z", result.Commit}
+
+	if result.PrevCommit != "" {
+		base, err := g.runGit(dirRepo, "merge-base", result.PrevCommit, result.Commit)
 		if err != nil {
-			return "", err
+			return result, WrapIfErr(err, "failed to compare the commits of branch: %s", result.Branch)
 		}
-	} else {
-		cmd := sync.NewCmdSync(g.Factory, altF)
 
-		err := WrapIfErr(ghcmd.Execute(cmd, args, g.streams()), "failed to execute update/sync command")
-		if err != nil {
-			return "", err
+		if base != result.PrevCommit {
+			result.Mode = UpdateReset
 		}
-	}
 
-	// Capture the result of the command execution.
-	const successMsgPfx = "✓ Synced"
+		argsDiff = []string{"diff", "--name-only", "-z", "--no-renames", result.PrevCommit, result.Commit}
+	}
 
-	result := g.Stdout.String()
+	names, err := g.runGit(dirRepo, argsDiff...)
+	if err != nil {
+		return result, WrapIfErr(err, "failed to list the changed files of branch: %s", result.Branch)
+	}
 
-	if !strings.HasPrefix(result, successMsgPfx) {
-		return "", NewErr("failed to sync gist. Output: '%s'", result)
+	for _, name := range strings.Split(names, "\x00") {
+		if name != "" {
+			result.ChangedFiles = append(result.ChangedFiles, name)
+		}
 	}
 
 	return result, nil
 }
+
+// localRepoDir returns the absolute path of the local repository at pathDir
+// after checking that it is a directory.
+func localRepoDir(pathDir string) (string, error) {
+	dirRepo, err := filepath.Abs(pathDir)
+	if err != nil {
+		return "", WrapIfErr(err, "failed to get absolute path of: %s", pathDir)
+	}
+
+	info, err := os.Stat(dirRepo)
+	if err != nil {
+		return "", WrapIfErr(err, "failed to access local repository")
+	}
+
+	if !info.IsDir() {
+		return "", NewErr("path to local repository is not a directory: %s", dirRepo)
+	}
+
+	return dirRepo, nil
+}
+
+// update is a wrapper around the repo.sync command from the gh cli, run in
+// dirRepo. Its success is told by the error only, since the output of gh is
+// meant for humans.
+//
+// If altF is not nil, it will be used instead of the default function. The git
+// client of its options, if any, is scoped to dirRepo.
+func (g *Gisty) update(dirRepo string, args []string, altF func(*sync.SyncOptions) error) error {
+	if altF == nil {
+		return WrapIfErr(g.runGHIn(dirRepo, append([]string{"repo", "sync"}, args...)...),
+			"failed to execute update/sync command")
+	}
+
+	factory := *g.Factory
+
+	if factory.GitClient != nil {
+		gitClient := factory.GitClient.Copy()
+		gitClient.RepoDir = dirRepo
+		factory.GitClient = gitClient
+	}
+
+	cmd := sync.NewCmdSync(&factory, altF)
+
+	return WrapIfErr(ghcmd.Execute(cmd, args, g.streams()), "failed to execute update/sync command")
+}
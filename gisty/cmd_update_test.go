@@ -1,7 +1,12 @@
 package gisty
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/cli/cli/v2/pkg/cmd/repo/sync"
@@ -9,38 +14,84 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+const (
+	updateTestPrevCommit = "1111111111111111111111111111111111111111"
+	updateTestCommit     = "2222222222222222222222222222222222222222"
+)
+
+// stubUpdateGit records the git commands run by Update. The branch points to
+// the commits in order, one per "git for-each-ref", and the other commands
+// print the outputs in outputs. The commands not in outputs print nothing.
+func stubUpdateGit(t *testing.T, obj *Gisty, commits []string, outputs map[string]string) *[]string {
+	t.Helper()
+
+	calls := []string{}
+
+	obj.GitRunner = func(_ context.Context, cmd GitCommand) error {
+		call := strings.Join(cmd.Args, " ")
+		calls = append(calls, call)
+
+		out, ok := outputs[call]
+
+		switch {
+		case ok:
+		case cmd.Args[0] == "for-each-ref":
+			require.NotEmpty(t, commits, "unexpected call: %s", call)
+			out, commits = commits[0], commits[1:]
+		case cmd.Args[0] == "symbolic-ref":
+			out = "origin/main"
+		}
+
+		_, err := fmt.Fprintln(cmd.Stdout, out)
+
+		return err
+	}
+
+	return &calls
+}
+
+// mockUpdateSuccess mocks the update function to succeed.
+func mockUpdateSuccess(obj *Gisty) {
+	obj.AltFunctions.Update = func(*sync.SyncOptions) error {
+		return nil
+	}
+}
+
 // ----------------------------------------------------------------------------
 //  Success cases
 // ----------------------------------------------------------------------------
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
 func TestGisty_Update_golden(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+	t.Parallel()
 
 	// Instantiate the object.
 	obj := NewGisty()
 	args := NewUpdateArgs(t.TempDir())
 
-	// Mock the update function.
-	obj.AltFunctions.Update = func(*sync.SyncOptions) error {
-		// On success, the output should include the following:
-		const successMsgPfx = "✓ Synced"
-
-		_, err := obj.Stdout.WriteString(successMsgPfx)
-		require.NoError(t, err, "failed to write to stdout during mock")
+	mockUpdateSuccess(obj)
 
-		return nil
-	}
+	calls := stubUpdateGit(t, obj, []string{updateTestPrevCommit, updateTestCommit}, map[string]string{
+		"merge-base " + updateTestPrevCommit + " " + updateTestCommit:                       updateTestPrevCommit,
+		"diff --name-only -z --no-renames " + updateTestPrevCommit + " " + updateTestCommit: "hello.md\x00main.go\x00",
+	})
 
 	// Test
-	_, err := obj.Update(args)
+	result, err := obj.Update(args)
 
 	require.NoError(t, err, "failed to update the gist")
+	require.Equal(t, UpdateResult{
+		Branch:       "main",
+		PrevCommit:   updateTestPrevCommit,
+		Commit:       updateTestCommit,
+		Mode:         UpdateFastForward,
+		ChangedFiles: []string{"hello.md", "main.go"},
+	}, result)
+	require.Equal(t, "symbolic-ref --quiet --short refs/remotes/origin/HEAD", (*calls)[0],
+		"the default branch of the remote should be synced")
 }
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
 func TestGisty_Update_golden_with_flags(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+	t.Parallel()
 
 	// Instantiate the object.
 	obj := NewGisty()
@@ -68,56 +119,147 @@ func TestGisty_Update_golden_with_flags(t *testing.T) {
 		require.Equal(t, source, opt.SrcArg, "source should be set")
 		require.Equal(t, force, opt.Force, "force should be set")
 
-		// On success, the output should include the following:
-		const successMsgPfx = "✓ Synced"
-
-		_, err := obj.Stdout.WriteString(successMsgPfx)
-		require.NoError(t, err, "failed to write to stdout during mock")
-
 		return nil
 	}
 
+	calls := stubUpdateGit(t, obj, []string{updateTestCommit, updateTestCommit}, nil)
+
 	// Test
-	_, err := obj.Update(args)
+	result, err := obj.Update(args)
 
 	require.NoError(t, err, "failed to update the gist")
+	require.Equal(t, UpdateResult{
+		Branch:       branch,
+		PrevCommit:   updateTestCommit,
+		Commit:       updateTestCommit,
+		Mode:         UpdateUpToDate,
+		ChangedFiles: []string{},
+	}, result, "the local branch should be up to date when syncing a remote")
+	require.Equal(t, []string{
+		"for-each-ref --format=%(objectname) refs/heads/main",
+		"for-each-ref --format=%(objectname) refs/heads/main",
+	}, *calls)
 }
 
-// ----------------------------------------------------------------------------
-//  Failure cases
-// ----------------------------------------------------------------------------
+func TestGisty_Update_reset(t *testing.T) {
+	t.Parallel()
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
-func TestGisty_Update_execute_success_but_wrong_output(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+	const base = "0000000000000000000000000000000000000000"
 
-	// Instantiate the object.
 	obj := NewGisty()
 	args := NewUpdateArgs(t.TempDir())
+	args.Force = true
 
-	// Mock the update function.
-	obj.AltFunctions.Update = func(*sync.SyncOptions) error {
-		const successMsgPfx = "success (no error) but unexpected output"
+	mockUpdateSuccess(obj)
+	stubUpdateGit(t, obj, []string{updateTestPrevCommit, updateTestCommit}, map[string]string{
+		"merge-base " + updateTestPrevCommit + " " + updateTestCommit:                       base,
+		"diff --name-only -z --no-renames " + updateTestPrevCommit + " " + updateTestCommit: "hello.md\x00",
+	})
+
+	result, err := obj.Update(args)
+
+	require.NoError(t, err)
+	require.Equal(t, UpdateReset, result.Mode, "local commits not in the remote should be reported as reset")
+	require.Equal(t, []string{"hello.md"}, result.ChangedFiles)
+}
+
+func TestGisty_Update_new_branch(t *testing.T) {
+	t.Parallel()
+
+	obj := NewGisty()
+	args := NewUpdateArgs(t.TempDir())
+	args.Branch = "dev"
+
+	mockUpdateSuccess(obj)
+	stubUpdateGit(t, obj, []string{"", updateTestCommit}, map[string]string{
+		"ls-tree -r --name-only -z " + updateTestCommit: "hello.md\x00sub/main.go\x00",
+	})
+
+	result, err := obj.Update(args)
+
+	require.NoError(t, err)
+	require.Equal(t, UpdateResult{
+		Branch:       "dev",
+		PrevCommit:   "",
+		Commit:       updateTestCommit,
+		Mode:         UpdateFastForward,
+		ChangedFiles: []string{"hello.md", "sub/main.go"},
+	}, result)
+}
+
+func TestGisty_Update_current_branch(t *testing.T) {
+	t.Parallel()
+
+	obj := NewGisty()
+
+	mockUpdateSuccess(obj)
+	stubUpdateGit(t, obj, []string{updateTestCommit, updateTestCommit}, nil)
+
+	gitRunner := obj.GitRunner
+	obj.GitRunner = func(ctx context.Context, cmd GitCommand) error {
+		switch strings.Join(cmd.Args, " ") {
+		case "symbolic-ref --quiet --short refs/remotes/origin/HEAD":
+			return errors.New("exit status 1")
+		case "rev-parse --abbrev-ref HEAD":
+			_, err := fmt.Fprintln(cmd.Stdout, "feature")
+
+			return err
+		}
+
+		return gitRunner(ctx, cmd)
+	}
 
-		_, err := obj.Stdout.WriteString(successMsgPfx)
-		require.NoError(t, err, "failed to write to stdout during mock")
+	result, err := obj.Update(NewUpdateArgs(t.TempDir()))
+
+	require.NoError(t, err)
+	require.Equal(t, "feature", result.Branch,
+		"the current branch should be used if the default branch of the remote is unknown")
+}
+
+func TestGisty_Update_in_repo_dir(t *testing.T) {
+	t.Parallel()
+
+	pathDirOrig, err := os.Getwd()
+	require.NoError(t, err)
+
+	obj := NewGisty()
+	args := NewUpdateArgs(t.TempDir())
+
+	var gotCmd GHCommand
+
+	obj.GHRunner = func(_ context.Context, cmd GHCommand) error {
+		gotCmd = cmd
 
 		return nil
 	}
 
-	// Test
-	_, err := obj.Update(args)
+	stubUpdateGit(t, obj, []string{updateTestCommit, updateTestCommit}, nil)
 
-	require.Error(t, err, "if update command fails to execute, it should return an error")
-	assert.Contains(t, err.Error(), "failed to sync gist",
-		"it should contain the error reason")
-	assert.Contains(t, err.Error(), "success (no error) but unexpected output",
-		"it should contain the original error")
+	gitRunner := obj.GitRunner
+	obj.GitRunner = func(ctx context.Context, cmd GitCommand) error {
+		require.Equal(t, args.PathDirRepo, cmd.Dir, "git should run in the repository")
+
+		return gitRunner(ctx, cmd)
+	}
+
+	_, err = obj.Update(args)
+
+	require.NoError(t, err)
+	require.Equal(t, args.PathDirRepo, gotCmd.Dir, "gh should run in the repository")
+	require.Equal(t, []string{"repo", "sync"}, gotCmd.Args)
+
+	pathDirCurr, err := os.Getwd()
+
+	require.NoError(t, err)
+	require.Equal(t, pathDirOrig, pathDirCurr, "working directory of the process should not change")
 }
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
+// ----------------------------------------------------------------------------
+//  Failure cases
+// ----------------------------------------------------------------------------
+
 func TestGisty_Update_fails_execute(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+	t.Parallel()
 
 	// Instantiate the object.
 	obj := NewGisty()
@@ -128,6 +270,8 @@ func TestGisty_Update_fails_execute(t *testing.T) {
 		return NewErr("forced error")
 	}
 
+	calls := stubUpdateGit(t, obj, []string{updateTestCommit}, nil)
+
 	// Test
 	_, err := obj.Update(args)
 
@@ -136,71 +280,48 @@ func TestGisty_Update_fails_execute(t *testing.T) {
 		"it should contain the error reason")
 	assert.Contains(t, err.Error(), "forced error",
 		"it should contain the original error")
+	assert.Len(t, *calls, 2, "the branch should not be read again on failure")
 }
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
-func TestGisty_Update_fail_to_change_dir(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+func TestGisty_Update_not_a_repo(t *testing.T) {
+	t.Parallel()
 
-	// Backup and defer restore the original osChdir function.
-	oldOSChdir := osChdir
-
-	defer func() {
-		osChdir = oldOSChdir
-	}()
+	obj := NewGisty()
+	obj.GitRunner = func(_ context.Context, cmd GitCommand) error {
+		_, err := fmt.Fprintln(cmd.Stderr, "fatal: not a git repository")
+		require.NoError(t, err)
 
-	// Mock osChdir to return an error.
-	osChdir = func(_ string) error {
-		return NewErr("forced error")
+		return errors.New("exit status 128")
 	}
 
-	// Instantiate the object.
-	obj := NewGisty()
-	args := NewUpdateArgs(t.TempDir())
+	mockUpdateSuccess(obj)
 
-	// Test
-	_, err := obj.Update(args)
+	_, err := obj.Update(NewUpdateArgs(t.TempDir()))
 
-	require.Error(t, err, "expected error when osChdir returns an error")
-	assert.Contains(t, err.Error(), "failed to change working directory to",
-		"it should contain the error reason")
-	assert.Contains(t, err.Error(), "forced error",
-		"it should contain the original error")
+	require.ErrorContains(t, err, "failed to get the branch of local repository")
+	require.ErrorContains(t, err, "not a git repository")
 }
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
-func TestGisty_Update_fail_to_get_wd(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+func TestGisty_Update_invalid_repo_dir(t *testing.T) {
+	t.Parallel()
 
-	// Backup and defer restore the original osGetwd function.
-	oldOSGetwd := osGetwd
+	obj := NewGisty()
+	pathFile := filepath.Join(t.TempDir(), "file")
 
-	defer func() {
-		osGetwd = oldOSGetwd
-	}()
+	_, err := obj.Update(NewUpdateArgs(pathFile))
 
-	// Mock osGetwd to return an error.
-	osGetwd = func() (string, error) {
-		return "", NewErr("forced error")
-	}
+	require.ErrorContains(t, err, "failed to access local repository")
+	require.ErrorIs(t, err, os.ErrNotExist)
 
-	// Instantiate the object.
-	obj := NewGisty()
-	args := NewUpdateArgs(t.TempDir())
+	require.NoError(t, os.WriteFile(pathFile, nil, 0o600))
 
-	// Test
-	_, err := obj.Update(args)
+	_, err = obj.Update(NewUpdateArgs(pathFile))
 
-	require.Error(t, err, "expected error when osGetwd returns an error")
-	assert.Contains(t, err.Error(), "failed to get current working directory",
-		"it should contain the error reason")
-	assert.Contains(t, err.Error(), "forced error",
-		"it should contain the original error")
+	require.ErrorContains(t, err, "path to local repository is not a directory")
 }
 
-//nolint:paralleltest // do not parallelize due to temporary directory change
 func TestGisty_Update_target_dir_is_empty(t *testing.T) {
-	chDirCleanUp(t) // defer change working dir back to original. see chdir_test.go
+	t.Parallel()
 
 	// Instantiate the object.
 	obj := NewGisty()
@@ -213,28 +334,3 @@ func TestGisty_Update_target_dir_is_empty(t *testing.T) {
 	assert.Contains(t, err.Error(), "path to local repository is required",
 		"it should contain the error reason")
 }
-
-// ----------------------------------------------------------------------------
-//  Helper functions
-// ----------------------------------------------------------------------------
-
-// chDirCleanUp is a helper function that ensures the working directory is changed
-// back to the original working directory.
-func chDirCleanUp(t *testing.T) {
-	t.Helper()
-
-	pathDirOrig, err := os.Getwd()
-	require.NoError(t, err, "failed to get current working directory during test setup")
-
-	// Ensure we return to the original directory regardless of test outcome
-	t.Cleanup(func() {
-		// Only change back if the original directory still exists to avoid
-		// conflicts when temporary directories are cleaned up
-		_, err := os.Stat(pathDirOrig)
-		if err == nil {
-			// Change the working directory back to the original working directory.
-			//nolint:usetesting // t.Chdir() has issues in Go 1.24, stick with os.Chdir()
-			require.NoError(t, os.Chdir(pathDirOrig), "failed to change working directory back to %s", pathDirOrig)
-		}
-	})
-}
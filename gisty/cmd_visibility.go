@@ -0,0 +1,244 @@
+package gisty
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: VisibilityOptions, VisibilityResult
+// ----------------------------------------------------------------------------
+
+// VisibilityOptions are the options for the SetVisibility function.
+type VisibilityOptions struct {
+	// WithHistory carries over the git history of the gist to the new gist by
+	// cloning the gist and pushing it to the new one. It requires git. When
+	// making the gist public, the files of every revision are scanned for
+	// secrets too.
+	WithHistory bool
+	// DeleteOriginal deletes the original gist once the new gist is created.
+	DeleteOriginal bool
+	// AllowSecrets makes the gist public even if Gisty.SecretScanner finds
+	// possible secrets in its files.
+	AllowSecrets bool
+}
+
+// NewVisibilityOptions returns a new VisibilityOptions with the default values.
+func NewVisibilityOptions() VisibilityOptions {
+	return VisibilityOptions{
+		WithHistory:    false,
+		DeleteOriginal: false,
+		AllowSecrets:   false,
+	}
+}
+
+// VisibilityResult is the result of SetVisibility. It maps the original gist
+// to the new one.
+type VisibilityResult struct {
+	OldID   string `json:"old_id"`
+	NewID   string `json:"new_id"` // same as OldID if the visibility was already the requested one.
+	NewURL  string `json:"new_url"`
+	Public  bool   `json:"public"`
+	History bool   `json:"history"` // true if the git history was carried over.
+	Deleted bool   `json:"deleted"` // true if the original gist was deleted.
+}
+
+// ----------------------------------------------------------------------------
+//  Method: SetVisibility
+// ----------------------------------------------------------------------------
+
+// SetVisibility makes the gist public or secret.
+//
+// Since GitHub does not allow changing the visibility of a gist, a new gist is
+// created with the same files and description in the requested visibility.
+// Comments and stars are not carried over. If the gist already has the
+// requested visibility, nothing is done and the result maps the gist to itself.
+//
+// The new gist is checked by g.Policies as a Create with its files. Before
+// making a gist public, its files, and with opts.WithHistory the files of all
+// its revisions, are scanned by g.SecretScanner and a *SecretsError is returned
+// if possible secrets are found, unless opts.AllowSecrets is true. On error
+// after the new gist is created, the returned result holds it and the original
+// gist is kept.
+func (g *Gisty) SetVisibility(gistID string, public bool, opts VisibilityOptions) (VisibilityResult, error) {
+	ctx := context.Background()
+	result := VisibilityResult{OldID: "", NewID: "", NewURL: "", Public: public, History: false, Deleted: false}
+
+	gistID, err := g.gistIDFromArg(gistID)
+	if err != nil {
+		return result, err
+	}
+
+	result.OldID = gistID
+
+	client, err := g.apiClient()
+	if err != nil {
+		return result, err
+	}
+
+	entry, contents, err := fetchGist(ctx, client, gistID, false)
+	if err != nil {
+		return result, err
+	}
+
+	if entry.Public == public {
+		result.NewID = gistID
+		result.NewURL = entry.HTMLURL
+
+		return result, nil
+	}
+
+	if public {
+		err = g.checkSecrets(contents, opts.AllowSecrets)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	entry.Public = public
+
+	err = g.checkPublishPolicies(&entry, contents)
+	if err != nil {
+		return result, err
+	}
+
+	if entry.Public != public {
+		return result, &PolicyViolationError{
+			Policy: "visibility",
+			File:   "",
+			Reason: "the policies do not allow changing the visibility of the gist",
+		}
+	}
+
+	dirRepo := ""
+
+	if opts.WithHistory {
+		dirTemp, err := os.MkdirTemp("", "gisty-visibility-*")
+		if err != nil {
+			return result, WrapIfErr(err, "failed to create temporary directory")
+		}
+
+		defer os.RemoveAll(dirTemp)
+
+		dirRepo, err = g.cloneHistory(gistID, dirTemp, public && !opts.AllowSecrets)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	created, err := publishGist(ctx, client, entry, contents, "", false)
+	if err != nil {
+		return result, WrapIfErr(err, "failed to create the new gist of: %s", gistID)
+	}
+
+	result.NewID = created.ID
+	result.NewURL = created.HTMLURL
+
+	if opts.WithHistory {
+		err = g.pushHistory(dirRepo, created)
+		if err != nil {
+			return result, err
+		}
+
+		result.History = true
+	}
+
+	if opts.DeleteOriginal {
+		err = client.DeleteGist(ctx, gistID)
+		if err != nil {
+			return result, wrapAPIErr(err, "failed to delete the original gist: %s", gistID)
+		}
+
+		result.Deleted = true
+	}
+
+	return result, nil
+}
+
+// cloneHistory clones the gist into dirTemp and returns the path of the clone.
+// If scan is true, the files of every revision are scanned for secrets, since
+// the whole history is published.
+func (g *Gisty) cloneHistory(gistID, dirTemp string, scan bool) (string, error) {
+	args := NewCloneArgs(gistID)
+	args.Dir = filepath.Join(dirTemp, gistID)
+
+	cloned, err := g.Clone(args)
+	if err != nil {
+		return "", WrapIfErr(err, "failed to clone gist: %s", gistID)
+	}
+
+	if scan {
+		err = g.checkHistorySecrets(cloned.Path)
+	}
+
+	return cloned.Path, err
+}
+
+// checkHistorySecrets scans the files of every revision in the repository of
+// the cloned gist with g.SecretScanner. Each file content is scanned once and
+// reported as "<file>@<revision>" of the latest revision having it.
+func (g *Gisty) checkHistorySecrets(dirRepo string) error {
+	if g.SecretScanner == nil {
+		return nil
+	}
+
+	revisions, err := g.runGit(dirRepo, "rev-list", "--all")
+	if err != nil {
+		return WrapIfErr(err, "failed to list the revisions of the gist")
+	}
+
+	files := map[string][]byte{}
+	scanned := map[string]bool{}
+
+	for _, revision := range strings.Fields(revisions) {
+		// E.g. "100644 blob <sha>\t<name>\x00" for each file.
+		tree, err := g.runGit(dirRepo, "ls-tree", "-z", revision)
+		if err != nil {
+			return WrapIfErr(err, "failed to list the files of revision: %s", revision)
+		}
+
+		for _, entry := range strings.Split(tree, "\x00") {
+			meta, name, ok := strings.Cut(entry, "\t")
+			fields := strings.Fields(meta)
+
+			if !ok || len(fields) != 3 || fields[1] != "blob" || scanned[fields[2]] {
+				continue
+			}
+
+			scanned[fields[2]] = true
+
+			content, err := g.runGit(dirRepo, "cat-file", "blob", fields[2])
+			if err != nil {
+				return WrapIfErr(err, "failed to read file %s of revision: %s", name, revision)
+			}
+
+			files[name+"@"+revision] = []byte(content)
+		}
+	}
+
+	return g.checkSecrets(files, false)
+}
+
+// pushHistory force-pushes the history of the cloned gist at dirRepo to the
+// default branch of the new gist.
+func (g *Gisty) pushHistory(dirRepo string, newGist *gistapi.Gist) error {
+	// E.g. "ref: refs/heads/main\tHEAD\n<sha>\tHEAD"
+	out, err := g.runGit(dirRepo, append(gitAuthArgs(), "ls-remote", "--symref", newGist.GitPushURL, "HEAD")...)
+	if err != nil {
+		return WrapIfErr(err, "failed to get the default branch of the new gist: %s", newGist.ID)
+	}
+
+	branch := "refs/heads/main"
+
+	if ref, ok := strings.CutPrefix(out, "ref: "); ok {
+		branch, _, _ = strings.Cut(ref, "\t")
+	}
+
+	_, err = g.runGit(dirRepo, append(gitAuthArgs(), "push", "--force", newGist.GitPushURL, "HEAD:"+branch)...)
+
+	return WrapIfErr(err, "failed to push the history to the new gist: %s", newGist.ID)
+}
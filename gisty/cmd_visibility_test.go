@@ -0,0 +1,152 @@
+package gisty_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGisty_SetVisibility(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	result, err := obj.SetVisibility(gistIDs[1], true, gisty.NewVisibilityOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, gistIDs[1], result.OldID)
+	require.NotEqual(t, gistIDs[1], result.NewID)
+	require.True(t, result.Public)
+	require.False(t, result.History)
+	require.False(t, result.Deleted)
+
+	created, ok := srv.Gist(result.NewID)
+
+	require.True(t, ok)
+	require.True(t, created.Public)
+	require.Equal(t, "secret gist", created.Description)
+	require.Equal(t, map[string]string{"secret.txt": "s3cr3t"}, created.Files)
+
+	_, ok = srv.Gist(gistIDs[1])
+
+	require.True(t, ok, "original gist should be kept by default")
+}
+
+func TestGisty_SetVisibility_same_visibility(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	result, err := obj.SetVisibility(gistIDs[0], true, gisty.NewVisibilityOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, gistIDs[0], result.NewID, "gist should be mapped to itself")
+	require.Len(t, srv.Gists(), 2, "no gist should be created")
+}
+
+func TestGisty_SetVisibility_history_and_delete(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	stubClone(t, obj)
+
+	gitCalls := [][]string{}
+
+	obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+		require.NotEmpty(t, cmd.Dir)
+
+		if cmd.Args[0] == "rev-parse" {
+			return nil // checked-out commit of the clone
+		}
+
+		// Skip the credential options.
+		args := cmd.Args[4:]
+		gitCalls = append(gitCalls, args)
+
+		if args[0] == "ls-remote" {
+			_, err := fmt.Fprint(cmd.Stdout, "ref: refs/heads/master\tHEAD\n0123abcd\tHEAD\n")
+
+			return err
+		}
+
+		return nil
+	}
+
+	opts := gisty.NewVisibilityOptions()
+	opts.WithHistory = true
+	opts.DeleteOriginal = true
+
+	result, err := obj.SetVisibility(gistIDs[0], false, opts)
+
+	require.NoError(t, err)
+	require.True(t, result.History)
+	require.True(t, result.Deleted)
+
+	pushURL := "https://gist.github.com/" + result.NewID + ".git"
+
+	require.Equal(t, [][]string{
+		{"ls-remote", "--symref", pushURL, "HEAD"},
+		{"push", "--force", pushURL, "HEAD:refs/heads/master"},
+	}, gitCalls)
+
+	_, ok := srv.Gist(gistIDs[0])
+
+	require.False(t, ok, "original gist should be deleted")
+}
+
+func TestGisty_SetVisibility_push_failure_keeps_original(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	stubClone(t, obj)
+
+	obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+		if cmd.Args[0] == "rev-parse" {
+			return nil // checked-out commit of the clone
+		}
+
+		_, err := fmt.Fprint(cmd.Stderr, "remote: rejected")
+		require.NoError(t, err)
+
+		return errors.New("forced error")
+	}
+
+	opts := gisty.NewVisibilityOptions()
+	opts.WithHistory = true
+	opts.DeleteOriginal = true
+
+	result, err := obj.SetVisibility(gistIDs[1], true, opts)
+
+	require.ErrorContains(t, err, "remote: rejected")
+	require.NotEmpty(t, result.NewID, "created gist should be returned on error")
+	require.False(t, result.Deleted)
+	srv.AssertNotRequested(t, http.MethodDelete, "/gists/"+gistIDs[1])
+}
+
+func TestGisty_SetVisibility_policies(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+	obj.Policies = []gisty.Policy{gisty.ForceSecretPolicy{Reject: false}}
+
+	_, err := obj.SetVisibility(gistIDs[1], true, gisty.NewVisibilityOptions())
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.Len(t, srv.Gists(), 2, "gist should not be created")
+
+	_, err = obj.SetVisibility("", true, gisty.NewVisibilityOptions())
+
+	require.ErrorIs(t, err, gisty.ErrInvalidGistID)
+}
@@ -0,0 +1,302 @@
+package gisty
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: WatchOptions
+// ----------------------------------------------------------------------------
+
+// Default values of WatchOptions.
+const (
+	WatchIntervalDefault   = time.Second
+	WatchDebounceDefault   = 2 * time.Second
+	WatchMaxBackoffDefault = 5 * time.Minute
+)
+
+// WatchOptions are the options for the Watch function.
+type WatchOptions struct {
+	// OnPublish is called after each attempt to publish the changes. If nil,
+	// nothing is called.
+	OnPublish func(WatchEvent)
+	// Interval is the interval to poll the directory for changes.
+	Interval time.Duration
+	// Debounce is the time to wait after the last change before publishing.
+	Debounce time.Duration
+	// MaxBackoff is the maximum time to wait before retrying a failed publish.
+	MaxBackoff time.Duration
+	// AllowSecrets publishes the changes even if Gisty.SecretScanner finds
+	// possible secrets in them.
+	AllowSecrets bool
+}
+
+// NewWatchOptions returns a new WatchOptions with the default values.
+func NewWatchOptions() WatchOptions {
+	return WatchOptions{
+		OnPublish:    nil,
+		Interval:     WatchIntervalDefault,
+		Debounce:     WatchDebounceDefault,
+		MaxBackoff:   WatchMaxBackoffDefault,
+		AllowSecrets: false,
+	}
+}
+
+// WatchEvent is the result of an attempt to publish the changes by Watch.
+type WatchEvent struct {
+	// Err is the error of the attempt. Nil on success.
+	Err error
+	// Revision is the revision of the gist after the publish.
+	Revision string
+	// Actions are the changes published to the gist. Or tried to on error.
+	Actions []SyncAction
+	// RetryIn is the time to wait before retrying on error.
+	RetryIn time.Duration
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Watch
+// ----------------------------------------------------------------------------
+
+// Watch polls the regular files in dir and publishes the changes to the gist
+// through the API until ctx is canceled. The gist mirrors the directory: local
+// changes, deletions and renames are pushed to the gist, and the changes made to
+// the gist by others are overwritten.
+//
+// The changes are published once the files stay unchanged for opts.Debounce.
+// On rate limits, server errors and network errors, the publish is retried with
+// an exponential backoff, up to opts.MaxBackoff, or after the time requested by
+// the API. Other errors stop watching. Hidden files, on both sides, and
+// subdirectories are ignored. It returns ctx.Err() when canceled.
+//
+// If g.SecretScanner finds possible secrets in the changes, they are not
+// published and the *SecretsError is notified to opts.OnPublish, unless
+// opts.AllowSecrets is true. Likewise for the violations of g.Policies, which
+// check dir as an Update. Watching continues until the files change again.
+func (g *Gisty) Watch(ctx context.Context, dir, gistID string, opts WatchOptions) error {
+	gistID, err := g.gistIDFromArg(gistID)
+	if err != nil {
+		return err
+	}
+
+	client, err := g.apiClient()
+	if err != nil {
+		return err
+	}
+
+	gist, err := client.GetGist(ctx, gistID)
+	if err != nil {
+		return wrapAPIErr(err, "failed to get gist: %s", gistID)
+	}
+
+	watcher := &watcher{
+		gisty:     g,
+		rejected:  nil,
+		client:    client,
+		opts:      opts,
+		gistID:    gistID,
+		dir:       dir,
+		published: map[string]string{},
+		current:   nil,
+		changedAt: time.Time{},
+		retryAt:   time.Time{},
+		backoff:   0,
+	}
+
+	for name, file := range gist.Files {
+		if !isHiddenFile(name) {
+			watcher.published[name] = file.Content
+		}
+	}
+
+	ticker := time.NewTicker(opts.Interval)
+	defer ticker.Stop()
+
+	for {
+		err = watcher.poll(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err() //nolint:wrapcheck // let the caller compare with context errors
+		case <-ticker.C:
+		}
+	}
+}
+
+// watcher holds the state of Watch.
+type watcher struct {
+	changedAt time.Time
+	retryAt   time.Time
+	gisty     *Gisty
+	client    *gistapi.Client
+	published map[string]string // files in the gist.
+	rejected  map[string]string // files in the directory rejected by the secret scanner or the policies.
+	current   map[string]string // files in the directory at the last poll.
+	opts      WatchOptions
+	gistID    string
+	dir       string
+	backoff   time.Duration
+}
+
+// poll reads the directory and publishes the changes if they are settled.
+func (w *watcher) poll(ctx context.Context, now time.Time) error {
+	files, err := readLocalFiles(w.dir)
+	if err != nil {
+		return err
+	}
+
+	if w.current == nil || !maps.Equal(files, w.current) {
+		w.current = files
+		w.changedAt = now
+	}
+
+	if maps.Equal(w.current, w.published) || now.Sub(w.changedAt) < w.opts.Debounce || now.Before(w.retryAt) {
+		return nil
+	}
+
+	if w.rejected != nil && maps.Equal(w.current, w.rejected) {
+		return nil
+	}
+
+	actions, edits := planPublish(w.published, w.current)
+
+	changes := map[string][]byte{}
+
+	for name, edit := range edits {
+		// Renames keep the published content. Any other file is scanned,
+		// even if it is empty.
+		if edit != nil && edit.Filename == "" {
+			changes[name] = []byte(edit.Content)
+		}
+	}
+
+	err = w.gisty.checkSecrets(changes, w.opts.AllowSecrets)
+	if err == nil {
+		err = w.gisty.checkUpdateDir(w.dir, "Watch")
+	}
+
+	if err != nil {
+		w.rejected = w.current
+
+		w.notify(WatchEvent{Err: err, Revision: "", Actions: actions, RetryIn: 0})
+
+		return nil
+	}
+
+	gist, err := w.client.EditGist(ctx, w.gistID, edits)
+	if err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err() //nolint:wrapcheck // let the caller compare with context errors
+		}
+
+		wait, retry := w.retryIn(err, now)
+
+		w.notify(WatchEvent{Err: err, Revision: "", Actions: actions, RetryIn: wait})
+
+		if !retry {
+			return wrapAPIErr(err, "failed to publish changes to gist: %s", w.gistID)
+		}
+
+		w.retryAt = now.Add(wait)
+
+		return nil
+	}
+
+	w.published = w.current
+	w.backoff = 0
+
+	w.notify(WatchEvent{Err: nil, Revision: gist.Revision(), Actions: actions, RetryIn: 0})
+
+	return nil
+}
+
+// retryIn returns the time to wait before retrying after err, and false if err
+// is not worth retrying.
+func (w *watcher) retryIn(err error, now time.Time) (time.Duration, bool) {
+	var errHTTP *gistapi.HTTPError
+
+	if errors.As(err, &errHTTP) {
+		if wait, ok := errHTTP.RetryAfter(now); ok {
+			return min(wait, w.opts.MaxBackoff), true
+		}
+
+		if errHTTP.StatusCode < http.StatusInternalServerError {
+			return 0, false
+		}
+	}
+
+	w.backoff = min(max(w.backoff*2, w.opts.Interval), w.opts.MaxBackoff) //nolint:mnd // exponential backoff
+
+	return w.backoff, true
+}
+
+func (w *watcher) notify(event WatchEvent) {
+	if w.opts.OnPublish != nil {
+		w.opts.OnPublish(event)
+	}
+}
+
+// planPublish returns the actions and the gist edits to make the published
+// files the same as the current ones. A file deleted and another one added with
+// the same content is published as a rename.
+func planPublish(published, current map[string]string) ([]SyncAction, map[string]*gistapi.EditFile) {
+	actions := []SyncAction{}
+	edits := map[string]*gistapi.EditFile{}
+
+	added := []string{}
+
+	for _, name := range slices.Sorted(maps.Keys(current)) {
+		content, ok := published[name]
+
+		switch {
+		case !ok:
+			added = append(added, name)
+		case content != current[name]:
+			actions = append(actions, SyncAction{File: name, From: "", Type: SyncPush})
+			edits[name] = &gistapi.EditFile{Content: current[name], Filename: ""}
+		}
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(published)) {
+		if _, ok := current[name]; ok {
+			continue
+		}
+
+		index := slices.IndexFunc(added, func(newName string) bool {
+			return current[newName] == published[name]
+		})
+
+		if index < 0 {
+			actions = append(actions, SyncAction{File: name, From: "", Type: SyncDeleteRemote})
+			edits[name] = nil
+
+			continue
+		}
+
+		actions = append(actions, SyncAction{File: added[index], From: name, Type: SyncRenameRemote})
+		edits[name] = &gistapi.EditFile{Content: "", Filename: added[index]}
+		added = slices.Delete(added, index, index+1)
+	}
+
+	for _, name := range added {
+		actions = append(actions, SyncAction{File: name, From: "", Type: SyncPush})
+		edits[name] = &gistapi.EditFile{Content: current[name], Filename: ""}
+	}
+
+	slices.SortFunc(actions, func(a, b SyncAction) int {
+		return strings.Compare(a.File, b.File)
+	})
+
+	return actions, edits
+}
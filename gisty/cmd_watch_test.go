@@ -0,0 +1,131 @@
+package gisty_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+// startWatch runs Watch in the background and returns the channels of the
+// publish events and the returned error.
+func startWatch(ctx context.Context, obj *gisty.Gisty, dir string) (<-chan gisty.WatchEvent, <-chan error) {
+	events := make(chan gisty.WatchEvent, 10)
+	done := make(chan error, 1)
+
+	opts := gisty.NewWatchOptions()
+	opts.Interval = 5 * time.Millisecond
+	opts.Debounce = 20 * time.Millisecond
+	opts.OnPublish = func(event gisty.WatchEvent) {
+		events <- event
+	}
+
+	go func() {
+		done <- obj.Watch(ctx, dir, syncTestGistID, opts)
+	}()
+
+	return events, done
+}
+
+func waitEvent(t *testing.T, events <-chan gisty.WatchEvent) gisty.WatchEvent {
+	t.Helper()
+
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "timeout waiting for the publish event")
+	}
+
+	return gisty.WatchEvent{} //nolint:exhaustruct // unreachable
+}
+
+func TestGisty_Watch(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{
+		"edit.txt": "v1", "old.txt": "rename me", "gone.txt": "delete me", ".env": "remote only",
+	})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{
+		"edit.txt": "v2", "new.txt": "rename me", "added.txt": "added", ".hidden": "ignored",
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, done := startWatch(ctx, obj, dir)
+
+	// The initial differences are published.
+	event := waitEvent(t, events)
+
+	require.NoError(t, event.Err)
+	require.Equal(t, []string{
+		"push added.txt",
+		"push edit.txt",
+		"delete-remote gone.txt",
+		"rename-remote old.txt -> new.txt",
+	}, actionStrings(event.Actions))
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, map[string]string{
+		"edit.txt": "v2", "new.txt": "rename me", "added.txt": "added", ".env": "remote only",
+	}, gist.Files, "the hidden gist files should be kept")
+	require.Equal(t, gist.Revision(), event.Revision)
+
+	// Rate limited publish is retried.
+	srv.FailNext(http.MethodPatch, "/gists/"+syncTestGistID, http.StatusForbidden,
+		http.Header{"Retry-After": {"0"}})
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "added.txt")))
+
+	event = waitEvent(t, events)
+
+	require.Error(t, event.Err)
+	require.Equal(t, []string{"delete-remote added.txt"}, actionStrings(event.Actions))
+
+	event = waitEvent(t, events)
+
+	require.NoError(t, event.Err)
+	require.Equal(t, []string{"delete-remote added.txt"}, actionStrings(event.Actions))
+
+	gist, _ = srv.Gist(syncTestGistID)
+	require.NotContains(t, gist.Files, "added.txt")
+
+	cancel()
+
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestGisty_Watch_fatal_error(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"file.txt": "v1"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"file.txt": "v2"})
+	srv.FailNext(http.MethodPatch, "/gists/"+syncTestGistID, http.StatusUnprocessableEntity, nil)
+
+	events, done := startWatch(context.Background(), obj, dir)
+
+	event := waitEvent(t, events)
+
+	require.Error(t, event.Err)
+	require.ErrorContains(t, <-done, "failed to publish changes")
+}
+
+func TestGisty_Watch_not_found(t *testing.T) {
+	t.Parallel()
+
+	_, obj := newSyncTestServer(t, map[string]string{"file.txt": "v1"})
+
+	err := obj.Watch(context.Background(), t.TempDir(), "ffff", gisty.NewWatchOptions())
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"slices"
 	"strings"
 	"testing"
@@ -17,13 +18,18 @@ func TestGisty_default_commands(t *testing.T) {
 	stubGHCommand(t, false)
 
 	obj := NewGisty()
-	require.NoError(t, obj.Clone([]string{"dummy"}))
+	argsClone := NewCloneArgs("dummy")
+	argsClone.Dir = filepath.Join(t.TempDir(), "dummy")
+	cloned, err := obj.Clone(argsClone)
+	require.NoError(t, err)
+	require.Equal(t, CloneResult{Path: argsClone.Dir, Commit: helperCommit}, cloned)
 
 	obj = NewGisty()
 	gistURL, err := obj.Create(CreateArgs{
-		Description: "",
-		FilePaths:   []string{"testdata/foo.md"},
-		AsPublic:    false,
+		Description:  "",
+		FilePaths:    []string{"testdata/foo.md"},
+		AsPublic:     false,
+		AllowSecrets: false,
 	})
 	require.NoError(t, err)
 	require.Equal(t, "https://gist.github.com/dummy", gistURL.String())
@@ -66,9 +72,13 @@ func TestGisty_runGH_error(t *testing.T) {
 		run  func() error
 	}{
 		{name: "runGH", run: func() error { return obj.runGH("version") }},
-		{name: "clone", run: func() error { return obj.Clone([]string{"dummy"}) }},
+		{name: "clone", run: func() error {
+			_, err := obj.Clone(NewCloneArgs("dummy"))
+
+			return err
+		}},
 		{name: "create", run: func() error {
-			_, err := obj.Create(CreateArgs{Description: "", FilePaths: nil, AsPublic: false})
+			_, err := obj.Create(CreateArgs{Description: "", FilePaths: nil, AsPublic: false, AllowSecrets: false})
 
 			return err
 		}},
@@ -89,7 +99,10 @@ func TestGisty_runGH_error(t *testing.T) {
 			return err
 		}},
 		{name: "update", run: func() error {
-			_, err := obj.Update(NewUpdateArgs(t.TempDir()))
+			objUpdate := NewGisty()
+			objUpdate.GitRunner = func(context.Context, GitCommand) error { return nil }
+
+			_, err := objUpdate.Update(NewUpdateArgs(t.TempDir()))
 
 			return err
 		}},
@@ -100,6 +113,10 @@ func TestGisty_runGH_error(t *testing.T) {
 	}
 }
 
+// helperCommit is the commit SHA printed by the helper process for
+// "git rev-parse HEAD".
+const helperCommit = "0123456789abcdef0123456789abcdef01234567"
+
 //nolint:paralleltest // This test is executed as a subprocess by stubGHCommand.
 func TestGHHelperProcess(t *testing.T) {
 	if os.Getenv("GO_WANT_GH_HELPER_PROCESS") != "1" {
@@ -116,6 +133,11 @@ func TestGHHelperProcess(t *testing.T) {
 	}
 
 	switch strings.Join(args[:min(2, len(args))], " ") {
+	case "gist clone":
+		require.NoError(t, os.MkdirAll(args[3], 0o700))
+	case "rev-parse HEAD":
+		_, err := fmt.Fprint(os.Stdout, helperCommit+"\n")
+		require.NoError(t, err)
 	case "gist create":
 		_, err := fmt.Fprint(os.Stdout, "https://gist.github.com/dummy\n")
 		require.NoError(t, err)
@@ -130,6 +152,9 @@ func TestGHHelperProcess(t *testing.T) {
 			_, err := fmt.Fprint(os.Stdout, "'42'")
 			require.NoError(t, err)
 		}
+	case "api user":
+		_, err := fmt.Fprint(os.Stdout, os.Getenv("GH_HOST")+" "+os.Getenv("GH_TOKEN"))
+		require.NoError(t, err)
 	case "repo sync":
 		_, err := fmt.Fprint(os.Stdout, "✓ Synced\n")
 		require.NoError(t, err)
@@ -138,6 +163,18 @@ func TestGHHelperProcess(t *testing.T) {
 	os.Exit(0)
 }
 
+//nolint:paralleltest // This test replaces the package-level command executor.
+func TestGisty_runGH_env(t *testing.T) {
+	stubGHCommand(t, false)
+
+	obj := NewGisty()
+	obj.Host = "ghe.example.com"
+	obj.SetToken("dummy-token")
+
+	require.NoError(t, obj.runGH("api", "user"))
+	require.Equal(t, "ghe.example.com dummy-token", obj.Stdout.String())
+}
+
 func stubGHCommand(t *testing.T, forceError bool) {
 	t.Helper()
 
@@ -161,3 +198,24 @@ func stubGHCommand(t *testing.T, forceError bool) {
 		return cmd
 	}
 }
+
+func TestGisty_GHRunner(t *testing.T) {
+	t.Parallel()
+
+	var gotArgs []string
+
+	obj := NewGisty()
+	obj.GHRunner = func(_ context.Context, cmd GHCommand) error {
+		gotArgs = cmd.Args
+
+		_, err := fmt.Fprint(cmd.Stdout, "https://gist.github.com/dummy\n")
+
+		return err
+	}
+
+	gistURL, err := obj.Create(CreateArgs{Description: "", FilePaths: []string{"foo.md"}, AsPublic: true, AllowSecrets: false})
+
+	require.NoError(t, err)
+	require.Equal(t, "https://gist.github.com/dummy", gistURL.String())
+	require.Equal(t, []string{commandGist, "create", "--public", "foo.md"}, gotArgs)
+}
@@ -0,0 +1,35 @@
+package gisty
+
+import "errors"
+
+// Errors that the callers can check with errors.Is to distinguish the failure
+// reasons. The errors returned by Gisty wrap them.
+var (
+	// ErrInvalidGistID is returned when the given gist ID or URL is malformed
+	// or empty.
+	ErrInvalidGistID = errors.New("invalid gist ID")
+	// ErrNotFound is returned when the requested gist does not exist or is not
+	// accessible by the authenticated user.
+	ErrNotFound = errors.New("gist not found")
+	// ErrSyncConflict is returned by Sync when files were changed differently
+	// on both the local and the remote sides.
+	ErrSyncConflict = errors.New("sync conflict")
+	// ErrSecretFound is returned when the files to be published contain
+	// possible secrets. See SecretScanner.
+	ErrSecretFound = errors.New("secret found")
+	// ErrPolicyViolation is returned when the arguments violate a policy. See
+	// Policy.
+	ErrPolicyViolation = errors.New("policy violation")
+	// ErrTemplateVars is returned when the variables used by a gist template
+	// are not given. See CreateFromTemplate.
+	ErrTemplateVars = errors.New("template variables not given")
+	// ErrUntrustedScript is returned when a gist script to run was never
+	// trusted or changed since it was trusted. See RunScript.
+	ErrUntrustedScript = errors.New("script not trusted")
+	// ErrVendorDrift is returned when the vendored gist files drifted from the
+	// lockfile or the manifest. See Gisty.VerifyVendor.
+	ErrVendorDrift = errors.New("vendored files drifted")
+	// ErrPushRejected is returned by Push when the remote branch has commits
+	// that are not in the local branch. See PushRejectedError.
+	ErrPushRejected = errors.New("push rejected")
+)
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
 	"github.com/cli/cli/v2/pkg/cmd/api"
 	"github.com/cli/cli/v2/pkg/cmd/gist/list"
 )
@@ -16,12 +17,38 @@ import (
 
 //nolint:lll // long line comment is intentional
 func ExampleGisty_Comments() {
-	// Instantiate a new Gisty object.
-	obj := gisty.NewGisty()
+	// Start a fake GitHub API server to avoid calling the actual GitHub API
+	// during test/example. Usually, you do not need to set this.
+	srv := gistytest.NewServer()
+	defer srv.Close()
 
-	// Get the comments in the gist.
 	const gistID = "42f5f23053ab59ca480f480b8d01e1fd"
 
+	srv.AddGist(gistytest.Gist{
+		ID:    gistID,
+		Files: map[string]string{"example.md": "# Example"},
+		Comments: []gisty.Comment{{
+			Author: gisty.Author{
+				AvatarURL: "https://avatars.githubusercontent.com/u/11840938?u=e915b35bd36abfdcbbaaa6fbe5ea0c6e8ee51e70&v=4",
+				Login:     "KEINOS",
+			},
+			ID:                "GC_lADOALStqtoAIDQyZjVmMjMwNTNhYjU5Y2E0ODBmNDgwYjhkMDFlMWZkzgBF6l4",
+			AuthorAssociation: "OWNER",
+			BodyRaw:           "1st example comment @ 20230528.\r\n\r\n- This line was added by edit.",
+			BodyHTML:          "<p dir=\"auto\">1st example comment @ 20230528.</p>\n<ul dir=\"auto\">\n<li>This line was added by edit.</li>\n</ul>",
+			BodyText:          "1st example comment @ 20230528.\n\nThis line was added by edit.",
+			CreatedAt:         "2023-05-28T08:36:32Z",
+			PublishedAt:       "2023-05-28T08:36:32Z",
+			LastEditedAt:      "2023-05-28T08:44:10Z",
+			IsMinimized:       false,
+			MinimizedReason:   "",
+		}},
+	})
+
+	// Instantiate a new Gisty object pointed at the fake server.
+	obj := srv.NewGisty()
+
+	// Get the comments in the gist.
 	comments, err := obj.Comments(gistID)
 	if err != nil {
 		log.Fatal(err)
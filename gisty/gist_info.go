@@ -12,11 +12,11 @@ import (
 
 // GistInfo holds information about a gist.
 type GistInfo struct {
-	UpdatedAt   time.Time // UpdatedAt is the time when the gist was last updated.
-	GistID      string    // GistID is the ID of the gist.
-	Description string    // Description is the description of the gist.
-	Files       int       // Files is the number of files in the gist.
-	IsPublic    bool      // IsPublic is true if the gist is public.
+	UpdatedAt   time.Time `json:"updated_at"`  // UpdatedAt is the time when the gist was last updated.
+	GistID      string    `json:"id"`          // GistID is the ID of the gist.
+	Description string    `json:"description"` // Description is the description of the gist.
+	Files       int       `json:"files"`       // Files is the number of files in the gist.
+	IsPublic    bool      `json:"public"`      // IsPublic is true if the gist is public.
 }
 
 // ----------------------------------------------------------------------------
@@ -0,0 +1,179 @@
+package gisty
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// hostGitHub is the host of github.com, which serves the gists at
+// gist.github.com and their raw files at gist.githubusercontent.com.
+const hostGitHub = "github.com"
+
+var (
+	reGistID       = regexp.MustCompile(`^[0-9A-Za-z]+$`)
+	reGistRevision = regexp.MustCompile(`^[0-9A-Fa-f]{4,40}$`)
+	reGistOwner    = regexp.MustCompile(`^[0-9A-Za-z][0-9A-Za-z-]*$`)
+	// reSCPLikeURL matches the scp-like syntax of git URLs. E.g.
+	// "git@gist.github.com:<id>.git".
+	reSCPLikeURL = regexp.MustCompile(`^[^/@:]+@([^/:]+):(.+)$`)
+)
+
+// ----------------------------------------------------------------------------
+//  Type: GistRef
+// ----------------------------------------------------------------------------
+
+// GistRef is a reference to a gist, optionally at a revision and to a file,
+// parsed by ParseGistRef.
+type GistRef struct {
+	// Host is the GitHub host of the gist, such as "github.com" or the host of
+	// a GitHub Enterprise Server. Empty if the reference is a bare gist ID.
+	Host string `json:"host,omitempty"`
+	// Owner is the login of the owner of the gist, if the reference has it.
+	Owner string `json:"owner,omitempty"`
+	// ID is the gist ID. It is never empty.
+	ID string `json:"id"`
+	// Revision is the SHA of the gist revision. Empty for the latest one.
+	Revision string `json:"revision,omitempty"`
+	// File is the name of the file, if the reference is a raw file URL.
+	File string `json:"file,omitempty"`
+}
+
+// ParseGistRef parses a gist ID or URL. The accepted forms are:
+//
+//	<id>
+//	<id>@<revision>
+//	https://gist.github.com/<id>
+//	https://gist.github.com/<owner>/<id>
+//	https://gist.github.com/<owner>/<id>/<revision>
+//	https://gist.githubusercontent.com/<owner>/<id>/raw/<revision>/<file>
+//	https://gist.githubusercontent.com/<owner>/<id>/raw/<file>
+//	https://gist.github.com/<id>.git
+//	git@gist.github.com:<id>.git
+//	https://<ghes-host>/gist/<owner>/<id>
+//	https://gist.<ghes-host>/<owner>/<id>
+//
+// The scheme may be omitted, the fragment and the query are ignored and a
+// "@<revision>" suffix is accepted after the ID of the URLs too. The error
+// wraps ErrInvalidGistID if ref is not a gist reference.
+func ParseGistRef(ref string) (GistRef, error) {
+	result := GistRef{Host: "", Owner: "", ID: "", Revision: "", File: ""}
+	if ref == "" {
+		return result, WrapIfErr(ErrInvalidGistID, "no gist specified")
+	}
+
+	if !strings.Contains(ref, "/") && !strings.Contains(ref, ":") {
+		return result, result.setID(ref, ref)
+	}
+
+	host, elems, err := splitGistURL(ref)
+	if err != nil {
+		return result, WrapIfErr(err, "failed to parse gist ID from URL: %q", ref)
+	}
+
+	result.Host = host
+
+	switch {
+	case len(elems) == 1:
+		return result, result.setID(elems[0], ref)
+	case len(elems) > 1:
+		result.Owner = elems[0]
+
+		if !reGistOwner.MatchString(result.Owner) {
+			return result, WrapIfErr(ErrInvalidGistID, "invalid owner of gist URL: %q", ref)
+		}
+
+		err = result.setID(elems[1], ref)
+		if err != nil {
+			return result, err
+		}
+	}
+
+	return result, result.setRest(elems[2:], ref)
+}
+
+// setID sets the ID and the revision from "<id>[.git][@<revision>]".
+func (r *GistRef) setID(elem, ref string) error {
+	elem = strings.TrimSuffix(elem, ".git")
+
+	id, revision, hasRevision := strings.Cut(elem, "@")
+	if !reGistID.MatchString(id) {
+		return WrapIfErr(ErrInvalidGistID, "invalid gist ID: %q", ref)
+	}
+
+	if hasRevision && !reGistRevision.MatchString(revision) {
+		return WrapIfErr(ErrInvalidGistID, "invalid revision of gist: %q", ref)
+	}
+
+	r.ID = id
+	r.Revision = revision
+
+	return nil
+}
+
+// setRest sets the revision and the file from the path elements after the
+// owner and the ID: "<revision>", "raw/<file>" or "raw/<revision>/<file>".
+func (r *GistRef) setRest(elems []string, ref string) error {
+	switch {
+	case len(elems) == 0:
+		return nil
+	case len(elems) == 1 && elems[0] == "revisions":
+		return nil
+	case len(elems) == 1 && r.Revision == "" && reGistRevision.MatchString(elems[0]):
+		r.Revision = elems[0]
+
+		return nil
+	case elems[0] == "raw" && len(elems) == 2: //nolint:mnd // raw/<file>
+		r.File = elems[1]
+
+		return nil
+	case elems[0] == "raw" && len(elems) == 3 && reGistRevision.MatchString(elems[1]): //nolint:mnd // raw/<rev>/<file>
+		if r.Revision != "" && r.Revision != elems[1] {
+			return WrapIfErr(ErrInvalidGistID, "conflicting revisions in gist URL: %q", ref)
+		}
+
+		r.Revision = elems[1]
+		r.File = elems[2]
+
+		return nil
+	}
+
+	return WrapIfErr(ErrInvalidGistID, "unsupported gist URL: %q", ref)
+}
+
+// splitGistURL returns the GitHub host and the path elements of the gist URL,
+// without the "gist/" prefix of GitHub Enterprise Server.
+func splitGistURL(input string) (string, []string, error) {
+	var host, pathURL string
+
+	if match := reSCPLikeURL.FindStringSubmatch(input); match != nil && !strings.Contains(input, "://") {
+		host, pathURL = match[1], match[2]
+	} else {
+		if !strings.Contains(input, "://") {
+			input = "https://" + input
+		}
+
+		parsed, err := url.Parse(input)
+		if err != nil {
+			return "", nil, WrapIfErr(ErrInvalidGistID, "%s", err.Error())
+		}
+
+		host, pathURL = parsed.Host, parsed.Path
+	}
+
+	elems := strings.Split(strings.Trim(pathURL, "/"), "/")
+	if elems[0] == "" {
+		return "", nil, WrapIfErr(ErrInvalidGistID, "no gist ID in URL")
+	}
+
+	switch {
+	case host == "gist.githubusercontent.com":
+		return hostGitHub, elems, nil
+	case strings.HasPrefix(host, "gist."):
+		return strings.TrimPrefix(host, "gist."), elems, nil
+	case elems[0] == "gist" && len(elems) > 1:
+		return host, elems[1:], nil
+	}
+
+	return "", nil, WrapIfErr(ErrInvalidGistID, "not a gist URL of %s", host)
+}
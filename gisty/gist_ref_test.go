@@ -0,0 +1,89 @@
+package gisty_test
+
+import (
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseGistRef(t *testing.T) {
+	t.Parallel()
+
+	const (
+		id  = "5b10b34f87955dfc86d310cd623a61d1"
+		rev = "6d2a3c6a1b0c2e6f4a0d3c1b9e8f7a6b5c4d3e2f"
+	)
+
+	for _, test := range []struct {
+		input string
+		want  gisty.GistRef
+	}{
+		{id, gisty.GistRef{Host: "", Owner: "", ID: id, Revision: "", File: ""}},
+		{id + "@" + rev, gisty.GistRef{Host: "", Owner: "", ID: id, Revision: rev, File: ""}},
+		{"https://gist.github.com/" + id, gisty.GistRef{Host: "github.com", Owner: "", ID: id, Revision: "", File: ""}},
+		{
+			"https://gist.github.com/octocat/" + id + "#file-hello-md",
+			gisty.GistRef{Host: "github.com", Owner: "octocat", ID: id, Revision: "", File: ""},
+		},
+		{
+			"gist.github.com/octocat/" + id + "/" + rev,
+			gisty.GistRef{Host: "github.com", Owner: "octocat", ID: id, Revision: rev, File: ""},
+		},
+		{
+			"https://gist.github.com/octocat/" + id + "/revisions",
+			gisty.GistRef{Host: "github.com", Owner: "octocat", ID: id, Revision: "", File: ""},
+		},
+		{
+			"https://gist.githubusercontent.com/octocat/" + id + "/raw/" + rev + "/hello%20world.md",
+			gisty.GistRef{Host: "github.com", Owner: "octocat", ID: id, Revision: rev, File: "hello world.md"},
+		},
+		{
+			"https://gist.githubusercontent.com/octocat/" + id + "/raw/hello.md",
+			gisty.GistRef{Host: "github.com", Owner: "octocat", ID: id, Revision: "", File: "hello.md"},
+		},
+		{"https://gist.github.com/" + id + ".git", gisty.GistRef{Host: "github.com", Owner: "", ID: id, Revision: "", File: ""}},
+		{"git@gist.github.com:" + id + ".git", gisty.GistRef{Host: "github.com", Owner: "", ID: id, Revision: "", File: ""}},
+		{
+			"https://github.example.com/gist/octocat/" + id + "@" + rev,
+			gisty.GistRef{Host: "github.example.com", Owner: "octocat", ID: id, Revision: rev, File: ""},
+		},
+		{
+			"https://github.example.com/gist/" + id + ".git",
+			gisty.GistRef{Host: "github.example.com", Owner: "", ID: id, Revision: "", File: ""},
+		},
+		{
+			"https://gist.github.example.com/octocat/" + id + "/raw/" + rev + "/main.go",
+			gisty.GistRef{Host: "github.example.com", Owner: "octocat", ID: id, Revision: rev, File: "main.go"},
+		},
+	} {
+		got, err := gisty.ParseGistRef(test.input)
+
+		require.NoError(t, err, test.input)
+		require.Equal(t, test.want, got, test.input)
+	}
+}
+
+func TestParseGistRef_invalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{
+		"",
+		" ",
+		"abc def",
+		"abc@xyz",
+		"abc@",
+		"https://gist.github.com/",
+		"https://github.com/octocat/hello",
+		"https://gist.github.com/octo_cat/abc",
+		"https://gist.github.com/octocat/abc/raw/a/b/c",
+		"https://gist.github.com/octocat/abc/forks",
+		"https://gist.github.com/octocat/abc@1234/raw/5678/main.go",
+		"https://gist.github.com/octocat/abc\n",
+		"git@gist.github.com:",
+	} {
+		_, err := gisty.ParseGistRef(input)
+
+		require.ErrorIs(t, err, gisty.ErrInvalidGistID, "input: %q", input)
+	}
+}
@@ -31,10 +31,12 @@ package gisty
 
 import (
 	"bytes"
+	"context"
 
 	buildinfo "github.com/KEINOS/go-gisty/gisty/buildinfos"
 	"github.com/KEINOS/go-gisty/gisty/internal/gistid"
 	"github.com/KEINOS/go-gisty/gisty/internal/httpclient"
+	"github.com/KEINOS/go-gisty/gisty/store"
 	"github.com/cli/cli/v2/pkg/cmd/api"
 	"github.com/cli/cli/v2/pkg/cmd/gist/clone"
 	"github.com/cli/cli/v2/pkg/cmd/gist/create"
@@ -58,6 +60,23 @@ type Gisty struct {
 	// Factory holds the I/O streams, http client, and other common
 	// dependencies to request GitHub API.
 	Factory *cmdutil.Factory
+	// GHRunner executes the gh command for the commands that are not replaced
+	// by AltFunctions. If nil, DefaultGHRunner is used.
+	GHRunner GHRunner
+	// GitRunner executes the git command for the operations on the gist
+	// repositories. If nil, DefaultGitRunner is used.
+	GitRunner GitRunner
+	// Policies check and rewrite the arguments of Create and Update, and of the
+	// other methods publishing gists, before they are executed. See Policy.
+	Policies []Policy
+	// SecretScanner scans the files before they are published by Create, Push,
+	// Sync, Watch, Restore, Migrate and SetVisibility. If nil, the files are not
+	// scanned.
+	SecretScanner *SecretScanner
+	// Store is the local mirror of the gists filled by Mirror. If set, Read
+	// and Comments fall back to it when GitHub cannot be reached. See
+	// ReadFromCache.
+	Store *store.Store
 	// Stdin is the standard input stream which each command reads from.
 	Stdin *bytes.Buffer
 	// Stdout is the standard output stream which each command writes to.
@@ -68,8 +87,26 @@ type Gisty struct {
 	BuildDate string
 	// BuildVersion is the version of the binary.
 	BuildVersion string
+	// Host is the GitHub host to request, such as "ghe.example.com" for GitHub
+	// Enterprise Server. If empty, the default host of gh is used. Which is
+	// GH_HOST environment variable or "github.com".
+	Host string
 	// MaxComment is the max number of comments in a gist to be fetched.
 	MaxComment int
+	// MaxStatsPerQuery is the max number of gists fetched in a single GraphQL
+	// query by Stats.
+	MaxStatsPerQuery int
+	// ReadFromCache makes Read and Comments return the gist and its comments
+	// from Store without requesting GitHub. It is ignored if Store is nil.
+	ReadFromCache bool
+
+	// token is the token set by SetToken. If empty, the token of the host in
+	// the environment or the gh config is used.
+	token string
+	// ctx is the context of the gh command executions. If nil,
+	// context.Background is used. It is set on the copies made for the batch
+	// operations.
+	ctx context.Context //nolint:containedctx // internal use only, for the batch operations
 }
 
 // AltFunc is a set of alternative functions to be used in the commands.
@@ -84,6 +121,7 @@ type AltFunc struct {
 	List      func(*list.ListOptions) error
 	Read      func(*view.ViewOptions) error
 	Stargazer func(*api.ApiOptions) error
+	Stats     func(*api.ApiOptions) error
 	Update    func(*sync.SyncOptions) error
 }
 
@@ -104,29 +142,55 @@ func NewGisty() *Gisty {
 	cmdFactory := new(cmdutil.Factory)
 
 	cmdFactory.AppVersion = buildVersion
-	cmdFactory.InvokingAgent = "go-gisty"
+	cmdFactory.InvokingAgent = buildinfo.UserAgent()
 	cmdFactory.IOStreams = ios
-	cmdFactory.HttpClient = httpclient.New(buildVersion, "go-gisty")
+	cmdFactory.HttpClient = httpclient.New()
 
 	gst := new(Gisty)
 
 	gst.AltFunctions = *altFn
 	gst.Factory = cmdFactory
+	gst.GHRunner = nil
+	gst.GitRunner = nil
+	gst.Policies = nil
+	gst.SecretScanner = nil
+	gst.Store = nil
 	gst.Stdin = stdin
 	gst.Stdout = stdout
 	gst.Stderr = stderr
 	gst.BuildDate = buildDate
 	gst.BuildVersion = buildVersion
+	gst.Host = ""
 	gst.MaxComment = MaxCommentDefault
+	gst.MaxStatsPerQuery = MaxStatsPerQueryDefault
+	gst.ReadFromCache = false
+	gst.token = ""
+	gst.ctx = nil
 
 	return gst
 }
 
+// ----------------------------------------------------------------------------
+//  Methods
+// ----------------------------------------------------------------------------
+
+// SetToken makes g authenticate with the given token instead of the token of
+// the host in the environment or the gh config. E.g. to use another account on
+// the same host.
+//
+// It replaces the HTTP client of g.Factory and sets the token to the gh
+// commands.
+func (g *Gisty) SetToken(token string) {
+	g.token = token
+	g.Factory.HttpClient = httpclient.NewWithToken(token)
+}
+
 // ----------------------------------------------------------------------------
 //  Functions
 // ----------------------------------------------------------------------------
 
-// SanitizeGistID removes non-alphanumeric characters from gistID.
+// SanitizeGistID removes non-alphanumeric characters from gistID. To validate
+// a gist ID or URL instead, use ParseGistRef.
 func SanitizeGistID(gistID string) string {
 	return gistid.Sanitize(gistID)
 }
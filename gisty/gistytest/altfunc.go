@@ -0,0 +1,306 @@
+package gistytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/cli/cli/v2/pkg/cmd/api"
+	"github.com/cli/cli/v2/pkg/cmd/gist/create"
+	"github.com/cli/cli/v2/pkg/cmd/gist/delete"
+	"github.com/cli/cli/v2/pkg/cmd/gist/list"
+	"github.com/itchyny/gojq"
+	"github.com/pkg/errors"
+)
+
+// AltFunc returns the alternative functions of Gisty that request the server
+// instead of executing the gh command. The output written to the I/O streams
+// mimics the output of the gh command.
+//
+// Read is not included since the default Read function already requests the
+// API using the HTTP client of the factory. Clone and Update are not included
+// since they require a git repository.
+func (s *Server) AltFunc() gisty.AltFunc {
+	return gisty.AltFunc{
+		Clone:     nil,
+		Comments:  s.runAPI,
+		Create:    s.runCreate,
+		Delete:    s.runDelete,
+		List:      s.runList,
+		Read:      nil,
+		Stargazer: s.runAPI,
+		Stats:     s.runAPI,
+		Update:    nil,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  gist create
+// ----------------------------------------------------------------------------
+
+func (s *Server) runCreate(opts *create.CreateOptions) error {
+	files := map[string]map[string]string{}
+
+	for _, name := range opts.Filenames {
+		var (
+			content []byte
+			err     error
+		)
+
+		if name == "-" {
+			name = "gistfile0.txt"
+			if opts.FilenameOverride != "" {
+				name = opts.FilenameOverride
+			}
+
+			content, err = io.ReadAll(opts.IO.In)
+		} else {
+			content, err = os.ReadFile(name)
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "failed to read file")
+		}
+
+		files[filepath.Base(name)] = map[string]string{"content": string(content)}
+	}
+
+	var created restGist
+
+	err := s.doJSON(http.MethodPost, "/gists", map[string]any{
+		"description": opts.Description,
+		"public":      opts.Public,
+		"files":       files,
+	}, &created)
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintln(opts.IO.Out, created.HTMLURL)
+
+	return errors.Wrap(err, "failed to write output")
+}
+
+// ----------------------------------------------------------------------------
+//  gist delete
+// ----------------------------------------------------------------------------
+
+func (s *Server) runDelete(opts *delete.DeleteOptions) error {
+	gistID := opts.Selector
+	if strings.Contains(gistID, "/") {
+		gistID = gistID[strings.LastIndex(gistID, "/")+1:]
+	}
+
+	return s.doJSON(http.MethodDelete, "/gists/"+gistID, nil, nil)
+}
+
+// ----------------------------------------------------------------------------
+//  gist list
+// ----------------------------------------------------------------------------
+
+func (s *Server) runList(opts *list.ListOptions) error {
+	const perPageMax = 100
+
+	var listed []restGist
+
+	for page := 1; ; page++ {
+		var chunk []restGist
+
+		err := s.doJSON(http.MethodGet,
+			fmt.Sprintf("/gists?per_page=%d&page=%d", perPageMax, page), nil, &chunk)
+		if err != nil {
+			return err
+		}
+
+		listed = append(listed, chunk...)
+
+		if len(chunk) < perPageMax {
+			break
+		}
+	}
+
+	count := 0
+
+	for _, gist := range listed {
+		if opts.Limit > 0 && count >= opts.Limit {
+			break
+		}
+
+		if (opts.Visibility == "public" && !gist.Public) || (opts.Visibility == "secret" && gist.Public) {
+			continue
+		}
+
+		if opts.Filter != nil && !opts.Filter.MatchString(gist.Description) {
+			continue
+		}
+
+		visibility := "secret"
+		if gist.Public {
+			visibility = "public"
+		}
+
+		unit := "files"
+		if len(gist.Files) == 1 {
+			unit = "file"
+		}
+
+		_, err := fmt.Fprintf(opts.IO.Out, "%s\t%s\t%d %s\t%s\t%s\n",
+			gist.ID, gist.Description, len(gist.Files), unit, visibility,
+			gist.UpdatedAt.UTC().Format("2006-01-02T15:04:05Z"))
+		if err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+
+		count++
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  api
+// ----------------------------------------------------------------------------
+
+// runAPI requests the GraphQL API and writes the response as `gh api` does,
+// applying the jq filter or the Go template if given.
+func (s *Server) runAPI(opts *api.ApiOptions) error {
+	payload := map[string]any{}
+	variables := map[string]any{}
+
+	for _, field := range append(append([]string{}, opts.RawFields...), opts.MagicFields...) {
+		key, value, _ := strings.Cut(field, "=")
+		if key == "query" {
+			payload[key] = value
+		} else {
+			variables[key] = value
+		}
+	}
+
+	if len(variables) > 0 {
+		payload["variables"] = variables
+	}
+
+	var response any
+
+	err := s.doJSON(http.MethodPost, "/"+strings.TrimPrefix(opts.RequestPath, "/"), payload, &response)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opts.FilterOutput != "":
+		return writeJQ(opts.IO.Out, opts.FilterOutput, response)
+	case opts.Template != "":
+		return writeTemplate(opts.IO.Out, opts.Template, response)
+	default:
+		return errors.Wrap(json.NewEncoder(opts.IO.Out).Encode(response), "failed to write output")
+	}
+}
+
+func writeJQ(out io.Writer, filter string, response any) error {
+	query, err := gojq.Parse(filter)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse jq filter")
+	}
+
+	iter := query.Run(response)
+
+	for {
+		value, ok := iter.Next()
+		if !ok {
+			return nil
+		}
+
+		if err, isErr := value.(error); isErr {
+			return errors.Wrap(err, "failed to apply jq filter")
+		}
+
+		if text, isText := value.(string); isText {
+			_, err = fmt.Fprintln(out, text)
+		} else {
+			err = json.NewEncoder(out).Encode(value)
+		}
+
+		if err != nil {
+			return errors.Wrap(err, "failed to write output")
+		}
+	}
+}
+
+func writeTemplate(out io.Writer, text string, response any) error {
+	tpl, err := template.New("").Parse(text)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse template")
+	}
+
+	// Re-decode to keep the numbers as they are. E.g. "42" instead of "4.2e+01".
+	raw, err := json.Marshal(response)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode response")
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+
+	var data any
+
+	err = decoder.Decode(&data)
+	if err != nil {
+		return errors.Wrap(err, "failed to decode response")
+	}
+
+	return errors.Wrap(tpl.Execute(out, data), "failed to execute template")
+}
+
+// ----------------------------------------------------------------------------
+//  HTTP helper
+// ----------------------------------------------------------------------------
+
+// doJSON sends the request with the JSON encoded payload and decodes the JSON
+// response into result if it is not nil.
+func (s *Server) doJSON(method, path string, payload, result any) error {
+	var body io.Reader
+
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return errors.Wrap(err, "failed to encode request")
+		}
+
+		body = bytes.NewReader(raw)
+	}
+
+	req, err := http.NewRequest(method, s.URL+path, body) //nolint:noctx // test helper
+	if err != nil {
+		return errors.Wrap(err, "failed to create request")
+	}
+
+	resp, err := s.server.Client().Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		return errors.Errorf("HTTP %d: %s (%s)", resp.StatusCode, apiErr.Message, req.URL)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return errors.Wrap(json.NewDecoder(resp.Body).Decode(result), "failed to decode response")
+}
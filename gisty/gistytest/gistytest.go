@@ -0,0 +1,395 @@
+/*
+Package gistytest provides an in-memory fake of the GitHub Gist API for testing
+code that uses the gisty package.
+
+The fake server implements the subset of the gist REST API (v3) and GraphQL
+API (v4) endpoints that Gisty uses. Seed it with fixtures, point a Gisty at it
+and assert the requests it received afterwards:
+
+	srv := gistytest.NewServer()
+	defer srv.Close()
+
+	gistID := srv.AddGist(gistytest.Gist{
+		Description: "my gist",
+		Files:       map[string]string{"hello.md": "# Hello"},
+	})
+
+	obj := srv.NewGisty()
+
+	gist, err := obj.Read(gistID)
+
+	srv.AssertRequested(t, http.MethodGet, "/gists/"+gistID)
+
+Commands that require a local git repository, such as Clone and Update, are not
+faked and still run the gh command.
+
+To test against the real API offline, use Recorder. It records the HTTP traffic
+and the gh command outputs into a golden file and replays them afterwards.
+*/
+package gistytest
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Gist
+// ----------------------------------------------------------------------------
+
+// Gist is a gist fixture held by the fake server.
+type Gist struct {
+	// UpdatedAt is the last update time. If zero, the time of seeding is used.
+	UpdatedAt time.Time
+	// Files maps the file names to their contents.
+	Files map[string]string
+	// ID is the gist ID. If empty, a new ID is assigned on seeding.
+	ID string
+	// Description is the description of the gist.
+	Description string
+	// Owner is the login name of the owner. If empty, Server.Login is used.
+	Owner string
+	// Comments are the comments of the gist.
+	Comments []gisty.Comment
+	// Stars is the number of stargazers.
+	Stars int
+	// Forks is the number of forks.
+	Forks int
+	// Public is true if the gist is public.
+	Public bool
+}
+
+// Revision returns the version of the gist, as in the history of the REST API.
+// It is derived from the description and the files, so it changes whenever they
+// change.
+func (g Gist) Revision() string {
+	names := make([]string, 0, len(g.Files))
+	for name := range g.Files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	hash := sha1.New() //nolint:gosec // not for security, same as git object IDs
+
+	fmt.Fprintf(hash, "%q\n", g.Description)
+
+	for _, name := range names {
+		fmt.Fprintf(hash, "%q %q\n", name, g.Files[name])
+	}
+
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// clone returns a deep copy of the gist.
+func (g Gist) clone() Gist {
+	files := make(map[string]string, len(g.Files))
+	for name, content := range g.Files {
+		files[name] = content
+	}
+
+	g.Files = files
+	g.Comments = slices.Clone(g.Comments)
+
+	return g
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Request
+// ----------------------------------------------------------------------------
+
+// Request is a request received by the fake server.
+type Request struct {
+	Header http.Header
+	Query  map[string][]string
+	Method string
+	// Path is the request path without the GitHub Enterprise API prefix.
+	Path string
+	Body []byte
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Server
+// ----------------------------------------------------------------------------
+
+// LoginDefault is the default login name of the authenticated viewer.
+const LoginDefault = "octocat"
+
+// Server is a fake GitHub Gist API server.
+type Server struct {
+	// URL is the base URL of the server. E.g. "http://127.0.0.1:1234".
+	URL string
+	// Login is the login name of the authenticated viewer.
+	Login string
+
+	server   *httptest.Server
+	gists    map[string]*Gist
+	requests []Request
+	failures []failure
+	lastID   int
+	mutex    sync.Mutex
+}
+
+// NewServer starts and returns a new fake server. The caller should call Close
+// when finished, to shut it down.
+func NewServer() *Server {
+	srv := &Server{
+		URL:      "",
+		Login:    LoginDefault,
+		server:   nil,
+		gists:    map[string]*Gist{},
+		requests: nil,
+		failures: nil,
+		lastID:   0,
+		mutex:    sync.Mutex{},
+	}
+
+	srv.server = httptest.NewServer(http.HandlerFunc(srv.serveHTTP))
+	srv.URL = srv.server.URL
+
+	return srv
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// ----------------------------------------------------------------------------
+//  Fixtures
+// ----------------------------------------------------------------------------
+
+// AddGist seeds the server with the given gist and returns its ID.
+func (s *Server) AddGist(gist Gist) string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.addGist(gist)
+}
+
+func (s *Server) addGist(gist Gist) string {
+	gist = gist.clone()
+
+	if gist.ID == "" {
+		s.lastID++
+		gist.ID = fmt.Sprintf("%032x", s.lastID)
+	}
+
+	if gist.Owner == "" {
+		gist.Owner = s.Login
+	}
+
+	if gist.UpdatedAt.IsZero() {
+		gist.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	}
+
+	s.gists[gist.ID] = &gist
+
+	return gist.ID
+}
+
+// Gist returns a copy of the gist with the given ID held by the server.
+func (s *Server) Gist(gistID string) (Gist, bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gist, ok := s.gists[gistID]
+	if !ok {
+		return Gist{}, false
+	}
+
+	return gist.clone(), true
+}
+
+// Gists returns copies of all the gists held by the server, most recently
+// updated first.
+func (s *Server) Gists() []Gist {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.sortedGists()
+}
+
+func (s *Server) sortedGists() []Gist {
+	result := make([]Gist, 0, len(s.gists))
+	for _, gist := range s.gists {
+		result = append(result, gist.clone())
+	}
+
+	slices.SortFunc(result, func(a, b Gist) int {
+		if cmp := b.UpdatedAt.Compare(a.UpdatedAt); cmp != 0 {
+			return cmp
+		}
+
+		return compareStrings(a.ID, b.ID)
+	})
+
+	return result
+}
+
+func compareStrings(a, b string) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Failure injection
+// ----------------------------------------------------------------------------
+
+type failure struct {
+	Header http.Header
+	Method string
+	Path   string
+	Status int
+}
+
+// FailNext makes the next request with the given method and path fail with the
+// status code and the response headers. Such as a rate limit error. Calling it
+// multiple times queues the failures.
+func (s *Server) FailNext(method, path string, status int, header http.Header) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.failures = append(s.failures, failure{Header: header, Method: method, Path: path, Status: status})
+}
+
+// popFailure removes and returns the first queued failure that matches the
+// request. The caller must hold the lock.
+func (s *Server) popFailure(method, path string) (failure, bool) {
+	for index, fail := range s.failures {
+		if fail.Method == method && fail.Path == path {
+			s.failures = slices.Delete(s.failures, index, index+1)
+
+			return fail, true
+		}
+	}
+
+	return failure{}, false
+}
+
+// ----------------------------------------------------------------------------
+//  Request assertions
+// ----------------------------------------------------------------------------
+
+// Requests returns the requests received by the server in order.
+func (s *Server) Requests() []Request {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return slices.Clone(s.requests)
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.requests = nil
+}
+
+// Requested returns the number of received requests that match the given
+// method and path.
+func (s *Server) Requested(method, path string) int {
+	count := 0
+
+	for _, req := range s.Requests() {
+		if req.Method == method && req.Path == path {
+			count++
+		}
+	}
+
+	return count
+}
+
+// AssertRequested marks the test as failed if the server did not receive any
+// request with the given method and path.
+func (s *Server) AssertRequested(tb testing.TB, method, path string) bool {
+	tb.Helper()
+
+	if s.Requested(method, path) == 0 {
+		tb.Errorf("gistytest: expected a request to %s %s, got none. requests: %v",
+			method, path, s.requestLines())
+
+		return false
+	}
+
+	return true
+}
+
+// AssertNotRequested marks the test as failed if the server received any
+// request with the given method and path.
+func (s *Server) AssertNotRequested(tb testing.TB, method, path string) bool {
+	tb.Helper()
+
+	if count := s.Requested(method, path); count != 0 {
+		tb.Errorf("gistytest: expected no request to %s %s, got %d", method, path, count)
+
+		return false
+	}
+
+	return true
+}
+
+func (s *Server) requestLines() []string {
+	requests := s.Requests()
+	lines := make([]string, 0, len(requests))
+
+	for _, req := range requests {
+		lines = append(lines, req.Method+" "+req.Path)
+	}
+
+	return lines
+}
+
+// ----------------------------------------------------------------------------
+//  Gisty integration
+// ----------------------------------------------------------------------------
+
+// Client returns an HTTP client that sends every request, regardless of the
+// GitHub host, to the server.
+func (s *Server) Client() *http.Client {
+	return &http.Client{
+		Transport:     newRewriteTransport(s.URL, s.server.Client().Transport),
+		CheckRedirect: nil,
+		Jar:           nil,
+		Timeout:       0,
+	}
+}
+
+// HTTPClient is the function form of Client to be used as a GitHub CLI
+// factory's HttpClient.
+func (s *Server) HTTPClient() (*http.Client, error) {
+	return s.Client(), nil
+}
+
+// Apply points the given Gisty at the server. It replaces the HTTP client of
+// the factory and sets the alternative functions of the commands that are
+// implemented by the server.
+func (s *Server) Apply(obj *gisty.Gisty) {
+	obj.Factory.HttpClient = s.HTTPClient
+	obj.AltFunctions = s.AltFunc()
+}
+
+// NewGisty returns a new Gisty instance pointed at the server.
+func (s *Server) NewGisty() *gisty.Gisty {
+	obj := gisty.NewGisty()
+
+	s.Apply(obj)
+
+	return obj
+}
@@ -0,0 +1,244 @@
+package gistytest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fixtureGistID = "5b10b34f87955dfc86d310cd623a61d1"
+	fixtureDesc   = "fixture gist"
+)
+
+func newFixtureServer(t *testing.T) *gistytest.Server {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddGist(gistytest.Gist{
+		UpdatedAt:   time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+		Files:       map[string]string{"hello.md": "# Hello", "main.go": "package main"},
+		ID:          fixtureGistID,
+		Description: fixtureDesc,
+		Owner:       "",
+		Comments: []gisty.Comment{
+			{Author: gisty.Author{AvatarURL: "", Login: "alice"}, ID: "c1", BodyRaw: "first"},
+			{Author: gisty.Author{AvatarURL: "", Login: "bob"}, ID: "c2", BodyRaw: "second"},
+		},
+		Stars:  7,
+		Forks:  2,
+		Public: true,
+	})
+
+	return srv
+}
+
+func TestServer_Read(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	gist, err := srv.NewGisty().Read("https://gist.github.com/octocat/" + fixtureGistID)
+
+	require.NoError(t, err)
+	require.Equal(t, fixtureGistID, gist.ID)
+	require.Equal(t, fixtureDesc, gist.Description)
+	require.Equal(t, "# Hello", gist.Files["hello.md"].Content)
+	require.Equal(t, "Markdown", gist.Files["hello.md"].Language)
+	require.Equal(t, gistytest.LoginDefault, gist.Owner.Login)
+	require.True(t, gist.Public)
+
+	srv.AssertRequested(t, http.MethodGet, "/gists/"+fixtureGistID)
+}
+
+func TestServer_Read_not_found(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	_, err := srv.NewGisty().Read("0123456789abcdef")
+
+	require.ErrorContains(t, err, "not found")
+}
+
+func TestServer_List(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+	srv.AddGist(gistytest.Gist{
+		UpdatedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+		Files:     map[string]string{"secret.txt": "secret"},
+	})
+
+	gists, err := srv.NewGisty().List(gisty.ListArgs{Limit: 10, OnlyPublic: false, OnlySecret: false})
+	require.NoError(t, err)
+	require.Len(t, gists, 2)
+	require.Equal(t, fixtureGistID, gists[0].GistID)
+	require.Equal(t, 2, gists[0].Files)
+	require.True(t, gists[0].IsPublic)
+	require.False(t, gists[1].IsPublic)
+
+	gists, err = srv.NewGisty().List(gisty.ListArgs{Limit: 10, OnlyPublic: false, OnlySecret: true})
+	require.NoError(t, err)
+	require.Len(t, gists, 1)
+	require.False(t, gists[0].IsPublic)
+	require.Equal(t, 1, gists[0].Files)
+}
+
+func TestServer_Create_and_Delete(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	defer srv.Close()
+
+	pathFile := filepath.Join(t.TempDir(), "foo.md")
+	require.NoError(t, os.WriteFile(pathFile, []byte("# Foo"), 0o600))
+
+	obj := srv.NewGisty()
+
+	gistURL, err := obj.Create(gisty.CreateArgs{
+		Description:  "created",
+		FilePaths:    []string{pathFile},
+		AsPublic:     true,
+		AllowSecrets: false,
+	})
+	require.NoError(t, err)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 1)
+	require.Equal(t, "https://gist.github.com/"+gists[0].ID, gistURL.String())
+	require.Equal(t, "created", gists[0].Description)
+	require.Equal(t, map[string]string{"foo.md": "# Foo"}, gists[0].Files)
+	require.True(t, gists[0].Public)
+
+	require.NoError(t, srv.NewGisty().Delete(gistURL.String()))
+	require.Empty(t, srv.Gists())
+
+	srv.AssertRequested(t, http.MethodPost, "/gists")
+	srv.AssertRequested(t, http.MethodDelete, "/gists/"+gists[0].ID)
+
+	require.Error(t, srv.NewGisty().Delete(gists[0].ID), "deleting twice should fail")
+}
+
+func TestServer_Stargazer(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	count, err := srv.NewGisty().Stargazer(fixtureGistID)
+
+	require.NoError(t, err)
+	require.Equal(t, 7, count)
+	srv.AssertRequested(t, http.MethodPost, "/graphql")
+	srv.AssertNotRequested(t, http.MethodGet, "/gists")
+}
+
+func TestServer_Comments(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	obj := srv.NewGisty()
+	obj.MaxComment = 1
+
+	comments, err := obj.Comments(fixtureGistID)
+
+	require.NoError(t, err)
+	require.Len(t, comments, 1, "only the last comment should be fetched")
+	require.Equal(t, "bob", comments[0].Author.Login)
+	require.Equal(t, "second", comments[0].BodyRaw)
+}
+
+func TestServer_edit_via_enterprise_path(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	body, err := json.Marshal(map[string]any{
+		"description": "edited",
+		"files": map[string]any{
+			"hello.md": map[string]string{"filename": "renamed.md"},
+			"main.go":  nil,
+			"new.txt":  map[string]string{"content": "new"},
+		},
+	})
+	require.NoError(t, err)
+
+	req, err := http.NewRequestWithContext(t.Context(), http.MethodPatch,
+		"https://ghe.example.com/api/v3/gists/"+fixtureGistID, bytes.NewReader(body))
+	require.NoError(t, err)
+
+	resp, err := srv.Client().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	gist, ok := srv.Gist(fixtureGistID)
+	require.True(t, ok)
+	require.Equal(t, "edited", gist.Description)
+	require.Equal(t, map[string]string{"renamed.md": "# Hello", "new.txt": "new"}, gist.Files)
+	require.Equal(t, 1, srv.Requested(http.MethodPatch, "/gists/"+fixtureGistID))
+
+	srv.ResetRequests()
+	require.Empty(t, srv.Requests())
+}
+
+func TestServer_FailNext(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+	obj := srv.NewGisty()
+
+	srv.FailNext(http.MethodGet, "/gists/"+fixtureGistID, http.StatusTooManyRequests,
+		http.Header{"Retry-After": {"1"}})
+
+	_, err := obj.Read(fixtureGistID)
+	require.ErrorContains(t, err, "429")
+
+	gist, err := obj.Read(fixtureGistID)
+	require.NoError(t, err, "only the next request should fail")
+	require.Equal(t, fixtureDesc, gist.Description)
+
+	fixture, _ := srv.Gist(fixtureGistID)
+	require.Len(t, fixture.Revision(), 40)
+}
+
+func TestServer_AssertRequested_fails(t *testing.T) {
+	t.Parallel()
+
+	srv := newFixtureServer(t)
+
+	mockT := &recorderTB{TB: t, failed: false}
+
+	require.False(t, srv.AssertRequested(mockT, http.MethodGet, "/gists"))
+	require.True(t, mockT.failed)
+
+	_, err := srv.NewGisty().List(gisty.ListArgs{Limit: 1, OnlyPublic: false, OnlySecret: false})
+	require.NoError(t, err)
+
+	mockT.failed = false
+
+	require.False(t, srv.AssertNotRequested(mockT, http.MethodGet, "/gists"))
+	require.True(t, mockT.failed)
+}
+
+// recorderTB records the failure instead of failing the test.
+type recorderTB struct {
+	testing.TB
+
+	failed bool
+}
+
+func (r *recorderTB) Errorf(string, ...any) {
+	r.failed = true
+}
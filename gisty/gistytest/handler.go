@@ -0,0 +1,651 @@
+package gistytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+)
+
+// serveHTTP records the request and dispatches it to the REST or GraphQL
+// handlers.
+func (s *Server) serveHTTP(resp http.ResponseWriter, req *http.Request) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(resp, http.StatusBadRequest, "failed to read request body")
+
+		return
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	s.mutex.Lock()
+	s.requests = append(s.requests, Request{
+		Header: req.Header.Clone(),
+		Query:  req.URL.Query(),
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Body:   body,
+	})
+	failure, failed := s.popFailure(req.Method, req.URL.Path)
+	s.mutex.Unlock()
+
+	if failed {
+		for key, values := range failure.Header {
+			resp.Header()[key] = values
+		}
+
+		writeError(resp, failure.Status, http.StatusText(failure.Status))
+
+		return
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /gists", s.handleListGists)
+	mux.HandleFunc("POST /gists", s.handleCreateGist)
+	mux.HandleFunc("GET /gists/{id}", s.handleGetGist)
+	mux.HandleFunc("PATCH /gists/{id}", s.handleEditGist)
+	mux.HandleFunc("DELETE /gists/{id}", s.handleDeleteGist)
+	mux.HandleFunc("GET /gists/{id}/star", s.handleStar)
+	mux.HandleFunc("PUT /gists/{id}/star", s.handleStar)
+	mux.HandleFunc("DELETE /gists/{id}/star", s.handleStar)
+	mux.HandleFunc("GET /gists/{id}/comments", s.handleListComments)
+	mux.HandleFunc("GET /gists/{id}/{sha}", s.handleGetRevision)
+	mux.HandleFunc("POST /gists/{id}/comments", s.handleCreateComment)
+	mux.HandleFunc("POST /graphql", s.handleGraphQL)
+	mux.HandleFunc("GET /raw/{id}/{name}", s.handleRaw)
+
+	mux.ServeHTTP(resp, req)
+}
+
+// ----------------------------------------------------------------------------
+//  REST API (v3)
+// ----------------------------------------------------------------------------
+
+type restOwner struct {
+	Login string `json:"login"`
+}
+
+type restFile struct {
+	Filename  string `json:"filename"`
+	Type      string `json:"type"`
+	Language  string `json:"language"`
+	RawURL    string `json:"raw_url"`
+	Content   string `json:"content,omitempty"`
+	Size      int    `json:"size"`
+	Truncated bool   `json:"truncated"`
+}
+
+//nolint:tagliatelle // snake case is required by GitHub API
+type restGist struct {
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+	Files       map[string]restFile `json:"files"`
+	Owner       restOwner           `json:"owner"`
+	ID          string              `json:"id"`
+	Description string              `json:"description"`
+	HTMLURL     string              `json:"html_url"`
+	GitPullURL  string              `json:"git_pull_url"`
+	GitPushURL  string              `json:"git_push_url"`
+	History     []restHistory       `json:"history,omitempty"`
+	Comments    int                 `json:"comments"`
+	Public      bool                `json:"public"`
+}
+
+type restHistory struct {
+	CommittedAt time.Time `json:"committed_at"`
+	Version     string    `json:"version"`
+}
+
+func (s *Server) toREST(gist Gist, withContent bool) restGist {
+	files := make(map[string]restFile, len(gist.Files))
+
+	var history []restHistory
+
+	if withContent {
+		history = []restHistory{{CommittedAt: gist.UpdatedAt, Version: gist.Revision()}}
+	}
+
+	for name, content := range gist.Files {
+		file := restFile{
+			Filename:  name,
+			Type:      fileType(name),
+			Language:  fileLanguage(name),
+			RawURL:    s.URL + "/raw/" + gist.ID + "/" + name,
+			Content:   "",
+			Size:      len(content),
+			Truncated: false,
+		}
+
+		if withContent {
+			file.Content = content
+		}
+
+		files[name] = file
+	}
+
+	return restGist{
+		CreatedAt:   gist.UpdatedAt,
+		UpdatedAt:   gist.UpdatedAt,
+		Files:       files,
+		Owner:       restOwner{Login: gist.Owner},
+		ID:          gist.ID,
+		Description: gist.Description,
+		HTMLURL:     "https://gist.github.com/" + gist.ID,
+		GitPullURL:  "https://gist.github.com/" + gist.ID + ".git",
+		GitPushURL:  "https://gist.github.com/" + gist.ID + ".git",
+		History:     history,
+		Comments:    len(gist.Comments),
+		Public:      gist.Public,
+	}
+}
+
+func (s *Server) handleListGists(resp http.ResponseWriter, req *http.Request) {
+	const perPageDefault = 30
+
+	perPage := queryInt(req, "per_page", perPageDefault)
+	page := queryInt(req, "page", 1)
+
+	s.mutex.Lock()
+	gists := s.sortedGists()
+	s.mutex.Unlock()
+
+	result := []restGist{}
+
+	for index, gist := range gists {
+		if index >= (page-1)*perPage && index < page*perPage {
+			result = append(result, s.toREST(gist, false))
+		}
+	}
+
+	writeJSON(resp, http.StatusOK, result)
+}
+
+func (s *Server) handleRaw(resp http.ResponseWriter, req *http.Request) {
+	gist, ok := s.Gist(req.PathValue("id"))
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	content, ok := gist.Files[req.PathValue("name")]
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	resp.Header().Set("Content-Type", "text/plain; charset=utf-8")
+
+	_, _ = io.WriteString(resp, content)
+}
+
+type restEditFile struct {
+	Content  *string `json:"content"`
+	Filename *string `json:"filename"`
+}
+
+type restEditRequest struct {
+	Description *string                  `json:"description"`
+	Files       map[string]*restEditFile `json:"files"`
+	Public      bool                     `json:"public"`
+}
+
+func (s *Server) handleCreateGist(resp http.ResponseWriter, req *http.Request) {
+	var body restEditRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeError(resp, http.StatusBadRequest, "Problems parsing JSON")
+
+		return
+	}
+
+	files := map[string]string{}
+
+	for name, file := range body.Files {
+		if file == nil || file.Content == nil || *file.Content == "" {
+			writeError(resp, http.StatusUnprocessableEntity, "Validation Failed: contents can't be blank")
+
+			return
+		}
+
+		files[name] = *file.Content
+	}
+
+	if len(files) == 0 {
+		writeError(resp, http.StatusUnprocessableEntity, "Validation Failed: files can't be empty")
+
+		return
+	}
+
+	description := ""
+	if body.Description != nil {
+		description = *body.Description
+	}
+
+	s.mutex.Lock()
+	gistID := s.addGist(Gist{
+		UpdatedAt:   time.Time{},
+		Files:       files,
+		ID:          "",
+		Description: description,
+		Owner:       "",
+		Comments:    nil,
+		Stars:       0,
+		Forks:       0,
+		Public:      body.Public,
+	})
+	gist := s.gists[gistID].clone()
+	s.mutex.Unlock()
+
+	writeJSON(resp, http.StatusCreated, s.toREST(gist, true))
+}
+
+func (s *Server) handleGetGist(resp http.ResponseWriter, req *http.Request) {
+	gist, ok := s.Gist(req.PathValue("id"))
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	writeJSON(resp, http.StatusOK, s.toREST(gist, true))
+}
+
+// handleGetRevision returns the gist at a revision. Only the latest revision
+// is held, so the older ones are not found.
+func (s *Server) handleGetRevision(resp http.ResponseWriter, req *http.Request) {
+	gist, ok := s.Gist(req.PathValue("id"))
+	if !ok || gist.Revision() != req.PathValue("sha") {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	writeJSON(resp, http.StatusOK, s.toREST(gist, true))
+}
+
+func (s *Server) handleEditGist(resp http.ResponseWriter, req *http.Request) {
+	var body restEditRequest
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil {
+		writeError(resp, http.StatusBadRequest, "Problems parsing JSON")
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gist, ok := s.gists[req.PathValue("id")]
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	if body.Description != nil {
+		gist.Description = *body.Description
+	}
+
+	for name, file := range body.Files {
+		content, exists := gist.Files[name]
+
+		switch {
+		case file == nil:
+			delete(gist.Files, name)
+		case file.Filename != nil && *file.Filename != name:
+			if file.Content != nil {
+				content = *file.Content
+			}
+
+			delete(gist.Files, name)
+			gist.Files[*file.Filename] = content
+		case file.Content != nil:
+			gist.Files[name] = *file.Content
+		case !exists:
+			writeError(resp, http.StatusUnprocessableEntity, "Validation Failed: file not found: "+name)
+
+			return
+		}
+	}
+
+	gist.UpdatedAt = nextUpdate(gist.UpdatedAt)
+
+	writeJSON(resp, http.StatusOK, s.toREST(gist.clone(), true))
+}
+
+// nextUpdate returns the current time, but always later than the given time so
+// that the edits are ordered even within the same second.
+func nextUpdate(last time.Time) time.Time {
+	now := time.Now().UTC().Truncate(time.Second)
+	if !now.After(last) {
+		return last.Add(time.Second)
+	}
+
+	return now
+}
+
+func (s *Server) handleDeleteGist(resp http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gistID := req.PathValue("id")
+
+	if _, ok := s.gists[gistID]; !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	delete(s.gists, gistID)
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) handleStar(resp http.ResponseWriter, req *http.Request) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gist, ok := s.gists[req.PathValue("id")]
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPut:
+		gist.Stars++
+	case http.MethodDelete:
+		gist.Stars = max(0, gist.Stars-1)
+	case http.MethodGet:
+		if gist.Stars == 0 {
+			writeError(resp, http.StatusNotFound, "Not Found")
+
+			return
+		}
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+//nolint:tagliatelle // snake case is required by GitHub API
+type restComment struct {
+	User      restOwner `json:"user"`
+	ID        string    `json:"node_id"`
+	Body      string    `json:"body"`
+	CreatedAt string    `json:"created_at"`
+	UpdatedAt string    `json:"updated_at"`
+}
+
+func (s *Server) handleListComments(resp http.ResponseWriter, req *http.Request) {
+	gist, ok := s.Gist(req.PathValue("id"))
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	const perPageDefault = 30
+
+	perPage := queryInt(req, "per_page", perPageDefault)
+	page := queryInt(req, "page", 1)
+
+	result := []restComment{}
+
+	for index, comment := range gist.Comments {
+		if index < (page-1)*perPage || index >= page*perPage {
+			continue
+		}
+
+		createdAt := comment.CreatedAt
+		if createdAt == "" {
+			createdAt = gist.UpdatedAt.Format(time.RFC3339)
+		}
+
+		updatedAt := comment.LastEditedAt
+		if updatedAt == "" {
+			updatedAt = createdAt
+		}
+
+		result = append(result, restComment{
+			User:      restOwner{Login: comment.Author.Login},
+			ID:        comment.ID,
+			Body:      comment.BodyRaw,
+			CreatedAt: createdAt,
+			UpdatedAt: updatedAt,
+		})
+	}
+
+	writeJSON(resp, http.StatusOK, result)
+}
+
+func (s *Server) handleCreateComment(resp http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Body string `json:"body"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil || body.Body == "" {
+		writeError(resp, http.StatusUnprocessableEntity, "Validation Failed: body can't be blank")
+
+		return
+	}
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	gist, ok := s.gists[req.PathValue("id")]
+	if !ok {
+		writeError(resp, http.StatusNotFound, "Not Found")
+
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	comment := gisty.Comment{
+		Author:            gisty.Author{AvatarURL: "", Login: s.Login},
+		ID:                "GC_" + gist.ID + "_" + strconv.Itoa(len(gist.Comments)+1),
+		AuthorAssociation: "OWNER",
+		BodyRaw:           body.Body,
+		BodyHTML:          "<p>" + body.Body + "</p>",
+		BodyText:          body.Body,
+		CreatedAt:         now,
+		PublishedAt:       now,
+		LastEditedAt:      "",
+		MinimizedReason:   "",
+		IsMinimized:       false,
+	}
+
+	gist.Comments = append(gist.Comments, comment)
+
+	writeJSON(resp, http.StatusCreated, restComment{
+		User:      restOwner{Login: comment.Author.Login},
+		ID:        comment.ID,
+		Body:      comment.BodyRaw,
+		CreatedAt: comment.CreatedAt,
+		UpdatedAt: comment.CreatedAt,
+	})
+}
+
+// ----------------------------------------------------------------------------
+//  GraphQL API (v4)
+// ----------------------------------------------------------------------------
+
+var (
+	// reGistSelection matches the (optionally aliased) gist selections in the
+	// viewer object. E.g. `gist(name: "abc")` or `g0: gist(name: "abc")`.
+	reGistSelection = regexp.MustCompile(`(?:(\w+)\s*:\s*)?\bgist\s*\(\s*name\s*:\s*"([^"]*)"\s*\)`)
+	// reLastComments matches the number of comments to fetch.
+	reLastComments = regexp.MustCompile(`comments\s*\(\s*last\s*:\s*(\d+)\s*\)`)
+)
+
+func (s *Server) handleGraphQL(resp http.ResponseWriter, req *http.Request) {
+	var body struct {
+		Query string `json:"query"`
+	}
+
+	err := json.NewDecoder(req.Body).Decode(&body)
+	if err != nil || body.Query == "" {
+		writeError(resp, http.StatusBadRequest, "Problems parsing JSON")
+
+		return
+	}
+
+	selections := reGistSelection.FindAllStringSubmatch(body.Query, -1)
+	if len(selections) == 0 {
+		writeJSON(resp, http.StatusOK, map[string]any{
+			"errors": []map[string]string{{"message": "gistytest: unsupported query"}},
+		})
+
+		return
+	}
+
+	lastComments := -1
+	if match := reLastComments.FindStringSubmatch(body.Query); match != nil {
+		lastComments, _ = strconv.Atoi(match[1])
+	}
+
+	viewer := map[string]any{"login": s.Login}
+
+	for _, selection := range selections {
+		key := selection[1]
+		if key == "" {
+			key = "gist"
+		}
+
+		gist, ok := s.Gist(selection[2])
+		if !ok {
+			viewer[key] = nil
+
+			continue
+		}
+
+		viewer[key] = s.toGraphQL(gist, lastComments)
+	}
+
+	writeJSON(resp, http.StatusOK, map[string]any{
+		"data": map[string]any{"viewer": viewer},
+	})
+}
+
+// toGraphQL returns the gist node with every field that Gisty queries.
+func (s *Server) toGraphQL(gist Gist, lastComments int) map[string]any {
+	comments := gist.Comments
+	if lastComments >= 0 && len(comments) > lastComments {
+		comments = comments[len(comments)-lastComments:]
+	}
+
+	edges := make([]map[string]any, 0, len(comments))
+	for _, comment := range comments {
+		edges = append(edges, map[string]any{"node": comment})
+	}
+
+	names := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		names = append(names, name)
+	}
+
+	slices.Sort(names)
+
+	files := make([]map[string]any, 0, len(names))
+	for _, name := range names {
+		files = append(files, map[string]any{
+			"name":      name,
+			"extension": path.Ext(name),
+			"size":      len(gist.Files[name]),
+			"language":  map[string]string{"name": fileLanguage(name)},
+			"text":      gist.Files[name],
+		})
+	}
+
+	return map[string]any{
+		"name":           gist.ID,
+		"description":    gist.Description,
+		"isPublic":       gist.Public,
+		"url":            "https://gist.github.com/" + gist.ID,
+		"updatedAt":      gist.UpdatedAt.Format(time.RFC3339),
+		"stargazerCount": gist.Stars,
+		"owner":          map[string]string{"login": gist.Owner},
+		"forks":          map[string]int{"totalCount": gist.Forks},
+		"comments":       map[string]any{"totalCount": len(gist.Comments), "edges": edges},
+		"files":          files,
+	}
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+func writeJSON(resp http.ResponseWriter, status int, value any) {
+	resp.Header().Set("Content-Type", "application/json; charset=utf-8")
+	resp.WriteHeader(status)
+
+	_ = json.NewEncoder(resp).Encode(value)
+}
+
+func writeError(resp http.ResponseWriter, status int, message string) {
+	writeJSON(resp, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest/gists",
+	})
+}
+
+func queryInt(req *http.Request, key string, defaultValue int) int {
+	value, err := strconv.Atoi(req.URL.Query().Get(key))
+	if err != nil || value < 1 {
+		return defaultValue
+	}
+
+	return value
+}
+
+// languages maps the file extensions to the language names as GitHub detects.
+var languages = map[string]string{
+	".c":    "C",
+	".go":   "Go",
+	".js":   "JavaScript",
+	".json": "JSON",
+	".md":   "Markdown",
+	".py":   "Python",
+	".rb":   "Ruby",
+	".rs":   "Rust",
+	".sh":   "Shell",
+	".ts":   "TypeScript",
+	".txt":  "Text",
+	".yaml": "YAML",
+	".yml":  "YAML",
+}
+
+func fileLanguage(name string) string {
+	if lang, ok := languages[strings.ToLower(path.Ext(name))]; ok {
+		return lang
+	}
+
+	return "Text"
+}
+
+func fileType(name string) string {
+	mimeType := mime.TypeByExtension(path.Ext(name))
+	if mimeType == "" {
+		return "text/plain"
+	}
+
+	mimeType, _, _ = strings.Cut(mimeType, ";")
+
+	return mimeType
+}
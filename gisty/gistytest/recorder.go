@@ -0,0 +1,472 @@
+package gistytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/pkg/errors"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Mode
+// ----------------------------------------------------------------------------
+
+// Mode is the mode of the Recorder.
+type Mode int
+
+const (
+	// ModeReplay serves the interactions from the golden file. Requests or gh
+	// executions that were not recorded fail.
+	ModeReplay Mode = iota
+	// ModeRecord executes the real requests and gh commands and captures them
+	// to be saved in the golden file.
+	ModeRecord
+)
+
+// EnvRecord is the name of the environment variable that switches ModeFromEnv
+// to the record mode when set to "1" or "true".
+const EnvRecord = "GISTYTEST_RECORD"
+
+// ModeFromEnv returns ModeRecord if the EnvRecord environment variable is set.
+// Otherwise, ModeReplay is returned, so that the tests run offline by default.
+func ModeFromEnv() Mode {
+	if value, err := strconv.ParseBool(os.Getenv(EnvRecord)); err == nil && value {
+		return ModeRecord
+	}
+
+	return ModeReplay
+}
+
+// Redacted replaces the credentials in the recorded interactions.
+const Redacted = "REDACTED"
+
+// ----------------------------------------------------------------------------
+//  Type: Recorder
+// ----------------------------------------------------------------------------
+
+// Recorder records the HTTP traffic and the gh command executions of a Gisty
+// into a golden file and replays them deterministically.
+//
+//	rec, err := gistytest.NewRecorder("testdata/read.golden.json", gistytest.ModeFromEnv())
+//	...
+//	obj := gisty.NewGisty()
+//	rec.Apply(obj)
+//	... // use obj
+//	err = rec.Save() // writes the golden file in record mode only
+//
+// Authentication headers are never recorded and GitHub tokens in the bodies
+// and outputs are replaced with Redacted.
+type Recorder struct {
+	// Transport is the underlying transport used in the record mode when the
+	// HTTP client of the Gisty has no transport.
+	Transport http.RoundTripper
+
+	path     string
+	cassette cassette
+	used     []bool
+	mode     Mode
+	mutex    sync.Mutex
+}
+
+// cassette is the content of the golden file.
+type cassette struct {
+	Interactions []interaction `json:"interactions"`
+}
+
+// interaction is either an HTTP round trip or a gh command execution.
+type interaction struct {
+	HTTP *httpInteraction `json:"http,omitempty"`
+	GH   *ghInteraction   `json:"gh,omitempty"`
+}
+
+type httpInteraction struct {
+	Header      http.Header `json:"header,omitempty"`
+	Method      string      `json:"method"`
+	URL         string      `json:"url"`
+	RequestBody string      `json:"requestBody,omitempty"`
+	Body        string      `json:"body"`
+	Status      int         `json:"status"`
+}
+
+type ghInteraction struct {
+	Args     []string `json:"args"`
+	Stdin    string   `json:"stdin,omitempty"`
+	Stdout   string   `json:"stdout"`
+	Stderr   string   `json:"stderr,omitempty"`
+	Error    string   `json:"error,omitempty"`
+	ExitCode int      `json:"exitCode"`
+}
+
+// NewRecorder returns a new Recorder for the golden file at path. In the replay
+// mode, the golden file must exist.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	rec := &Recorder{
+		Transport: http.DefaultTransport,
+		path:      path,
+		cassette:  cassette{Interactions: []interaction{}},
+		used:      nil,
+		mode:      mode,
+		mutex:     sync.Mutex{},
+	}
+
+	if mode == ModeRecord {
+		return rec, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read golden file")
+	}
+
+	err = json.Unmarshal(data, &rec.cassette)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to parse golden file: %s", path)
+	}
+
+	rec.used = make([]bool, len(rec.cassette.Interactions))
+
+	return rec, nil
+}
+
+// Mode returns the mode of the recorder.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Apply makes the given Gisty send its HTTP requests and gh command executions
+// through the recorder.
+//
+// In the record mode, the HTTP client and the GHRunner already set to the Gisty
+// are used to execute the real requests. Apply it after the Gisty is set up.
+func (r *Recorder) Apply(obj *gisty.Gisty) {
+	next := obj.GHRunner
+	if next == nil {
+		next = gisty.DefaultGHRunner
+	}
+
+	obj.GHRunner = func(ctx context.Context, cmd gisty.GHCommand) error {
+		return r.runGH(ctx, cmd, next)
+	}
+
+	if r.mode == ModeReplay {
+		obj.Factory.HttpClient = func() (*http.Client, error) {
+			return &http.Client{Transport: r, CheckRedirect: nil, Jar: nil, Timeout: 0}, nil
+		}
+
+		return
+	}
+
+	httpClient := obj.Factory.HttpClient
+	obj.Factory.HttpClient = func() (*http.Client, error) {
+		client, err := httpClient()
+		if err != nil {
+			return nil, err
+		}
+
+		wrapped := *client
+		wrapped.Transport = &recordTransport{recorder: r, next: client.Transport}
+
+		return &wrapped, nil
+	}
+}
+
+// Save writes the recorded interactions to the golden file in the record mode.
+// It does nothing in the replay mode.
+func (r *Recorder) Save() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mutex.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mutex.Unlock()
+
+	if err != nil {
+		return errors.Wrap(err, "failed to encode golden file")
+	}
+
+	err = os.MkdirAll(filepath.Dir(r.path), 0o750)
+	if err != nil {
+		return errors.Wrap(err, "failed to create golden file directory")
+	}
+
+	return errors.Wrap(os.WriteFile(r.path, append(data, '\n'), 0o600), "failed to write golden file")
+}
+
+// ----------------------------------------------------------------------------
+//  HTTP
+// ----------------------------------------------------------------------------
+
+// recordTransport executes the real request and records the round trip.
+type recordTransport struct {
+	recorder *Recorder
+	next     http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *recordTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	next := t.next
+	if next == nil {
+		next = t.recorder.Transport
+	}
+
+	resp, err := next.RoundTrip(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to execute request to record")
+	}
+
+	respBody, err := readBody(&resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := resp.Header.Clone()
+	for _, key := range []string{"Set-Cookie", "Authorization", "X-Oauth-Scopes", "X-Github-Request-Id"} {
+		header.Del(key)
+	}
+
+	t.recorder.add(interaction{
+		HTTP: &httpInteraction{
+			Header:      header,
+			Method:      req.Method,
+			URL:         scrub(req.URL.String()),
+			RequestBody: scrub(string(reqBody)),
+			Body:        scrub(string(respBody)),
+			Status:      resp.StatusCode,
+		},
+		GH: nil,
+	})
+
+	return resp, nil
+}
+
+// RoundTrip implements http.RoundTripper by serving the recorded response that
+// matches the request in the replay mode.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := readBody(&req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	url := scrub(req.URL.String())
+	body := scrub(string(reqBody))
+
+	found := r.take(func(item interaction) bool {
+		return item.HTTP != nil && item.HTTP.Method == req.Method && item.HTTP.URL == url &&
+			item.HTTP.RequestBody == body
+	})
+	if found == nil {
+		return nil, errors.Errorf("gistytest: no recorded response for %s %s in %s", req.Method, url, r.path)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", found.HTTP.Status, http.StatusText(found.HTTP.Status)),
+		StatusCode:    found.HTTP.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        found.HTTP.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(found.HTTP.Body)),
+		ContentLength: int64(len(found.HTTP.Body)),
+		Request:       req,
+	}, nil
+}
+
+func readBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read body")
+	}
+
+	_ = (*body).Close()
+	*body = io.NopCloser(bytes.NewReader(data))
+
+	return data, nil
+}
+
+// ----------------------------------------------------------------------------
+//  gh command
+// ----------------------------------------------------------------------------
+
+// ExitError is returned in the replay mode when the recorded gh command failed.
+type ExitError struct {
+	Message string
+	Code    int
+}
+
+// Error implements the error interface.
+func (e *ExitError) Error() string {
+	return e.Message
+}
+
+// ExitCode returns the recorded exit code of the gh command.
+func (e *ExitError) ExitCode() int {
+	return e.Code
+}
+
+func (r *Recorder) runGH(ctx context.Context, cmd gisty.GHCommand, next gisty.GHRunner) error {
+	stdin := []byte{}
+
+	if cmd.Stdin != nil {
+		data, err := io.ReadAll(cmd.Stdin)
+		if err != nil {
+			return errors.Wrap(err, "failed to read stdin")
+		}
+
+		stdin = data
+	}
+
+	args := make([]string, 0, len(cmd.Args))
+	for _, arg := range cmd.Args {
+		args = append(args, scrub(arg))
+	}
+
+	if r.mode == ModeReplay {
+		return r.replayGH(cmd, args, scrub(string(stdin)))
+	}
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	errRun := next(ctx, gisty.GHCommand{
+		Stdin:  bytes.NewReader(stdin),
+		Stdout: io.MultiWriter(writerOrDiscard(cmd.Stdout), stdout),
+		Stderr: io.MultiWriter(writerOrDiscard(cmd.Stderr), stderr),
+		Args:   cmd.Args,
+		Env:    cmd.Env,
+	})
+
+	recorded := &ghInteraction{
+		Args:     args,
+		Stdin:    scrub(string(stdin)),
+		Stdout:   scrub(stdout.String()),
+		Stderr:   scrub(stderr.String()),
+		Error:    "",
+		ExitCode: 0,
+	}
+
+	if errRun != nil {
+		recorded.Error = scrub(errRun.Error())
+		recorded.ExitCode = 1
+
+		var exitErr *exec.ExitError
+		if errors.As(errRun, &exitErr) {
+			recorded.ExitCode = exitErr.ExitCode()
+		}
+	}
+
+	r.add(interaction{HTTP: nil, GH: recorded})
+
+	return errRun
+}
+
+func (r *Recorder) replayGH(cmd gisty.GHCommand, args []string, stdin string) error {
+	found := r.take(func(item interaction) bool {
+		return item.GH != nil && slices.Equal(item.GH.Args, args) && item.GH.Stdin == stdin
+	})
+	if found == nil {
+		return errors.Errorf("gistytest: no recorded gh execution for %q in %s", args, r.path)
+	}
+
+	for _, output := range []struct {
+		writer io.Writer
+		text   string
+	}{
+		{writer: cmd.Stdout, text: found.GH.Stdout},
+		{writer: cmd.Stderr, text: found.GH.Stderr},
+	} {
+		if output.writer == nil {
+			continue
+		}
+
+		_, err := io.WriteString(output.writer, output.text)
+		if err != nil {
+			return errors.Wrap(err, "failed to write recorded output")
+		}
+	}
+
+	if found.GH.Error != "" {
+		return &ExitError{Message: found.GH.Error, Code: found.GH.ExitCode}
+	}
+
+	return nil
+}
+
+func writerOrDiscard(writer io.Writer) io.Writer {
+	if writer == nil {
+		return io.Discard
+	}
+
+	return writer
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+func (r *Recorder) add(item interaction) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, item)
+}
+
+// take returns the first unused interaction that matches and marks it as used.
+// The same request can be recorded more than once and they are replayed in the
+// recorded order.
+func (r *Recorder) take(match func(interaction) bool) *interaction {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for index, item := range r.cassette.Interactions {
+		if !r.used[index] && match(item) {
+			r.used[index] = true
+
+			return &item
+		}
+	}
+
+	return nil
+}
+
+// reToken matches the GitHub tokens. Such as personal access tokens (classic
+// and fine-grained), OAuth, user-to-server, server-to-server and refresh tokens.
+var reToken = regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{16,}|github_pat_[A-Za-z0-9_]{22,})\b`)
+
+// tokenEnvs are the environment variables which may hold the tokens.
+var tokenEnvs = []string{"GH_TOKEN", "GITHUB_TOKEN", "GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+
+// scrub replaces the GitHub tokens in the text with Redacted.
+func scrub(text string) string {
+	text = reToken.ReplaceAllString(text, Redacted)
+
+	for _, env := range tokenEnvs {
+		if token := os.Getenv(env); len(token) > 3 {
+			text = strings.ReplaceAll(text, token, Redacted)
+		}
+	}
+
+	return text
+}
@@ -0,0 +1,51 @@
+package gistytest
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// rewriteTransport is an http.RoundTripper that redirects every request to the
+// fake server. The "/api/v3" and "/api" prefixes of GitHub Enterprise hosts are
+// removed so that github.com and GHES requests share the same routes.
+type rewriteTransport struct {
+	base   http.RoundTripper
+	target *url.URL
+}
+
+func newRewriteTransport(serverURL string, base http.RoundTripper) *rewriteTransport {
+	target, err := url.Parse(serverURL)
+	if err != nil {
+		panic("gistytest: invalid server URL: " + serverURL)
+	}
+
+	return &rewriteTransport{
+		base:   base,
+		target: target,
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	clone := req.Clone(req.Context())
+
+	clone.URL.Scheme = t.target.Scheme
+	clone.URL.Host = t.target.Host
+	clone.URL.Path = trimAPIPrefix(clone.URL.Path)
+	clone.URL.RawPath = ""
+	clone.Host = t.target.Host
+
+	//nolint:wrapcheck // the error of the underlying transport is returned as is
+	return t.base.RoundTrip(clone)
+}
+
+func trimAPIPrefix(path string) string {
+	for _, prefix := range []string{"/api/v3", "/api"} {
+		if trimmed, ok := strings.CutPrefix(path, prefix); ok && (trimmed == "" || trimmed[0] == '/') {
+			return trimmed
+		}
+	}
+
+	return path
+}
@@ -0,0 +1,166 @@
+// Package gistapi is a minimal client of the GitHub REST API for the gist
+// endpoints that are not covered by the GitHub CLI commands.
+package gistapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// HostDefault is the host name of github.com.
+const HostDefault = "github.com"
+
+// Client requests the GitHub REST API of a host.
+type Client struct {
+	// HTTP is the client used to send the requests. It is expected to add the
+	// authentication headers.
+	HTTP *http.Client
+	// Host is the GitHub host name. E.g. "github.com" or "ghe.example.com".
+	Host string
+}
+
+// New returns a new Client for the given host. If host is empty, github.com is
+// used.
+func New(httpClient *http.Client, host string) *Client {
+	if host == "" {
+		host = HostDefault
+	}
+
+	return &Client{
+		HTTP: httpClient,
+		Host: host,
+	}
+}
+
+// RESTPrefix returns the base URL of the REST API of the host.
+func (c *Client) RESTPrefix() string {
+	if strings.EqualFold(c.Host, HostDefault) {
+		return "https://api.github.com/"
+	}
+
+	return "https://" + c.Host + "/api/v3/"
+}
+
+// Do sends a request with the JSON encoded payload, if not nil, to the path
+// relative to the REST prefix. The JSON response is decoded into result, if
+// not nil. Responses other than 2xx are returned as *HTTPError.
+func (c *Client) Do(ctx context.Context, method, path string, payload, result any) error {
+	var body io.Reader
+
+	if payload != nil {
+		encoded, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode request body: %w", err)
+		}
+
+		body = bytes.NewReader(encoded)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.RESTPrefix()+strings.TrimPrefix(path, "/"), body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to request %s %s: %w", method, path, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newHTTPError(req, resp)
+	}
+
+	if result == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	err = json.NewDecoder(resp.Body).Decode(result)
+	if err != nil {
+		return fmt.Errorf("failed to decode response of %s %s: %w", method, path, err)
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: HTTPError
+// ----------------------------------------------------------------------------
+
+// HTTPError is an error response of the API.
+type HTTPError struct {
+	// Header is the header of the response.
+	Header http.Header
+	// Message is the error message of the response body, if any.
+	Message string
+	// Method and URL of the request.
+	Method string
+	URL    string
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+}
+
+func newHTTPError(req *http.Request, resp *http.Response) *HTTPError {
+	var body struct {
+		Message string `json:"message"`
+	}
+
+	_ = json.NewDecoder(resp.Body).Decode(&body) // the message is optional
+
+	return &HTTPError{
+		Header:     resp.Header,
+		Message:    body.Message,
+		Method:     req.Method,
+		URL:        req.URL.String(),
+		StatusCode: resp.StatusCode,
+	}
+}
+
+// RetryAfter returns the time to wait before retrying the request if the
+// response is a rate limit error. It returns false if it is not.
+func (e *HTTPError) RetryAfter(now time.Time) (time.Duration, bool) {
+	if e.StatusCode != http.StatusTooManyRequests && e.StatusCode != http.StatusForbidden {
+		return 0, false
+	}
+
+	// Secondary rate limits.
+	if seconds, err := strconv.Atoi(e.Header.Get("Retry-After")); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second, true
+	}
+
+	// Primary rate limits.
+	if e.Header.Get("X-Ratelimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(e.Header.Get("X-Ratelimit-Reset"), 10, 64)
+		if err != nil {
+			return 0, true
+		}
+
+		return max(time.Unix(reset, 0).Sub(now), 0), true
+	}
+
+	return 0, e.StatusCode == http.StatusTooManyRequests
+}
+
+// Error implements the error interface.
+func (e *HTTPError) Error() string {
+	msg := fmt.Sprintf("HTTP %d: %s %s", e.StatusCode, e.Method, e.URL)
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+
+	return msg
+}
@@ -0,0 +1,178 @@
+package gistapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTestClient returns a client that sends the requests to the handler.
+func newTestClient(t *testing.T, host string, handler http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	target, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		req.URL.Scheme = target.Scheme
+		req.URL.Host = target.Host
+
+		return http.DefaultTransport.RoundTrip(req)
+	})
+
+	return New(&http.Client{Transport: transport, CheckRedirect: nil, Jar: nil, Timeout: 0}, host)
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestClient_RESTPrefix(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, "https://api.github.com/", New(nil, "").RESTPrefix())
+	require.Equal(t, "https://ghe.example.com/api/v3/", New(nil, "ghe.example.com").RESTPrefix())
+}
+
+func TestClient_GetGist(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "ghe.example.com", func(resp http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/api/v3/gists/abc", req.URL.Path)
+
+		_, err := resp.Write([]byte(`{"id":"abc","files":{"a.txt":{"content":"A"}},"history":[{"version":"v2"},{"version":"v1"}]}`))
+		require.NoError(t, err)
+	})
+
+	gist, err := client.GetGist(context.Background(), "abc")
+
+	require.NoError(t, err)
+	require.Equal(t, "A", gist.Files["a.txt"].Content)
+	require.Equal(t, "v2", gist.Revision())
+	require.Empty(t, (&Gist{}).Revision(), "revision should be empty without history") //nolint:exhaustruct // zero value
+}
+
+func TestClient_EditGist(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "", func(resp http.ResponseWriter, req *http.Request) {
+		require.Equal(t, http.MethodPatch, req.Method)
+		require.Equal(t, "/gists/abc", req.URL.Path)
+
+		body := make([]byte, req.ContentLength)
+		_, _ = req.Body.Read(body)
+		require.JSONEq(t, `{"files":{"a.txt":{"content":"B"},"b.txt":null}}`, string(body))
+
+		_, err := resp.Write([]byte(`{"id":"abc"}`))
+		require.NoError(t, err)
+	})
+
+	gist, err := client.EditGist(context.Background(), "abc", map[string]*EditFile{
+		"a.txt": {Content: "B"},
+		"b.txt": nil,
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, "abc", gist.ID)
+}
+
+func TestClient_ListGists_pagination(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "", func(resp http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/gists", req.URL.Path)
+		require.Equal(t, "100", req.URL.Query().Get("per_page"))
+
+		count := perPageMax
+		if req.URL.Query().Get("page") == "2" {
+			count = 1
+		}
+
+		gists := make([]Gist, count)
+		for index := range gists {
+			gists[index].ID = req.URL.Query().Get("page") + "-" + strconv.Itoa(index)
+		}
+
+		require.NoError(t, json.NewEncoder(resp).Encode(gists))
+	})
+
+	gists, err := client.ListGists(context.Background())
+
+	require.NoError(t, err)
+	require.Len(t, gists, perPageMax+1)
+	require.Equal(t, "2-0", gists[perPageMax].ID)
+}
+
+func TestClient_Do_error(t *testing.T) {
+	t.Parallel()
+
+	client := newTestClient(t, "", func(resp http.ResponseWriter, _ *http.Request) {
+		resp.WriteHeader(http.StatusNotFound)
+
+		_, err := resp.Write([]byte(`{"message":"Not Found"}`))
+		require.NoError(t, err)
+	})
+
+	_, err := client.GetGist(context.Background(), "abc")
+
+	var errHTTP *HTTPError
+
+	require.ErrorAs(t, err, &errHTTP)
+	require.Equal(t, http.StatusNotFound, errHTTP.StatusCode)
+	require.Equal(t, "HTTP 404: GET https://api.github.com/gists/abc: Not Found", err.Error())
+
+	// Payloads that cannot be encoded.
+	err = client.Do(context.Background(), http.MethodPost, "gists", func() {}, nil)
+	require.ErrorContains(t, err, "failed to encode request body")
+}
+
+func TestHTTPError_RetryAfter(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1000, 0)
+
+	for _, test := range []struct {
+		header http.Header
+		name   string
+		status int
+		want   time.Duration
+		ok     bool
+	}{
+		{name: "not found", status: http.StatusNotFound, header: http.Header{}, want: 0, ok: false},
+		{name: "forbidden", status: http.StatusForbidden, header: http.Header{}, want: 0, ok: false},
+		{name: "too many requests", status: http.StatusTooManyRequests, header: http.Header{}, want: 0, ok: true},
+		{
+			name: "retry after", status: http.StatusForbidden,
+			header: http.Header{"Retry-After": {"30"}}, want: 30 * time.Second, ok: true,
+		},
+		{
+			name: "rate limit reset", status: http.StatusForbidden,
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"1060"}},
+			want:   time.Minute, ok: true,
+		},
+		{
+			name: "rate limit without reset", status: http.StatusForbidden,
+			header: http.Header{"X-Ratelimit-Remaining": {"0"}}, want: 0, ok: true,
+		},
+	} {
+		errHTTP := &HTTPError{Header: test.header, Message: "", Method: "", URL: "", StatusCode: test.status}
+
+		wait, ok := errHTTP.RetryAfter(now)
+
+		require.Equal(t, test.ok, ok, test.name)
+		require.Equal(t, test.want, wait, test.name)
+	}
+}
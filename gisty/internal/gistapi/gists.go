@@ -0,0 +1,209 @@
+package gistapi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Gist is a gist of the REST API.
+type Gist struct {
+	UpdatedAt   time.Time        `json:"updated_at"`
+	Files       map[string]*File `json:"files"`
+	Owner       User             `json:"owner"`
+	ID          string           `json:"id"`
+	Description string           `json:"description"`
+	HTMLURL     string           `json:"html_url"`
+	GitPullURL  string           `json:"git_pull_url"`
+	GitPushURL  string           `json:"git_push_url"`
+	History     []History        `json:"history"`
+	Public      bool             `json:"public"`
+}
+
+// Revision returns the latest version of the gist in the history. It is empty
+// if the history is not included in the response.
+func (g *Gist) Revision() string {
+	if len(g.History) == 0 {
+		return ""
+	}
+
+	return g.History[0].Version
+}
+
+// User is the owner of a gist or the author of a comment.
+type User struct {
+	Login string `json:"login"`
+}
+
+// File is a file of a gist.
+type File struct {
+	Filename  string `json:"filename"`
+	Language  string `json:"language"`
+	RawURL    string `json:"raw_url"`
+	Content   string `json:"content"`
+	Size      int    `json:"size"`
+	Truncated bool   `json:"truncated"`
+}
+
+// History is a revision of a gist.
+type History struct {
+	CommittedAt time.Time `json:"committed_at"`
+	Version     string    `json:"version"`
+}
+
+// EditFile is a file change of a gist edit. A nil *EditFile deletes the file.
+type EditFile struct {
+	// Content is the new content of the file. If empty, the content is kept.
+	Content string `json:"content,omitempty"`
+	// Filename is the new name of the file. If empty, the file is not renamed.
+	Filename string `json:"filename,omitempty"`
+}
+
+// GetGist returns the gist with the file contents and the history.
+func (c *Client) GetGist(ctx context.Context, gistID string) (*Gist, error) {
+	gist := new(Gist)
+
+	err := c.Do(ctx, http.MethodGet, "gists/"+gistID, nil, gist)
+	if err != nil {
+		return nil, err
+	}
+
+	return gist, nil
+}
+
+// GetGistRevision returns the gist at the revision, the SHA of a commit of the
+// gist repository.
+func (c *Client) GetGistRevision(ctx context.Context, gistID, revision string) (*Gist, error) {
+	gist := new(Gist)
+
+	err := c.Do(ctx, http.MethodGet, "gists/"+gistID+"/"+revision, nil, gist)
+	if err != nil {
+		return nil, err
+	}
+
+	return gist, nil
+}
+
+// EditGist changes the files of the gist and returns the updated gist.
+func (c *Client) EditGist(ctx context.Context, gistID string, files map[string]*EditFile) (*Gist, error) {
+	return c.patchGist(ctx, gistID, map[string]any{"files": files})
+}
+
+// UpdateGist changes the description and the files of the gist and returns the
+// updated gist.
+func (c *Client) UpdateGist(
+	ctx context.Context, gistID, description string, files map[string]*EditFile,
+) (*Gist, error) {
+	return c.patchGist(ctx, gistID, map[string]any{"description": description, "files": files})
+}
+
+func (c *Client) patchGist(ctx context.Context, gistID string, payload map[string]any) (*Gist, error) {
+	gist := new(Gist)
+
+	err := c.Do(ctx, http.MethodPatch, "gists/"+gistID, payload, gist)
+	if err != nil {
+		return nil, err
+	}
+
+	return gist, nil
+}
+
+// NewGist is the payload to create a gist.
+type NewGist struct {
+	Files       map[string]*EditFile `json:"files"`
+	Description string               `json:"description"`
+	Public      bool                 `json:"public"`
+}
+
+// Comment is a comment of a gist.
+type Comment struct {
+	CreatedAt time.Time `json:"created_at"`
+	User      User      `json:"user"`
+	Body      string    `json:"body"`
+	ID        int64     `json:"id"`
+}
+
+// perPageMax is the max number of items per page of the list endpoints.
+const perPageMax = 100
+
+// ListGists returns all the gists of the authenticated user. The file contents
+// are not included.
+func (c *Client) ListGists(ctx context.Context) ([]Gist, error) {
+	return listAll[Gist](ctx, c, "gists")
+}
+
+// CreateGist creates a new gist and returns it.
+func (c *Client) CreateGist(ctx context.Context, gist NewGist) (*Gist, error) {
+	created := new(Gist)
+
+	err := c.Do(ctx, http.MethodPost, "gists", gist, created)
+	if err != nil {
+		return nil, err
+	}
+
+	return created, nil
+}
+
+// DeleteGist deletes the gist.
+func (c *Client) DeleteGist(ctx context.Context, gistID string) error {
+	return c.Do(ctx, http.MethodDelete, "gists/"+gistID, nil, nil)
+}
+
+// ListComments returns all the comments of the gist, oldest first.
+func (c *Client) ListComments(ctx context.Context, gistID string) ([]Comment, error) {
+	return listAll[Comment](ctx, c, "gists/"+gistID+"/comments")
+}
+
+// CreateComment adds a comment to the gist.
+func (c *Client) CreateComment(ctx context.Context, gistID, body string) error {
+	return c.Do(ctx, http.MethodPost, "gists/"+gistID+"/comments", map[string]string{"body": body}, nil)
+}
+
+// GetRaw returns the content at the raw URL of a file. It is used to get the
+// content of the truncated files.
+func (c *Client) GetRaw(ctx context.Context, rawURL string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request %s: %w", rawURL, err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, newHTTPError(req, resp)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", rawURL, err)
+	}
+
+	return content, nil
+}
+
+// listAll requests all the pages of the list endpoint.
+func listAll[T any](ctx context.Context, c *Client, path string) ([]T, error) {
+	result := []T{}
+
+	for page := 1; ; page++ {
+		var items []T
+
+		err := c.Do(ctx, http.MethodGet, fmt.Sprintf("%s?per_page=%d&page=%d", path, perPageMax, page), nil, &items)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, items...)
+
+		if len(items) < perPageMax {
+			return result, nil
+		}
+	}
+}
@@ -2,26 +2,66 @@
 package httpclient
 
 import (
+	"context"
 	"net/http"
 
+	"github.com/KEINOS/go-gisty/gisty/buildinfos"
 	cliapi "github.com/cli/cli/v2/api"
 	ghauth "github.com/cli/go-gh/v2/pkg/auth"
 )
 
+// tokenGetter returns the token and its source of the host.
+type tokenGetter interface {
+	ActiveToken(host string) (string, string)
+}
+
 type authTokenGetter struct{}
 
 func (authTokenGetter) ActiveToken(host string) (string, string) {
 	return ghauth.TokenForHost(host)
 }
 
-// New returns a GitHub CLI-compatible HTTP client factory.
-func New(appVersion, invokingAgent string) func() (*http.Client, error) {
+type fixedTokenGetter string
+
+func (t fixedTokenGetter) ActiveToken(string) (string, string) {
+	return string(t), "token"
+}
+
+// New returns a GitHub CLI-compatible HTTP client factory. See userAgent for
+// the User-Agent header of the clients.
+func New() func() (*http.Client, error) {
+	return newFactory(authTokenGetter{})
+}
+
+// NewWithToken returns a GitHub CLI-compatible HTTP client factory which
+// authenticates with the given token to any host, instead of the token of the
+// host in the environment or the gh config.
+func NewWithToken(token string) func() (*http.Client, error) {
+	return newFactory(fixedTokenGetter(token))
+}
+
+// userAgent returns the app version and the invoking agent of the clients.
+// The User-Agent header is "GitHub CLI <app version> Agent/<invoking agent>",
+// where the app version is the version of the GitHub CLI module Gisty is built
+// against. E.g. "GitHub CLI v2.97.0 Agent/go-gisty/v1.2.3 (...)".
+func userAgent() (string, string) {
+	appVersion := buildinfos.CLIVersion()
+	if appVersion == "" {
+		appVersion = "(devel)"
+	}
+
+	return appVersion, buildinfos.UserAgent()
+}
+
+func newFactory(tokens tokenGetter) func() (*http.Client, error) {
+	appVersion, invokingAgent := userAgent()
+
 	return func() (*http.Client, error) {
 		return cliapi.NewHTTPClient(cliapi.HTTPClientOptions{
 			AppVersion:         appVersion,
 			InvokingAgent:      invokingAgent,
 			CacheTTL:           0,
-			Config:             authTokenGetter{},
+			Config:             tokens,
 			EnableCache:        false,
 			Log:                nil,
 			LogColorize:        false,
@@ -31,3 +71,36 @@ func New(appVersion, invokingAgent string) func() (*http.Client, error) {
 		})
 	}
 }
+
+// WithContext returns a factory of the clients of factory whose requests are
+// canceled along with ctx, for the callers building the requests without a
+// context, such as the GitHub CLI commands.
+func WithContext(ctx context.Context, factory func() (*http.Client, error)) func() (*http.Client, error) {
+	return func() (*http.Client, error) {
+		client, err := factory()
+		if err != nil {
+			return nil, err
+		}
+
+		transport := client.Transport
+		if transport == nil {
+			transport = http.DefaultTransport
+		}
+
+		withCtx := *client
+		withCtx.Transport = contextTransport{ctx: ctx, base: transport}
+
+		return &withCtx, nil
+	}
+}
+
+// contextTransport sends the requests with ctx.
+type contextTransport struct {
+	ctx  context.Context //nolint:containedctx // the requests are built without a context
+	base http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.base.RoundTrip(req.WithContext(t.ctx)) //nolint:wrapcheck // the error of the base transport as is
+}
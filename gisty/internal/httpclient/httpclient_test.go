@@ -1,6 +1,11 @@
 package httpclient
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -18,8 +23,70 @@ func TestAuthTokenGetter_ActiveToken(t *testing.T) {
 func TestNew(t *testing.T) {
 	t.Parallel()
 
-	client, err := New("v1.2.3", "go-gisty-test")()
+	client, err := New()()
 
 	require.NoError(t, err)
 	require.NotNil(t, client)
 }
+
+func TestNewWithToken(t *testing.T) {
+	t.Parallel()
+
+	token, _ := fixedTokenGetter("other-token").ActiveToken("ghe.example.com")
+
+	require.Equal(t, "other-token", token)
+
+	client, err := NewWithToken("other-token")()
+
+	require.NoError(t, err)
+	require.NotNil(t, client)
+}
+
+func TestNew_user_agent(t *testing.T) {
+	t.Parallel()
+
+	var gotUserAgent string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		gotUserAgent = r.Header.Get("User-Agent")
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewWithToken("dummy-token")()
+	require.NoError(t, err)
+
+	resp, err := client.Get(srv.URL) //nolint:noctx // test request
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	appVersion, agent := userAgent()
+
+	require.Equal(t, "GitHub CLI "+appVersion+" Agent/"+agent, gotUserAgent)
+	require.True(t, strings.HasPrefix(agent, "go-gisty/"), "agent should tell Gisty: %s", agent)
+}
+
+func TestWithContext(t *testing.T) {
+	t.Parallel()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client, err := WithContext(ctx, NewWithToken("dummy-token"))()
+	require.NoError(t, err)
+
+	resp, err := client.Get(srv.URL) //nolint:noctx // the context is of the client
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	cancel()
+
+	_, err = client.Get(srv.URL) //nolint:noctx // the context is of the client
+	require.ErrorIs(t, err, context.Canceled)
+
+	_, err = WithContext(ctx, func() (*http.Client, error) {
+		return nil, errors.New("forced error")
+	})()
+	require.ErrorContains(t, err, "forced error")
+}
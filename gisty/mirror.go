@@ -0,0 +1,259 @@
+package gisty
+
+import (
+	"context"
+	"errors"
+	"net"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/internal/gistapi"
+	"github.com/KEINOS/go-gisty/gisty/store"
+	"github.com/cli/cli/v2/pkg/cmd/gist/shared"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: MirrorOptions, MirrorResult
+// ----------------------------------------------------------------------------
+
+// MirrorOptions are the options for the Mirror function.
+type MirrorOptions struct {
+	// ListLimit is the maximum number of recent gists to mirror. If less than
+	// 1, SearchListLimitDefault is used.
+	ListLimit int
+}
+
+// NewMirrorOptions returns a new MirrorOptions with the default values.
+func NewMirrorOptions() MirrorOptions {
+	return MirrorOptions{
+		ListLimit: SearchListLimitDefault,
+	}
+}
+
+// MirrorResult is the result of Mirror.
+type MirrorResult struct {
+	Updated   []string `json:"updated"`   // IDs of the gists added or refreshed.
+	Deleted   []string `json:"deleted"`   // IDs of the gists no longer listed.
+	Unchanged int      `json:"unchanged"` // number of the gists up to date.
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Mirror
+// ----------------------------------------------------------------------------
+
+// Mirror refreshes g.Store with the gists of the viewer, including their file
+// contents and comments.
+//
+// Only the gists whose UpdatedAt in List differs from the stored one are
+// fetched again, and the gists no longer listed are removed. Each fetched gist
+// is stored as soon as it is fetched, so an interrupted mirror resumes where
+// it stopped.
+func (g *Gisty) Mirror(opts MirrorOptions) (MirrorResult, error) {
+	ctx := context.Background()
+	result := MirrorResult{Updated: []string{}, Deleted: []string{}, Unchanged: 0}
+
+	if g.Store == nil {
+		return result, NewErr("no store to mirror the gists to")
+	}
+
+	listLimit := opts.ListLimit
+	if listLimit < 1 {
+		listLimit = SearchListLimitDefault
+	}
+
+	// The listed gists are parsed from the Stdout, which is shared by the calls.
+	g.Stdout.Reset()
+
+	infos, err := g.List(ListArgs{Limit: listLimit, OnlyPublic: false, OnlySecret: false})
+	if err != nil {
+		return result, WrapIfErr(err, "failed to list gists to mirror")
+	}
+
+	stored, err := g.Store.UpdateTimes()
+	if err != nil {
+		return result, WrapIfErr(err, "failed to read the mirrored gists")
+	}
+
+	client, err := g.apiClient()
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range infos {
+		updatedAt, ok := stored[info.GistID]
+
+		delete(stored, info.GistID)
+
+		if ok && updatedAt.Equal(info.UpdatedAt) {
+			result.Unchanged++
+
+			continue
+		}
+
+		gist, err := mirrorGist(ctx, client, info)
+		if err != nil {
+			return result, err
+		}
+
+		err = g.Store.Put(gist)
+		if err != nil {
+			return result, WrapIfErr(err, "failed to mirror gist: %s", info.GistID)
+		}
+
+		result.Updated = append(result.Updated, info.GistID)
+	}
+
+	// The remaining gists were deleted or are beyond the list limit.
+	for _, gistID := range sortedKeys(stored) {
+		err = g.Store.Delete(gistID)
+		if err != nil {
+			return result, WrapIfErr(err, "failed to remove the mirrored gist: %s", gistID)
+		}
+
+		result.Deleted = append(result.Deleted, gistID)
+	}
+
+	return result, nil
+}
+
+// mirrorGist fetches the gist with its file contents and comments. UpdatedAt
+// is taken from info to compare it with the next List.
+func mirrorGist(ctx context.Context, client *gistapi.Client, info GistInfo) (store.Gist, error) {
+	gist, err := client.GetGist(ctx, info.GistID)
+	if err != nil {
+		return store.Gist{}, wrapAPIErr(err, "failed to get gist: %s", info.GistID)
+	}
+
+	mirrored := store.Gist{
+		UpdatedAt:   info.UpdatedAt,
+		MirroredAt:  time.Now().UTC(),
+		Files:       make([]store.File, 0, len(gist.Files)),
+		Comments:    []store.Comment{},
+		ID:          gist.ID,
+		Description: gist.Description,
+		Owner:       gist.Owner.Login,
+		HTMLURL:     gist.HTMLURL,
+		Public:      gist.Public,
+	}
+
+	for _, name := range sortedKeys(gist.Files) {
+		file := gist.Files[name]
+		content := file.Content
+
+		if file.Truncated {
+			raw, err := client.GetRaw(ctx, file.RawURL)
+			if err != nil {
+				return mirrored, wrapAPIErr(err, "failed to get file %s of gist %s", name, gist.ID)
+			}
+
+			content = string(raw)
+		}
+
+		mirrored.Files = append(mirrored.Files, store.File{Name: name, Language: file.Language, Content: content})
+	}
+
+	comments, err := client.ListComments(ctx, gist.ID)
+	if err != nil {
+		return mirrored, wrapAPIErr(err, "failed to get comments of gist %s", gist.ID)
+	}
+
+	for _, comment := range comments {
+		mirrored.Comments = append(mirrored.Comments, store.Comment{
+			CreatedAt: comment.CreatedAt,
+			Author:    comment.User.Login,
+			Body:      comment.Body,
+		})
+	}
+
+	return mirrored, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Reading from the store
+// ----------------------------------------------------------------------------
+
+// getStore returns the mirrored gist from g.Store.
+func (g *Gisty) getStore(gist string) (store.Gist, error) {
+	gistID, err := g.gistIDFromArg(gist)
+	if err != nil {
+		return store.Gist{}, err
+	}
+
+	mirrored, err := g.Store.Get(gistID)
+	if errors.Is(err, store.ErrNotFound) {
+		return mirrored, WrapIfErr(errors.Join(ErrNotFound, err), "failed to read gist from store: %s", gistID)
+	}
+
+	return mirrored, WrapIfErr(err, "failed to read gist from store: %s", gistID)
+}
+
+// readStore returns the gist from g.Store in the form of Read.
+func (g *Gisty) readStore(gist string) (*shared.Gist, error) {
+	mirrored, err := g.getStore(gist)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]*shared.GistFile, len(mirrored.Files))
+
+	for _, file := range mirrored.Files {
+		files[file.Name] = &shared.GistFile{
+			Filename:  file.Name,
+			Type:      "",
+			Language:  file.Language,
+			Content:   file.Content,
+			RawURL:    "",
+			Truncated: false,
+		}
+	}
+
+	return &shared.Gist{
+		ID:          mirrored.ID,
+		Description: mirrored.Description,
+		Files:       files,
+		UpdatedAt:   mirrored.UpdatedAt,
+		Public:      mirrored.Public,
+		HTMLURL:     mirrored.HTMLURL,
+		Owner:       &shared.GistOwner{Login: mirrored.Owner},
+	}, nil
+}
+
+// readStoreComments returns the comments of the gist from g.Store in the form
+// of Comments, up to the latest g.MaxComment ones. Only the author login, the
+// body and the creation time are mirrored, so the other fields are empty.
+func (g *Gisty) readStoreComments(gist string) ([]Comment, error) {
+	mirrored, err := g.getStore(gist)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := mirrored.Comments[max(len(mirrored.Comments)-g.MaxComment, 0):]
+	comments := make([]Comment, 0, len(latest))
+
+	for _, comment := range latest {
+		createdAt := comment.CreatedAt.Format(time.RFC3339)
+
+		comments = append(comments, Comment{
+			Author:            Author{AvatarURL: "", Login: comment.Author},
+			ID:                "",
+			AuthorAssociation: "",
+			BodyRaw:           comment.Body,
+			BodyHTML:          "",
+			BodyText:          comment.Body,
+			CreatedAt:         createdAt,
+			PublishedAt:       createdAt,
+			LastEditedAt:      "",
+			MinimizedReason:   "",
+			IsMinimized:       false,
+		})
+	}
+
+	return comments, nil
+}
+
+// isNetworkErr returns true if err is caused by a failure to reach the host,
+// such as being offline.
+func isNetworkErr(err error) bool {
+	var errNet net.Error
+
+	return errors.As(err, &errNet)
+}
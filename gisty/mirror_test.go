@@ -0,0 +1,157 @@
+package gisty_test
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/KEINOS/go-gisty/gisty/store"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestStore(t *testing.T) *store.Store {
+	t.Helper()
+
+	mirror, err := store.Open(filepath.Join(t.TempDir(), "mirror.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { require.NoError(t, mirror.Close()) })
+
+	return mirror
+}
+
+func TestGisty_Mirror(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+	obj.Store = openTestStore(t)
+
+	result, err := obj.Mirror(gisty.NewMirrorOptions())
+
+	require.NoError(t, err)
+	require.ElementsMatch(t, gistIDs, result.Updated)
+	require.Empty(t, result.Deleted)
+
+	mirrored, err := obj.Store.Get(gistIDs[0])
+
+	require.NoError(t, err)
+	require.Equal(t, "public gist", mirrored.Description)
+	require.Equal(t, srv.Login, mirrored.Owner)
+	require.Equal(t, []store.File{
+		{Name: "README.md", Language: "Markdown", Content: "# Hello\n"},
+		{Name: "main.go", Language: "Go", Content: "package main\n"},
+	}, mirrored.Files)
+	require.Equal(t, []store.Comment{{
+		CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+		Author:    "alice",
+		Body:      "Nice!\nThanks.",
+	}}, mirrored.Comments)
+
+	// Only the updated gist is fetched again and the deleted one is removed.
+	srv.ResetRequests()
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		UpdatedAt:   time.Now().UTC().Add(time.Hour).Truncate(time.Second),
+		ID:          gistIDs[1],
+		Description: "secret gist, edited",
+		Files:       map[string]string{"secret.txt": "s3cr3t"},
+	})
+	require.NoError(t, obj.Delete(gistIDs[0]))
+
+	result, err = obj.Mirror(gisty.NewMirrorOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, gisty.MirrorResult{Updated: []string{gistIDs[1]}, Deleted: []string{gistIDs[0]}, Unchanged: 0}, result)
+	require.Equal(t, 1, srv.Requested(http.MethodGet, "/gists/"+gistIDs[1]))
+
+	gists, err := obj.Store.List()
+
+	require.NoError(t, err)
+	require.Len(t, gists, 1)
+	require.Equal(t, "secret gist, edited", gists[0].Description)
+
+	result, err = obj.Mirror(gisty.NewMirrorOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Unchanged)
+	require.Empty(t, result.Updated)
+}
+
+func TestGisty_Mirror_errors(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+
+	_, err := obj.Mirror(gisty.NewMirrorOptions())
+
+	require.ErrorContains(t, err, "no store to mirror the gists to")
+
+	obj.Store = openTestStore(t)
+
+	srv.FailNext(http.MethodGet, "/gists/"+gistIDs[1]+"/comments", http.StatusInternalServerError, nil)
+
+	result, err := obj.Mirror(gisty.NewMirrorOptions())
+
+	require.ErrorContains(t, err, "failed to get comments of gist "+gistIDs[1])
+	require.Len(t, result.Updated, 1, "gists mirrored before the error should be reported")
+}
+
+func TestGisty_Read_from_store(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newBackupTestServer(t)
+	obj := srv.NewGisty()
+	obj.Store = openTestStore(t)
+
+	_, err := obj.Mirror(gisty.NewMirrorOptions())
+	require.NoError(t, err)
+
+	// ReadFromCache does not request GitHub.
+	srv.ResetRequests()
+
+	obj.ReadFromCache = true
+
+	gist, err := obj.Read("https://gist.github.com/" + gistIDs[0])
+
+	require.NoError(t, err)
+	require.Equal(t, "package main\n", gist.Files["main.go"].Content)
+	require.Equal(t, srv.Login, gist.Owner.Login)
+	require.Empty(t, srv.Requests())
+
+	_, err = obj.Read("unknown")
+
+	require.ErrorIs(t, err, gisty.ErrNotFound)
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	comments, err := obj.Comments(gistIDs[0])
+
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+	require.Equal(t, "alice", comments[0].Author.Login)
+	require.Equal(t, "Nice!\nThanks.", comments[0].BodyRaw)
+	require.Equal(t, "2026-01-02T03:04:05Z", comments[0].CreatedAt)
+	require.Empty(t, srv.Requests(), "comments should be read from the store")
+
+	// Offline, the store is used as a fallback.
+	obj.ReadFromCache = false
+
+	srv.Close()
+
+	gist, err = obj.Read(gistIDs[1])
+
+	require.NoError(t, err)
+	require.Equal(t, "secret gist", gist.Description)
+
+	comments, err = obj.Comments(gistIDs[0])
+
+	require.NoError(t, err)
+	require.Len(t, comments, 1)
+
+	_, err = obj.Read("unknown")
+
+	require.Error(t, err, "gists not in the store should fail with the network error")
+}
@@ -0,0 +1,384 @@
+package gisty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: Policy
+// ----------------------------------------------------------------------------
+
+// Policy checks the arguments of Create and Update before they are executed.
+//
+// Set the policies to Gisty.Policies. They are called in order and may rewrite
+// the arguments, e.g. to force the gist to be secret, or reject them by
+// returning an error. The built-in policies return *PolicyViolationError.
+//
+// The other methods publishing gists are checked the same way. SetVisibility,
+// Restore and Migrate are checked as a Create with the files of the gist to
+// be published. Push, Sync and Watch are checked as an Update of their
+// directory. Since they take none of the arguments of Update, the policies
+// can only reject them, and rewriting the arguments is rejected as a
+// *PolicyViolationError instead of being ignored.
+type Policy interface {
+	// CheckCreate is called with the arguments of Create.
+	CheckCreate(args *CreateArgs) error
+	// CheckUpdate is called with the arguments of Update.
+	CheckUpdate(args *UpdateArgs) error
+}
+
+// PolicyViolationError is returned when the arguments violate a policy. It
+// wraps ErrPolicyViolation.
+type PolicyViolationError struct {
+	// Policy is the name of the violated policy. E.g. "max-file-size".
+	Policy string
+	// File is the path of the violating file. Empty if not about a file.
+	File string
+	// Reason describes the violation.
+	Reason string
+}
+
+// Error implements the error interface.
+func (e *PolicyViolationError) Error() string {
+	if e.File != "" {
+		return fmt.Sprintf("%s: %s: %s: %s", ErrPolicyViolation, e.Policy, e.File, e.Reason)
+	}
+
+	return fmt.Sprintf("%s: %s: %s", ErrPolicyViolation, e.Policy, e.Reason)
+}
+
+// Unwrap returns ErrPolicyViolation.
+func (e *PolicyViolationError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// checkCreatePolicies applies g.Policies to args. The violations of all the
+// policies are joined.
+func (g *Gisty) checkCreatePolicies(args *CreateArgs) error {
+	errs := make([]error, 0, len(g.Policies))
+	for _, policy := range g.Policies {
+		errs = append(errs, policy.CheckCreate(args))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkCreateContents applies g.Policies to args as checkCreatePolicies does,
+// but with the files given as names to contents instead of args.FilePaths.
+// Since the policies check the files by path, the files are written to a
+// temporary directory. The violations are reported with the file names.
+func (g *Gisty) checkCreateContents(args *CreateArgs, contents map[string][]byte) error {
+	if len(g.Policies) == 0 {
+		return nil
+	}
+
+	dirTemp, err := os.MkdirTemp("", "gisty-policy-*")
+	if err != nil {
+		return WrapIfErr(err, "failed to create temporary directory")
+	}
+
+	defer os.RemoveAll(dirTemp)
+
+	filePaths := make([]string, 0, len(contents))
+
+	for _, name := range sortedKeys(contents) {
+		if !filepath.IsLocal(name) || strings.ContainsAny(name, `/\`) {
+			return NewErr("invalid file name of gist: %q", name)
+		}
+
+		pathFile := filepath.Join(dirTemp, name)
+
+		err = os.WriteFile(pathFile, contents[name], 0o600)
+		if err != nil {
+			return WrapIfErr(err, "failed to write file to check: %s", name)
+		}
+
+		filePaths = append(filePaths, pathFile)
+	}
+
+	args.FilePaths = filePaths
+
+	err = g.checkCreatePolicies(args)
+
+	args.FilePaths = nil
+
+	trimViolationFiles(err, dirTemp)
+
+	return err
+}
+
+// trimViolationFiles removes dir from the files of the *PolicyViolationError
+// in err, including the joined ones.
+func trimViolationFiles(err error, dir string) {
+	//nolint:errorlint // the joined errors are walked manually
+	switch err := err.(type) {
+	case *PolicyViolationError:
+		if rel, errRel := filepath.Rel(dir, err.File); err.File != "" && errRel == nil {
+			err.File = rel
+		}
+	case interface{ Unwrap() []error }:
+		for _, joined := range err.Unwrap() {
+			trimViolationFiles(joined, dir)
+		}
+	}
+}
+
+// checkUpdatePolicies applies g.Policies to args. The violations of all the
+// policies are joined.
+func (g *Gisty) checkUpdatePolicies(args *UpdateArgs) error {
+	errs := make([]error, 0, len(g.Policies))
+	for _, policy := range g.Policies {
+		errs = append(errs, policy.CheckUpdate(args))
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkUpdateDir applies g.Policies to dir as an Update on behalf of method,
+// which publishes the files of dir without the other arguments of Update. A
+// rewrite of the arguments is rejected, since method cannot apply it.
+func (g *Gisty) checkUpdateDir(dir, method string) error {
+	args := NewUpdateArgs(dir)
+
+	err := g.checkUpdatePolicies(&args)
+	if err != nil {
+		return err
+	}
+
+	if args != NewUpdateArgs(dir) {
+		return &PolicyViolationError{
+			Policy: "update-args",
+			File:   "",
+			Reason: "the policies cannot change the arguments of " + method,
+		}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Built-in policies
+// ----------------------------------------------------------------------------
+
+// ForceSecretPolicy forbids public gists. The gists to be created as public are
+// created as secret, or rejected if Reject is true.
+type ForceSecretPolicy struct {
+	Reject bool
+}
+
+// CheckCreate implements Policy.
+func (p ForceSecretPolicy) CheckCreate(args *CreateArgs) error {
+	if !args.AsPublic {
+		return nil
+	}
+
+	if p.Reject {
+		return &PolicyViolationError{Policy: "force-secret", File: "", Reason: "public gists are not allowed"}
+	}
+
+	args.AsPublic = false
+
+	return nil
+}
+
+// CheckUpdate implements Policy. Update does not change the visibility.
+func (ForceSecretPolicy) CheckUpdate(*UpdateArgs) error {
+	return nil
+}
+
+// DescriptionPolicy requires the descriptions of the new gists to match
+// Pattern. E.g. `\b[A-Z]+-\d+\b` to require a ticket ID.
+type DescriptionPolicy struct {
+	Pattern *regexp.Regexp
+}
+
+// CheckCreate implements Policy. All the descriptions are rejected if Pattern
+// is nil.
+func (p DescriptionPolicy) CheckCreate(args *CreateArgs) error {
+	if p.Pattern == nil {
+		return &PolicyViolationError{Policy: "description", File: "", Reason: "no pattern to match the description"}
+	}
+
+	if p.Pattern.MatchString(args.Description) {
+		return nil
+	}
+
+	return &PolicyViolationError{
+		Policy: "description",
+		File:   "",
+		Reason: fmt.Sprintf("description %q does not match %q", args.Description, p.Pattern),
+	}
+}
+
+// CheckUpdate implements Policy. Update does not change the description.
+func (DescriptionPolicy) CheckUpdate(*UpdateArgs) error {
+	return nil
+}
+
+// MaxFileSizePolicy rejects the files larger than MaxBytes. On Update, the
+// files in the local repository are checked.
+type MaxFileSizePolicy struct {
+	MaxBytes int64
+}
+
+// CheckCreate implements Policy.
+func (p MaxFileSizePolicy) CheckCreate(args *CreateArgs) error {
+	return checkFiles(args.FilePaths, p.check)
+}
+
+// CheckUpdate implements Policy.
+func (p MaxFileSizePolicy) CheckUpdate(args *UpdateArgs) error {
+	return checkFiles(repoFiles(args.PathDirRepo), p.check)
+}
+
+func (p MaxFileSizePolicy) check(pathFile string, info os.FileInfo) error {
+	if info == nil || info.Size() <= p.MaxBytes {
+		return nil
+	}
+
+	return &PolicyViolationError{
+		Policy: "max-file-size",
+		File:   pathFile,
+		Reason: fmt.Sprintf("%d bytes exceeds the limit of %d bytes", info.Size(), p.MaxBytes),
+	}
+}
+
+// ExtensionPolicy allows only the files with the given extensions, such as
+// ".md" and ".go". On Update, the files in the local repository are checked.
+type ExtensionPolicy struct {
+	Extensions []string
+}
+
+// CheckCreate implements Policy.
+func (p ExtensionPolicy) CheckCreate(args *CreateArgs) error {
+	return checkFiles(args.FilePaths, p.check)
+}
+
+// CheckUpdate implements Policy.
+func (p ExtensionPolicy) CheckUpdate(args *UpdateArgs) error {
+	return checkFiles(repoFiles(args.PathDirRepo), p.check)
+}
+
+func (p ExtensionPolicy) check(pathFile string, _ os.FileInfo) error {
+	if pathFile == "-" {
+		return nil // standard input has no extension.
+	}
+
+	ext := strings.ToLower(filepath.Ext(pathFile))
+
+	if slices.ContainsFunc(p.Extensions, func(allowed string) bool { return strings.EqualFold(allowed, ext) }) {
+		return nil
+	}
+
+	return &PolicyViolationError{
+		Policy: "extension",
+		File:   pathFile,
+		Reason: fmt.Sprintf("extension %q is not allowed. Allowed: %s", ext, strings.Join(p.Extensions, ", ")),
+	}
+}
+
+// checkFiles calls check with the file info of each file, nil for the standard
+// input and the missing files, and joins the errors.
+func checkFiles(filePaths []string, check func(pathFile string, info os.FileInfo) error) error {
+	errs := make([]error, 0, len(filePaths))
+
+	for _, pathFile := range filePaths {
+		var info os.FileInfo
+
+		if pathFile != "-" {
+			info, _ = os.Stat(pathFile) // missing files are reported by the command.
+		}
+
+		errs = append(errs, check(pathFile, info))
+	}
+
+	return errors.Join(errs...)
+}
+
+// repoFiles returns the paths of the files in the top directory of the gist
+// repository. Gists have no subdirectories.
+func repoFiles(dir string) []string {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	filePaths := make([]string, 0, len(entries))
+
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && !strings.HasPrefix(entry.Name(), ".") {
+			filePaths = append(filePaths, filepath.Join(dir, entry.Name()))
+		}
+	}
+
+	return filePaths
+}
+
+// ----------------------------------------------------------------------------
+//  Type: PolicyConfig
+// ----------------------------------------------------------------------------
+
+// PolicyConfig is the JSON configuration of the built-in policies. E.g.
+//
+//	{
+//	  "force_secret": true,
+//	  "description_pattern": "\\b[A-Z]+-\\d+\\b",
+//	  "max_file_size": 1048576,
+//	  "allowed_extensions": [".md", ".go"]
+//	}
+type PolicyConfig struct {
+	DescriptionPattern string   `json:"description_pattern,omitempty"`
+	AllowedExtensions  []string `json:"allowed_extensions,omitempty"`
+	MaxFileSize        int64    `json:"max_file_size,omitempty"`
+	ForceSecret        bool     `json:"force_secret,omitempty"`
+	RejectPublic       bool     `json:"reject_public,omitempty"` // rejects public gists instead of forcing them secret.
+}
+
+// LoadPolicyConfig reads the PolicyConfig in JSON from r. Unknown fields are
+// rejected to catch typos.
+func LoadPolicyConfig(r io.Reader) (PolicyConfig, error) {
+	var config PolicyConfig
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&config)
+
+	return config, WrapIfErr(err, "failed to parse policy config")
+}
+
+// Policies returns the built-in policies enabled in the config.
+func (c PolicyConfig) Policies() ([]Policy, error) {
+	policies := []Policy{}
+
+	if c.ForceSecret || c.RejectPublic {
+		policies = append(policies, ForceSecretPolicy{Reject: c.RejectPublic})
+	}
+
+	if c.DescriptionPattern != "" {
+		pattern, err := regexp.Compile(c.DescriptionPattern)
+		if err != nil {
+			return nil, WrapIfErr(err, "invalid description_pattern of policy config")
+		}
+
+		policies = append(policies, DescriptionPolicy{Pattern: pattern})
+	}
+
+	if c.MaxFileSize > 0 {
+		policies = append(policies, MaxFileSizePolicy{MaxBytes: c.MaxFileSize})
+	}
+
+	if len(c.AllowedExtensions) > 0 {
+		policies = append(policies, ExtensionPolicy{Extensions: c.AllowedExtensions})
+	}
+
+	return policies, nil
+}
@@ -0,0 +1,260 @@
+package gisty_test
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGisty_Create_policies(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"main.go": "package main", "big.md": strings.Repeat("x", 100)})
+
+	obj := srv.NewGisty()
+	obj.Policies = []gisty.Policy{
+		gisty.ForceSecretPolicy{Reject: false},
+		gisty.DescriptionPolicy{Pattern: regexp.MustCompile(`\bOPS-\d+\b`)},
+		gisty.MaxFileSizePolicy{MaxBytes: 50},
+		gisty.ExtensionPolicy{Extensions: []string{".go", ".MD"}},
+	}
+
+	// Rewritten to secret.
+	_, err := obj.Create(gisty.CreateArgs{
+		Description:  "repro for OPS-123",
+		FilePaths:    []string{filepath.Join(dir, "main.go")},
+		AsPublic:     true,
+		AllowSecrets: false,
+	})
+
+	require.NoError(t, err)
+
+	gists := srv.Gists()
+	require.Len(t, gists, 1)
+	require.False(t, gists[0].Public, "public gist should be forced to be secret")
+
+	// Rejected by all the other policies.
+	writeFiles(t, dir, map[string]string{"run.sh": "echo"})
+
+	_, err = obj.Create(gisty.CreateArgs{
+		Description:  "no ticket",
+		FilePaths:    []string{filepath.Join(dir, "big.md"), filepath.Join(dir, "run.sh")},
+		AsPublic:     false,
+		AllowSecrets: false,
+	})
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.ErrorContains(t, err, `policy violation: description: description "no ticket" does not match`)
+	require.ErrorContains(t, err, "policy violation: max-file-size: "+filepath.Join(dir, "big.md")+": 100 bytes")
+	require.ErrorContains(t, err, "policy violation: extension: "+filepath.Join(dir, "run.sh"))
+
+	var errViolation *gisty.PolicyViolationError
+
+	require.ErrorAs(t, err, &errViolation)
+	require.Equal(t, "description", errViolation.Policy)
+	require.Len(t, srv.Gists(), 1, "gist should not be created")
+}
+
+func TestForceSecretPolicy_reject(t *testing.T) {
+	t.Parallel()
+
+	args := gisty.CreateArgs{Description: "", FilePaths: nil, AsPublic: true, AllowSecrets: false}
+
+	err := gisty.ForceSecretPolicy{Reject: true}.CheckCreate(&args)
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.EqualError(t, err, "policy violation: force-secret: public gists are not allowed")
+	require.True(t, args.AsPublic, "rejected args should not be rewritten")
+}
+
+func TestDescriptionPolicy_nil_pattern(t *testing.T) {
+	t.Parallel()
+
+	args := gisty.CreateArgs{Description: "any", FilePaths: nil, AsPublic: false, AllowSecrets: false}
+
+	err := gisty.DescriptionPolicy{Pattern: nil}.CheckCreate(&args)
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation, "a policy without pattern should reject instead of panic")
+}
+
+func TestGisty_Restore_and_Migrate_policies(t *testing.T) {
+	t.Parallel()
+
+	srcSrv, _ := newBackupTestServer(t)
+
+	var archive bytes.Buffer
+
+	_, err := srcSrv.NewGisty().Backup(&archive, gisty.NewBackupOptions())
+	require.NoError(t, err)
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	obj := dstSrv.NewGisty()
+	obj.Policies = []gisty.Policy{gisty.ExtensionPolicy{Extensions: []string{".go", ".md"}}}
+
+	_, err = obj.Restore(bytes.NewReader(archive.Bytes()), gisty.NewRestoreOptions())
+
+	var errViolation *gisty.PolicyViolationError
+
+	require.ErrorAs(t, err, &errViolation)
+	require.Equal(t, "secret.txt", errViolation.File, "the violation should name the file of the gist")
+
+	_, err = srcSrv.NewGisty().Migrate(context.Background(), obj, gisty.NewMigrateOptions())
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.ErrorContains(t, err, "extension: secret.txt")
+
+	// The allowed gists are published, rewritten by the policies.
+	obj.Policies = []gisty.Policy{gisty.ForceSecretPolicy{Reject: false}}
+
+	result, err := obj.Restore(bytes.NewReader(archive.Bytes()), gisty.NewRestoreOptions())
+	require.NoError(t, err)
+
+	for _, gistID := range result.IDMap {
+		gist, _ := dstSrv.Gist(gistID)
+		require.False(t, gist.Public, "gist %s should be restored as secret", gistID)
+	}
+}
+
+func TestGisty_Sync_and_Watch_policies(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"file.md": "v1"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"file.md": "v2", "tool.exe": "binary"})
+
+	obj.Policies = []gisty.Policy{gisty.ExtensionPolicy{Extensions: []string{".md"}}}
+
+	_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, map[string]string{"file.md": "v1"}, gist.Files, "nothing should be pushed")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, done := startWatch(ctx, obj, dir)
+
+	event := waitEvent(t, events)
+
+	require.ErrorIs(t, event.Err, gisty.ErrPolicyViolation)
+
+	gist, _ = srv.Gist(syncTestGistID)
+	require.Equal(t, map[string]string{"file.md": "v1"}, gist.Files, "nothing should be published")
+
+	cancel()
+
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+// branchPolicy rewrites the arguments of Update to another branch.
+type branchPolicy struct{}
+
+func (branchPolicy) CheckCreate(*gisty.CreateArgs) error { return nil }
+
+func (branchPolicy) CheckUpdate(args *gisty.UpdateArgs) error {
+	args.Branch = "release"
+
+	return nil
+}
+
+func TestGisty_Sync_policies_rewrite(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"file.md": "v1"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"file.md": "v1", "new.md": "new"})
+
+	obj.Policies = []gisty.Policy{branchPolicy{}}
+
+	_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.ErrorIs(t, err, gisty.ErrPolicyViolation)
+	require.ErrorContains(t, err, "the policies cannot change the arguments of Sync")
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, map[string]string{"file.md": "v1"}, gist.Files, "nothing should be pushed")
+}
+
+func TestGisty_Update_policies(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{"notes.md": "ok", "tool.exe": "binary", ".hidden": "ignored"})
+
+	obj := gisty.NewGisty()
+	obj.Policies = []gisty.Policy{
+		gisty.ForceSecretPolicy{Reject: true},
+		gisty.DescriptionPolicy{Pattern: regexp.MustCompile(`.+`)},
+		gisty.ExtensionPolicy{Extensions: []string{".md"}},
+	}
+
+	_, err := obj.Update(gisty.NewUpdateArgs(dir))
+
+	var errViolation *gisty.PolicyViolationError
+
+	require.ErrorAs(t, err, &errViolation)
+	require.Equal(t, "extension", errViolation.Policy)
+	require.Equal(t, filepath.Join(dir, "tool.exe"), errViolation.File)
+}
+
+func TestLoadPolicyConfig(t *testing.T) {
+	t.Parallel()
+
+	config, err := gisty.LoadPolicyConfig(strings.NewReader(`{
+		"force_secret": true,
+		"description_pattern": "\\bOPS-\\d+\\b",
+		"max_file_size": 1024,
+		"allowed_extensions": [".md"]
+	}`))
+
+	require.NoError(t, err)
+
+	policies, err := config.Policies()
+
+	require.NoError(t, err)
+	require.Len(t, policies, 4)
+	require.Equal(t, gisty.ForceSecretPolicy{Reject: false}, policies[0])
+	require.Equal(t, gisty.MaxFileSizePolicy{MaxBytes: 1024}, policies[2])
+
+	_, err = gisty.LoadPolicyConfig(strings.NewReader(`{"force_secrets": true}`))
+
+	require.ErrorContains(t, err, "failed to parse policy config")
+
+	_, err = gisty.PolicyConfig{DescriptionPattern: "("}.Policies() //nolint:exhaustruct // only the field under test
+
+	require.ErrorContains(t, err, "invalid description_pattern")
+}
+
+func TestPolicies_missing_file_and_stdin(t *testing.T) {
+	t.Parallel()
+
+	args := gisty.CreateArgs{
+		Description:  "",
+		FilePaths:    []string{filepath.Join(t.TempDir(), "missing.txt"), "-"},
+		AsPublic:     false,
+		AllowSecrets: false,
+	}
+
+	require.NoError(t, gisty.MaxFileSizePolicy{MaxBytes: 1}.CheckCreate(&args),
+		"missing files and stdin should be left to the command")
+	require.NoError(t, gisty.ExtensionPolicy{Extensions: []string{".md"}}.CheckCreate(&gisty.CreateArgs{
+		Description: "", FilePaths: []string{"-"}, AsPublic: false, AllowSecrets: false,
+	}))
+}
@@ -2,6 +2,8 @@ package gisty
 
 import (
 	"context"
+	"io"
+	"os"
 	"os/exec"
 
 	"github.com/KEINOS/go-gisty/internal/ghcmd"
@@ -11,9 +13,106 @@ const commandGist = "gist"
 
 var execCommandContext = exec.CommandContext
 
+// ----------------------------------------------------------------------------
+//  Type: GHCommand, GHRunner
+// ----------------------------------------------------------------------------
+
+// GHCommand describes an execution of the gh command.
+type GHCommand struct {
+	// Stdin is the standard input of the command.
+	Stdin io.Reader
+	// Stdout is the standard output of the command.
+	Stdout io.Writer
+	// Stderr is the standard error of the command.
+	Stderr io.Writer
+	// Dir is the working directory of the command. If empty, the current
+	// directory of the process is used.
+	Dir string
+	// Args are the arguments to the gh command. E.g. ["gist", "list"].
+	Args []string
+	// Env are the additional environment variables of the command in the
+	// "key=value" form. E.g. ["GH_HOST=ghe.example.com"].
+	Env []string
+}
+
+// GHRunner executes the gh command. It is the extension point to intercept the
+// gh command executions, such as recording and replaying them in tests.
+type GHRunner func(ctx context.Context, cmd GHCommand) error
+
+// DefaultGHRunner executes the gh command installed in PATH.
+func DefaultGHRunner(ctx context.Context, cmd GHCommand) error {
+	executor := execCommandContext
+
+	if len(cmd.Env) > 0 || cmd.Dir != "" {
+		executor = func(ctx context.Context, name string, args ...string) *exec.Cmd {
+			command := execCommandContext(ctx, name, args...)
+
+			if cmd.Dir != "" {
+				command.Dir = cmd.Dir
+			}
+
+			if len(cmd.Env) > 0 {
+				if command.Env == nil {
+					command.Env = os.Environ()
+				}
+
+				command.Env = append(command.Env, cmd.Env...)
+			}
+
+			return command
+		}
+	}
+
+	//nolint:wrapcheck // the caller wraps the error
+	return ghcmd.Run(ctx, executor, ghcmd.Streams{
+		Stdin:  cmd.Stdin,
+		Stdout: cmd.Stdout,
+		Stderr: cmd.Stderr,
+	}, cmd.Args...)
+}
+
 func (g *Gisty) runGH(args ...string) error {
+	return g.runGHIn("", args...)
+}
+
+// runGHIn runs the gh command in dir. If dir is empty, the current directory
+// of the process is used.
+func (g *Gisty) runGHIn(dir string, args ...string) error {
+	runner := g.GHRunner
+	if runner == nil {
+		runner = DefaultGHRunner
+	}
+
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	return WrapIfErr(
-		ghcmd.Run(context.Background(), execCommandContext, g.streams(), args...),
+		runner(ctx, GHCommand{
+			Stdin:  g.Stdin,
+			Stdout: g.Stdout,
+			Stderr: g.Stderr,
+			Dir:    dir,
+			Args:   args,
+			Env:    g.ghEnv(),
+		}),
 		"failed to execute gh command",
 	)
 }
+
+// ghEnv returns the environment variables of the gh command for the settings
+// of g.
+func (g *Gisty) ghEnv() []string {
+	var env []string
+
+	if g.Host != "" {
+		env = append(env, "GH_HOST="+g.Host)
+	}
+
+	if g.token != "" {
+		env = append(env, "GH_TOKEN="+g.token, "GH_ENTERPRISE_TOKEN="+g.token)
+	}
+
+	return env
+}
@@ -0,0 +1,112 @@
+package gisty
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"strings"
+)
+
+// ----------------------------------------------------------------------------
+//  Type: GitCommand, GitRunner
+// ----------------------------------------------------------------------------
+
+// GitCommand describes an execution of the git command.
+type GitCommand struct {
+	// Stdin is the standard input of the command.
+	Stdin io.Reader
+	// Stdout is the standard output of the command.
+	Stdout io.Writer
+	// Stderr is the standard error of the command.
+	Stderr io.Writer
+	// Dir is the working directory of the command. If empty, the current
+	// directory of the process is used.
+	Dir string
+	// Args are the arguments to the git command. E.g. ["push", "origin"].
+	Args []string
+	// Env are the additional environment variables of the command in the
+	// "key=value" form.
+	Env []string
+}
+
+// GitRunner executes the git command. It is the extension point to intercept
+// the git command executions, such as stubbing them in tests.
+type GitRunner func(ctx context.Context, cmd GitCommand) error
+
+// DefaultGitRunner executes the git command installed in PATH.
+func DefaultGitRunner(ctx context.Context, cmd GitCommand) error {
+	command := execCommandContext(ctx, "git", cmd.Args...)
+
+	command.Dir = cmd.Dir
+	command.Stdin = cmd.Stdin
+	command.Stdout = cmd.Stdout
+	command.Stderr = cmd.Stderr
+
+	if len(cmd.Env) > 0 {
+		if command.Env == nil {
+			command.Env = os.Environ()
+		}
+
+		command.Env = append(command.Env, cmd.Env...)
+	}
+
+	return WrapIfErr(command.Run(), "failed to run git %s", strings.Join(cmd.Args, " "))
+}
+
+// runGit runs the git command in dir and returns its standard output with the
+// surrounding spaces trimmed. The standard error is included in the error.
+func (g *Gisty) runGit(dir string, args ...string) (string, error) {
+	out, err := g.runGitOutput(dir, args...)
+	if err != nil {
+		return "", err
+	}
+
+	return out, nil
+}
+
+// runGitOutput is like runGit but returns the standard output on error too, for
+// the commands reporting the failure details there, such as "git push
+// --porcelain".
+func (g *Gisty) runGitOutput(dir string, args ...string) (string, error) {
+	runner := g.GitRunner
+	if runner == nil {
+		runner = DefaultGitRunner
+	}
+
+	ctx := g.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	err := runner(ctx, GitCommand{
+		Stdin:  nil,
+		Stdout: &stdout,
+		Stderr: &stderr,
+		Dir:    dir,
+		Args:   args,
+		Env:    g.ghEnv(),
+	})
+	out := strings.TrimSpace(stdout.String())
+
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return out, WrapIfErr(err, msg)
+		}
+
+		return out, err
+	}
+
+	return out, nil
+}
+
+// gitAuthArgs returns the git options to authenticate the requests to the
+// GitHub hosts with gh, as "gh gist clone" does.
+func gitAuthArgs() []string {
+	return []string{
+		"-c", "credential.helper=",
+		"-c", "credential.helper=!gh auth git-credential",
+	}
+}
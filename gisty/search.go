@@ -0,0 +1,412 @@
+package gisty
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SearchIndexVersion is the version of the format of the search index file.
+const SearchIndexVersion = 1
+
+// Default values of SearchOptions.
+const (
+	SearchListLimitDefault  = 1000
+	SearchMaxSnippetDefault = 3
+)
+
+// searchSnippetWidth is the maximum number of characters of a snippet.
+const searchSnippetWidth = 120
+
+// ----------------------------------------------------------------------------
+//  Type: SearchOptions, SearchResult, SearchMatch
+// ----------------------------------------------------------------------------
+
+// SearchOptions are the options for the Search function.
+type SearchOptions struct {
+	// IndexPath is the path to the JSON file of the local index. If empty, the
+	// index is built in memory and discarded after the search.
+	IndexPath string
+	// Filename is a glob pattern, in path.Match syntax, to filter the files by
+	// name. E.g. "*.go". Only the matching files are searched.
+	Filename string
+	// Language filters the files by the language detected by GitHub, case
+	// insensitively. E.g. "Go".
+	Language string
+	// Limit is the maximum number of results. If less than 1, all the results
+	// are returned.
+	Limit int
+	// ListLimit is the maximum number of recent gists to index. If less than 1,
+	// SearchListLimitDefault is used.
+	ListLimit int
+	// MaxSnippets is the maximum number of matching lines returned per gist.
+	// If less than 1, SearchMaxSnippetDefault is used.
+	MaxSnippets int
+	// Offline searches the existing index without refreshing it.
+	Offline bool
+}
+
+// NewSearchOptions returns a new SearchOptions with the default values.
+func NewSearchOptions() SearchOptions {
+	return SearchOptions{
+		IndexPath:   "",
+		Filename:    "",
+		Language:    "",
+		Limit:       0,
+		ListLimit:   SearchListLimitDefault,
+		MaxSnippets: SearchMaxSnippetDefault,
+		Offline:     false,
+	}
+}
+
+// SearchResult is a gist matching the search query.
+type SearchResult struct {
+	// Matches are the matching lines of the gist files, at most
+	// SearchOptions.MaxSnippets.
+	Matches []SearchMatch `json:"matches"`
+	GistInfo
+	// Score is the relevance of the gist. The higher, the more relevant.
+	Score int `json:"score"`
+}
+
+// SearchMatch is a line of a gist file matching the search query.
+type SearchMatch struct {
+	File string `json:"file"`
+	Text string `json:"text"` // the line, shortened around the first match.
+	Line int    `json:"line"` // 1-based line number.
+}
+
+// ----------------------------------------------------------------------------
+//  Type: SearchIndex
+// ----------------------------------------------------------------------------
+
+// SearchIndex is the local index of the gists used by Search. It is stored as
+// JSON in SearchOptions.IndexPath.
+type SearchIndex struct {
+	RefreshedAt time.Time              `json:"refreshed_at"`
+	Gists       map[string]IndexedGist `json:"gists"`
+	Version     int                    `json:"version"`
+}
+
+// IndexedGist is a gist in the search index.
+type IndexedGist struct {
+	Files []IndexedFile `json:"files"`
+	GistInfo
+}
+
+// IndexedFile is a file of a gist in the search index. The content of the
+// files larger than 1 MB is truncated by GitHub.
+type IndexedFile struct {
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Search
+// ----------------------------------------------------------------------------
+
+// Search searches the files, the file names and the descriptions of the gists
+// of the viewer, including the secret ones.
+//
+// Since GitHub has no search API for the gist contents, a local index of the
+// gists is built with List and Read. If opts.IndexPath is set, the index is
+// stored there and only the gists updated since the previous search are read
+// again.
+//
+// The query is split into terms by spaces and a gist matches if all the terms
+// are found, case insensitively. An empty query matches all the gists with
+// the files matching opts.Filename and opts.Language. The results are sorted
+// by score and then by the update time, newest first.
+func (g *Gisty) Search(query string, opts SearchOptions) ([]SearchResult, error) {
+	index, err := readSearchIndex(opts.IndexPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.Offline {
+		err = g.refreshSearchIndex(&index, opts.ListLimit)
+		if err != nil {
+			return nil, err
+		}
+
+		err = writeSearchIndex(opts.IndexPath, index)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return searchIndex(index, query, opts), nil
+}
+
+// refreshSearchIndex updates the index with the listed gists. The gists whose
+// update time is unchanged are kept and the unlisted ones are removed.
+func (g *Gisty) refreshSearchIndex(index *SearchIndex, listLimit int) error {
+	if listLimit < 1 {
+		listLimit = SearchListLimitDefault
+	}
+
+	// The listed gists are parsed from the Stdout, which is shared by the calls.
+	g.Stdout.Reset()
+
+	infos, err := g.List(ListArgs{Limit: listLimit, OnlyPublic: false, OnlySecret: false})
+	if err != nil {
+		return WrapIfErr(err, "failed to list gists to index")
+	}
+
+	refreshed := make(map[string]IndexedGist, len(infos))
+	outdated := []string{}
+	infoOf := map[string]GistInfo{}
+
+	for _, info := range infos {
+		indexed, ok := index.Gists[info.GistID]
+		if ok && indexed.UpdatedAt.Equal(info.UpdatedAt) {
+			refreshed[info.GistID] = indexed
+
+			continue
+		}
+
+		outdated = append(outdated, info.GistID)
+		infoOf[info.GistID] = info
+	}
+
+	for _, result := range g.ReadMany(context.Background(), outdated, NewBatchOptions()) {
+		if result.Err != nil {
+			return WrapIfErr(result.Err, "failed to read gist to index: %s", result.GistID)
+		}
+
+		indexed := IndexedGist{Files: []IndexedFile{}, GistInfo: infoOf[result.GistID]}
+
+		for _, name := range sortedKeys(result.Value.Files) {
+			file := result.Value.Files[name]
+
+			indexed.Files = append(indexed.Files, IndexedFile{
+				Name:     name,
+				Language: file.Language,
+				Content:  file.Content,
+			})
+		}
+
+		refreshed[result.GistID] = indexed
+	}
+
+	index.Gists = refreshed
+	index.RefreshedAt = time.Now().UTC()
+
+	return nil
+}
+
+// searchIndex returns the ranked gists of the index matching the query.
+func searchIndex(index SearchIndex, query string, opts SearchOptions) []SearchResult {
+	terms := strings.Fields(strings.ToLower(query))
+	maxSnippets := cmp.Or(max(opts.MaxSnippets, 0), SearchMaxSnippetDefault)
+	results := []SearchResult{}
+
+	for _, indexed := range index.Gists {
+		result, ok := searchGist(indexed, terms, opts, maxSnippets)
+		if ok {
+			results = append(results, result)
+		}
+	}
+
+	slices.SortFunc(results, func(a, b SearchResult) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			b.UpdatedAt.Compare(a.UpdatedAt),
+			cmp.Compare(a.GistID, b.GistID),
+		)
+	})
+
+	if opts.Limit > 0 && len(results) > opts.Limit {
+		results = results[:opts.Limit]
+	}
+
+	return results
+}
+
+// searchGist scores the gist against the terms. The description counts 3 per
+// term, a file name 2 and each matching line 1.
+func searchGist(indexed IndexedGist, terms []string, opts SearchOptions, maxSnippets int) (SearchResult, bool) {
+	result := SearchResult{Matches: []SearchMatch{}, GistInfo: indexed.GistInfo, Score: 0}
+	found := make([]bool, len(terms))
+	description := strings.ToLower(indexed.Description)
+	hasFile := false
+
+	for index, term := range terms {
+		if strings.Contains(description, term) {
+			found[index] = true
+			result.Score += 3
+		}
+	}
+
+	for _, file := range indexed.Files {
+		if !matchFileFilter(file, opts) {
+			continue
+		}
+
+		hasFile = true
+		name := strings.ToLower(file.Name)
+
+		for index, term := range terms {
+			if strings.Contains(name, term) {
+				found[index] = true
+				result.Score += 2
+			}
+		}
+
+		for lineNum, line := range strings.Split(file.Content, "\n") {
+			column := firstTermIndex(strings.ToLower(line), terms, found)
+			if column < 0 {
+				continue
+			}
+
+			result.Score++
+
+			if len(result.Matches) < maxSnippets {
+				result.Matches = append(result.Matches, SearchMatch{
+					File: file.Name,
+					Text: snippet(line, column),
+					Line: lineNum + 1,
+				})
+			}
+		}
+	}
+
+	if !hasFile || slices.Contains(found, false) {
+		return result, false
+	}
+
+	return result, true
+}
+
+// matchFileFilter returns true if the file matches the filename and language
+// filters of opts.
+func matchFileFilter(file IndexedFile, opts SearchOptions) bool {
+	if opts.Filename != "" {
+		ok, err := path.Match(opts.Filename, file.Name)
+		if err != nil || !ok {
+			return false
+		}
+	}
+
+	return opts.Language == "" || strings.EqualFold(opts.Language, file.Language)
+}
+
+// firstTermIndex returns the byte index of the first term found in the lower
+// cased line, or -1. The found terms are marked in found.
+func firstTermIndex(line string, terms []string, found []bool) int {
+	first := -1
+
+	for index, term := range terms {
+		column := strings.Index(line, term)
+		if column < 0 {
+			continue
+		}
+
+		found[index] = true
+
+		if first < 0 || column < first {
+			first = column
+		}
+	}
+
+	return first
+}
+
+// snippet returns the trimmed line shortened to searchSnippetWidth characters
+// around the byte index column.
+func snippet(line string, column int) string {
+	if utf8.RuneCountInString(line) <= searchSnippetWidth {
+		return strings.TrimSpace(line)
+	}
+
+	// Lower casing may change the byte length of the line.
+	column = min(column, len(line))
+
+	start := max(utf8.RuneCountInString(line[:column])-searchSnippetWidth/4, 0)
+	runes := []rune(line)
+	end := min(start+searchSnippetWidth, len(runes))
+	text := string(runes[start:end])
+
+	if start > 0 {
+		text = "..." + text
+	}
+
+	if end < len(runes) {
+		text += "..."
+	}
+
+	return strings.TrimSpace(text)
+}
+
+// ----------------------------------------------------------------------------
+//  Index file
+// ----------------------------------------------------------------------------
+
+// readSearchIndex reads the index file. An empty index is returned if pathFile
+// is empty or does not exist, or if the index is of another version.
+func readSearchIndex(pathFile string) (SearchIndex, error) {
+	index := SearchIndex{RefreshedAt: time.Time{}, Gists: map[string]IndexedGist{}, Version: SearchIndexVersion}
+
+	if pathFile == "" {
+		return index, nil
+	}
+
+	data, err := os.ReadFile(pathFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return index, nil
+	}
+
+	if err != nil {
+		return index, WrapIfErr(err, "failed to read search index")
+	}
+
+	var stored SearchIndex
+
+	err = json.Unmarshal(data, &stored)
+	if err != nil {
+		return index, WrapIfErr(err, "failed to parse search index: %s", pathFile)
+	}
+
+	if stored.Version != SearchIndexVersion || stored.Gists == nil {
+		return index, nil
+	}
+
+	return stored, nil
+}
+
+// writeSearchIndex writes the index to pathFile through a temporary file, so
+// that an interruption does not leave a broken index.
+func writeSearchIndex(pathFile string, index SearchIndex) error {
+	if pathFile == "" {
+		return nil
+	}
+
+	data, err := json.Marshal(index)
+	if err != nil {
+		return WrapIfErr(err, "failed to encode search index")
+	}
+
+	err = os.MkdirAll(filepath.Dir(pathFile), 0o700)
+	if err != nil {
+		return WrapIfErr(err, "failed to create directory of search index")
+	}
+
+	pathTemp := filepath.Join(filepath.Dir(pathFile), "."+filepath.Base(pathFile)+".tmp")
+
+	err = os.WriteFile(pathTemp, data, 0o600)
+	if err != nil {
+		return WrapIfErr(err, "failed to write search index")
+	}
+
+	return WrapIfErr(os.Rename(pathTemp, pathFile), "failed to write search index")
+}
@@ -0,0 +1,203 @@
+package gisty_test
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+// newSearchTestServer returns a fake server with three gists to search and
+// their IDs.
+func newSearchTestServer(t *testing.T) (*gistytest.Server, []string) {
+	t.Helper()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistIDs := []string{
+		srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			UpdatedAt:   time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			Description: "retry helper",
+			Files: map[string]string{
+				"retry.go":  "package retry\n\n// Backoff waits with exponential backoff.\nfunc Backoff() {}\n",
+				"README.md": "# Retry\n",
+			},
+		}),
+		srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			UpdatedAt:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			Description: "shell snippets",
+			Files:       map[string]string{"backoff.sh": "#!/bin/sh\n# naive backoff\nsleep 1\n"},
+			Public:      true,
+		}),
+		srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+			UpdatedAt:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			Description: "unrelated",
+			Files:       map[string]string{"notes.txt": "nothing here\n"},
+		}),
+	}
+
+	return srv, gistIDs
+}
+
+func resultIDs(results []gisty.SearchResult) []string {
+	ids := make([]string, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.GistID)
+	}
+
+	return ids
+}
+
+func TestGisty_Search(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newSearchTestServer(t)
+	obj := srv.NewGisty()
+
+	results, err := obj.Search("Backoff", gisty.NewSearchOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[1], gistIDs[0]}, resultIDs(results), "file name match should rank first")
+	require.Equal(t, 3, results[0].Score)
+	require.Equal(t, []gisty.SearchMatch{
+		{File: "retry.go", Text: "// Backoff waits with exponential backoff.", Line: 3},
+		{File: "retry.go", Text: "func Backoff() {}", Line: 4},
+	}, results[1].Matches)
+	require.Equal(t, "retry helper", results[1].Description)
+	require.Equal(t, 2, results[1].Files)
+
+	// All the terms must match, in the description, the file names or the contents.
+	results, err = obj.Search("retry exponential", gisty.NewSearchOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[0]}, resultIDs(results))
+
+	results, err = obj.Search("backoff missing", gisty.NewSearchOptions())
+
+	require.NoError(t, err)
+	require.Empty(t, results)
+}
+
+func TestGisty_Search_filters(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newSearchTestServer(t)
+	obj := srv.NewGisty()
+
+	opts := gisty.NewSearchOptions()
+	opts.Filename = "*.sh"
+
+	results, err := obj.Search("backoff", opts)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[1]}, resultIDs(results))
+
+	opts = gisty.NewSearchOptions()
+	opts.Language = "go"
+
+	results, err = obj.Search("", opts)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[0]}, resultIDs(results), "empty query should match the filtered files")
+
+	opts = gisty.NewSearchOptions()
+	opts.Limit = 1
+
+	results, err = obj.Search("", opts)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[2]}, resultIDs(results), "newest gist should rank first on the same score")
+}
+
+func TestGisty_Search_incremental_index(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newSearchTestServer(t)
+	obj := srv.NewGisty()
+
+	opts := gisty.NewSearchOptions()
+	opts.IndexPath = filepath.Join(t.TempDir(), "cache", "index.json")
+
+	_, err := obj.Search("backoff", opts)
+
+	require.NoError(t, err)
+	require.FileExists(t, opts.IndexPath)
+
+	// Only the updated gist is read again and the deleted one is dropped.
+	srv.ResetRequests()
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		UpdatedAt:   time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+		ID:          gistIDs[2],
+		Description: "unrelated",
+		Files:       map[string]string{"notes.txt": "now with backoff\n"},
+	})
+	require.NoError(t, obj.Delete(gistIDs[1]))
+
+	results, err := obj.Search("backoff", opts)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{gistIDs[0], gistIDs[2]}, resultIDs(results))
+	require.Equal(t, 1, srv.Requested(http.MethodGet, "/gists/"+gistIDs[2]))
+	srv.AssertNotRequested(t, http.MethodGet, "/gists/"+gistIDs[0])
+
+	// Offline search does not request the server.
+	srv.ResetRequests()
+
+	opts.Offline = true
+
+	results, err = obj.Search("backoff", opts)
+
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+	require.Empty(t, srv.Requests())
+}
+
+func TestGisty_Search_errors(t *testing.T) {
+	t.Parallel()
+
+	srv, gistIDs := newSearchTestServer(t)
+	obj := srv.NewGisty()
+
+	srv.FailNext(http.MethodGet, "/gists/"+gistIDs[0], http.StatusInternalServerError, nil)
+
+	_, err := obj.Search("backoff", gisty.NewSearchOptions())
+
+	require.ErrorContains(t, err, "failed to read gist to index: "+gistIDs[0])
+
+	opts := gisty.NewSearchOptions()
+	opts.IndexPath = filepath.Join(t.TempDir(), "index.json")
+
+	require.NoError(t, os.WriteFile(opts.IndexPath, []byte("{"), 0o600))
+
+	_, err = obj.Search("backoff", opts)
+
+	require.ErrorContains(t, err, "failed to parse search index")
+}
+
+func TestGisty_Search_long_line_snippet(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"long.txt": strings.Repeat("a", 200) + " needle " + strings.Repeat("b", 200)},
+	})
+
+	results, err := srv.NewGisty().Search("needle", gisty.NewSearchOptions())
+
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+
+	text := results[0].Matches[0].Text
+
+	require.Contains(t, text, "needle")
+	require.True(t, strings.HasPrefix(text, "...") && strings.HasSuffix(text, "..."), text)
+}
@@ -0,0 +1,227 @@
+package gisty
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// Defaults of the high-entropy string detection of SecretScanner.
+const (
+	SecretEntropyThresholdDefault = 4.5
+	SecretEntropyMinLengthDefault = 20
+)
+
+// ----------------------------------------------------------------------------
+//  Type: SecretScanner
+// ----------------------------------------------------------------------------
+
+// SecretRule is a rule of SecretScanner. A line matching Pattern is reported
+// as a finding of the rule.
+type SecretRule struct {
+	Pattern *regexp.Regexp
+	Name    string
+}
+
+// SecretFinding is a possible secret found by SecretScanner.
+type SecretFinding struct {
+	File  string `json:"file"`
+	Rule  string `json:"rule"`
+	Match string `json:"match"` // matched text with the most of it masked.
+	Line  int    `json:"line"`  // 1-based line number.
+}
+
+// String returns the finding in the "<file>:<line>: <rule> (<match>)" form.
+func (f SecretFinding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", f.File, f.Line, f.Rule, f.Match)
+}
+
+// SecretScanner scans the files to be published for credentials such as API
+// keys, tokens and private keys.
+//
+// Set it to Gisty.SecretScanner to scan the files before Create, Push, Sync,
+// Watch, Restore, Migrate and SetVisibility publish them. They return a *SecretsError if
+// anything is found, unless the AllowSecrets field of their arguments is set.
+type SecretScanner struct {
+	// Rules are the rules to detect the secrets. NewSecretScanner sets the
+	// built-in rules.
+	Rules []SecretRule
+	// EntropyThreshold is the Shannon entropy in bits per character above which
+	// a token-like string with both letters and digits is reported as
+	// "high-entropy-string". Zero disables the detection.
+	EntropyThreshold float64
+	// EntropyMinLength is the minimum length of the token-like strings to check
+	// their entropy.
+	EntropyMinLength int
+}
+
+// NewSecretScanner returns a new SecretScanner with the built-in rules for AWS
+// keys, GitHub tokens and private keys, and the high-entropy string detection.
+func NewSecretScanner() *SecretScanner {
+	return &SecretScanner{
+		Rules: []SecretRule{
+			{
+				Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+				Name:    "aws-access-key-id",
+			},
+			{
+				Pattern: regexp.MustCompile(`(?i)aws_?secret_?access_?key\W{1,4}[A-Za-z0-9/+]{40}\b`),
+				Name:    "aws-secret-access-key",
+			},
+			{
+				Pattern: regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36}|github_pat_[A-Za-z0-9_]{82})\b`),
+				Name:    "github-token",
+			},
+			{
+				Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )*PRIVATE KEY( BLOCK)?-----`),
+				Name:    "private-key",
+			},
+		},
+		EntropyThreshold: SecretEntropyThresholdDefault,
+		EntropyMinLength: SecretEntropyMinLengthDefault,
+	}
+}
+
+// AddRule adds a custom rule with the regular expression pattern.
+func (s *SecretScanner) AddRule(name, pattern string) error {
+	compiled, err := regexp.Compile(pattern)
+	if err != nil {
+		return WrapIfErr(err, "invalid pattern of secret rule: %s", name)
+	}
+
+	s.Rules = append(s.Rules, SecretRule{Pattern: compiled, Name: name})
+
+	return nil
+}
+
+// tokenPattern matches the token-like strings to check their entropy.
+var tokenPattern = regexp.MustCompile(`[A-Za-z0-9+/=_\-]+`)
+
+// Scan returns the possible secrets in the content of the file, in the order of
+// the lines.
+func (s *SecretScanner) Scan(file string, content []byte) []SecretFinding {
+	findings := []SecretFinding{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	scanner.Buffer(nil, len(content)+1)
+
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		found := false
+
+		for _, rule := range s.Rules {
+			if match := rule.Pattern.FindString(line); match != "" {
+				findings = append(findings, SecretFinding{File: file, Rule: rule.Name, Match: maskSecret(match), Line: lineNum})
+				found = true
+			}
+		}
+
+		if found || s.EntropyThreshold <= 0 {
+			continue
+		}
+
+		for _, token := range tokenPattern.FindAllString(line, -1) {
+			if len(token) >= s.EntropyMinLength && hasLetterAndDigit(token) && shannonEntropy(token) > s.EntropyThreshold {
+				findings = append(findings, SecretFinding{
+					File: file, Rule: "high-entropy-string", Match: maskSecret(token), Line: lineNum,
+				})
+
+				break
+			}
+		}
+	}
+
+	return findings
+}
+
+// scanFiles scans the files given as names to contents in the order of the
+// names.
+func (s *SecretScanner) scanFiles(files map[string][]byte) []SecretFinding {
+	findings := []SecretFinding{}
+
+	for _, name := range sortedKeys(files) {
+		findings = append(findings, s.Scan(name, files[name])...)
+	}
+
+	return findings
+}
+
+// checkSecrets returns a *SecretsError if g.SecretScanner finds secrets in the
+// files. It returns nil if the scanner is not set or allowed is true.
+func (g *Gisty) checkSecrets(files map[string][]byte, allowed bool) error {
+	if g.SecretScanner == nil || allowed {
+		return nil
+	}
+
+	if findings := g.SecretScanner.scanFiles(files); len(findings) > 0 {
+		return &SecretsError{Findings: findings}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: SecretsError
+// ----------------------------------------------------------------------------
+
+// SecretsError is returned when the files to be published contain possible
+// secrets. It wraps ErrSecretFound.
+type SecretsError struct {
+	Findings []SecretFinding
+}
+
+// Error implements the error interface. It lists the findings.
+func (e *SecretsError) Error() string {
+	lines := make([]string, 0, len(e.Findings))
+	for _, finding := range e.Findings {
+		lines = append(lines, finding.String())
+	}
+
+	return fmt.Sprintf("%s: %d possible secret(s). Remove them or allow explicitly to publish:\n%s",
+		ErrSecretFound, len(e.Findings), strings.Join(lines, "\n"))
+}
+
+// Unwrap returns ErrSecretFound.
+func (e *SecretsError) Unwrap() error {
+	return ErrSecretFound
+}
+
+// ----------------------------------------------------------------------------
+//  Helpers
+// ----------------------------------------------------------------------------
+
+// maskSecret returns the secret with all but the first 4 characters masked.
+func maskSecret(secret string) string {
+	const visible = 4
+
+	if len(secret) <= visible {
+		return strings.Repeat("*", len(secret))
+	}
+
+	return secret[:visible] + strings.Repeat("*", min(len(secret)-visible, 8)) //nolint:mnd // max mask length
+}
+
+// shannonEntropy returns the Shannon entropy of the string in bits per byte.
+func shannonEntropy(text string) float64 {
+	counts := map[byte]int{}
+	for index := range len(text) {
+		counts[text[index]]++
+	}
+
+	entropy := 0.0
+
+	for _, count := range counts {
+		freq := float64(count) / float64(len(text))
+		entropy -= freq * math.Log2(freq)
+	}
+
+	return entropy
+}
+
+func hasLetterAndDigit(text string) bool {
+	return strings.ContainsAny(text, "0123456789") &&
+		strings.ContainsFunc(text, func(r rune) bool { return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') })
+}
@@ -0,0 +1,326 @@
+package gisty_test
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+// Fake credentials built at runtime, so that the source itself is not flagged.
+var (
+	fakeAWSKeyID   = "AKIA" + "IOSFODNN7EXAMPLE"
+	fakeGitHubPAT  = "ghp_" + strings.Repeat("a1B2", 9)
+	fakePrivateKey = "-----BEGIN RSA " + "PRIVATE KEY-----"
+)
+
+func TestSecretScanner_Scan(t *testing.T) {
+	t.Parallel()
+
+	scanner := gisty.NewSecretScanner()
+
+	require.NoError(t, scanner.AddRule("slack-webhook", `hooks\.slack\.com/services/\S+`))
+
+	content := strings.Join([]string{
+		"# config",
+		"aws_access_key_id = " + fakeAWSKeyID,
+		"token: " + fakeGitHubPAT,
+		fakePrivateKey,
+		"url: https://hooks.slack.com/services/T000/B000/XXXX",
+		"secret = wJalrXUtnFEMI/K7MDENG/bPxRfiCYEXAMPLEKEY",
+		"import github.com/KEINOS/go-gisty/gisty/internal/gistapi",
+		"alphabet: abcdefghijklmnopqrstuvwxyz",
+	}, "\n")
+
+	findings := scanner.Scan("config.yml", []byte(content))
+
+	require.Equal(t, []gisty.SecretFinding{
+		{File: "config.yml", Rule: "aws-access-key-id", Match: "AKIA********", Line: 2},
+		{File: "config.yml", Rule: "github-token", Match: "ghp_********", Line: 3},
+		{File: "config.yml", Rule: "private-key", Match: "----********", Line: 4},
+		{File: "config.yml", Rule: "slack-webhook", Match: "hook********", Line: 5},
+		{File: "config.yml", Rule: "high-entropy-string", Match: "wJal********", Line: 6},
+	}, findings)
+	require.Equal(t, "config.yml:2: aws-access-key-id (AKIA********)", findings[0].String())
+
+	scanner.EntropyThreshold = 0
+
+	require.Len(t, scanner.Scan("config.yml", []byte(content)), 4, "entropy check should be disabled")
+	require.Empty(t, scanner.Scan("empty.txt", nil))
+}
+
+func TestSecretScanner_AddRule_invalid(t *testing.T) {
+	t.Parallel()
+
+	err := gisty.NewSecretScanner().AddRule("broken", `(`)
+
+	require.ErrorContains(t, err, "invalid pattern of secret rule: broken")
+}
+
+func TestGisty_Create_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	pathFile := filepath.Join(t.TempDir(), "creds.env")
+	require.NoError(t, os.WriteFile(pathFile, []byte("\nGH_TOKEN="+fakeGitHubPAT+"\n"), 0o600))
+
+	obj := srv.NewGisty()
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	args := gisty.CreateArgs{Description: "", FilePaths: []string{pathFile}, AsPublic: false, AllowSecrets: false}
+
+	_, err := obj.Create(args)
+
+	var errSecrets *gisty.SecretsError
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorAs(t, err, &errSecrets)
+	require.Equal(t, pathFile, errSecrets.Findings[0].File)
+	require.Equal(t, 2, errSecrets.Findings[0].Line)
+	require.ErrorContains(t, err, pathFile+":2: github-token")
+	require.Empty(t, srv.Gists(), "gist should not be created")
+
+	// Explicit override.
+	args.AllowSecrets = true
+
+	_, err = srv.NewGisty().Create(args)
+
+	require.NoError(t, err)
+	require.Len(t, srv.Gists(), 1)
+}
+
+func TestGisty_Sync_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"remote.txt": "remote"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"key.pem": fakePrivateKey + "\n", "notes.txt": "safe"})
+
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	_, err := obj.Sync(dir, syncTestGistID, gisty.NewSyncOptions())
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, "key.pem:1: private-key")
+	require.NoFileExists(t, filepath.Join(dir, "remote.txt"), "nothing should be synced")
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.NotContains(t, gist.Files, "notes.txt")
+}
+
+func TestGisty_Watch_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srv, obj := newSyncTestServer(t, map[string]string{"file.txt": "v1"})
+	dir := t.TempDir()
+
+	writeFiles(t, dir, map[string]string{"file.txt": "key: " + fakeAWSKeyID})
+
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	events, done := startWatch(ctx, obj, dir)
+
+	event := waitEvent(t, events)
+
+	require.ErrorIs(t, event.Err, gisty.ErrSecretFound)
+
+	gist, _ := srv.Gist(syncTestGistID)
+	require.Equal(t, "v1", gist.Files["file.txt"], "secret should not be published")
+
+	// Publishes once the secret is removed.
+	writeFiles(t, dir, map[string]string{"file.txt": "key: <redacted>"})
+
+	event = waitEvent(t, events)
+
+	require.NoError(t, event.Err)
+
+	gist, _ = srv.Gist(syncTestGistID)
+	require.Equal(t, "key: <redacted>", gist.Files["file.txt"])
+
+	cancel()
+	require.ErrorIs(t, <-done, context.Canceled)
+}
+
+func TestGisty_Restore_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srcSrv := gistytest.NewServer()
+	t.Cleanup(srcSrv.Close)
+
+	srcSrv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"creds.env": "GH_TOKEN=" + fakeGitHubPAT},
+	})
+
+	var archive bytes.Buffer
+
+	_, err := srcSrv.NewGisty().Backup(&archive, gisty.NewBackupOptions())
+	require.NoError(t, err)
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	obj := dstSrv.NewGisty()
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	opts := gisty.NewRestoreOptions()
+
+	_, err = obj.Restore(bytes.NewReader(archive.Bytes()), opts)
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, "creds.env:1: github-token")
+	require.Empty(t, dstSrv.Gists(), "gist should not be restored")
+
+	// Explicit override.
+	opts.AllowSecrets = true
+
+	_, err = obj.Restore(bytes.NewReader(archive.Bytes()), opts)
+
+	require.NoError(t, err)
+	require.Len(t, dstSrv.Gists(), 1)
+}
+
+func TestGisty_Migrate_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srcSrv := gistytest.NewServer()
+	t.Cleanup(srcSrv.Close)
+
+	srcSrv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"key.pem": fakePrivateKey + "\n"},
+	})
+
+	dstSrv := gistytest.NewServer()
+	t.Cleanup(dstSrv.Close)
+
+	target := dstSrv.NewGisty()
+	target.SecretScanner = gisty.NewSecretScanner()
+
+	opts := gisty.NewMigrateOptions()
+
+	report, err := srcSrv.NewGisty().Migrate(context.Background(), target, opts)
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, "key.pem:1: private-key")
+	require.Empty(t, dstSrv.Gists(), "gist should not be migrated")
+	require.NotEmpty(t, report.Gists[0].Error)
+
+	// Explicit override.
+	opts.AllowSecrets = true
+
+	_, err = srcSrv.NewGisty().Migrate(context.Background(), target, opts)
+
+	require.NoError(t, err)
+	require.Len(t, dstSrv.Gists(), 1)
+}
+
+func TestGisty_SetVisibility_secret_scan(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"config.yml": "aws_access_key_id: " + fakeAWSKeyID},
+	})
+
+	obj := srv.NewGisty()
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	opts := gisty.NewVisibilityOptions()
+
+	_, err := obj.SetVisibility(gistID, true, opts)
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, "config.yml:1: aws-access-key-id")
+	require.Len(t, srv.Gists(), 1, "gist should not be made public")
+
+	// Explicit override.
+	opts.AllowSecrets = true
+
+	result, err := obj.SetVisibility(gistID, true, opts)
+
+	require.NoError(t, err)
+
+	created, _ := srv.Gist(result.NewID)
+	require.True(t, created.Public)
+}
+
+func TestGisty_SetVisibility_secret_scan_history(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"config.yml": "region: us-east-1\n"},
+	})
+
+	obj := srv.NewGisty()
+	obj.SecretScanner = gisty.NewSecretScanner()
+
+	stubClone(t, obj)
+
+	// The key was committed in the first revision and removed in the second.
+	gitOutputs := map[string]string{
+		"rev-parse HEAD":        "2222222\n",
+		"rev-list --all":        "2222222\n1111111\n",
+		"ls-tree -z 2222222":    "100644 blob bbbbbbb\tconfig.yml\x00",
+		"ls-tree -z 1111111":    "100644 blob aaaaaaa\tconfig.yml\x00",
+		"cat-file blob bbbbbbb": "region: us-east-1\n",
+		"cat-file blob aaaaaaa": "aws_access_key_id: " + fakeAWSKeyID + "\n",
+		"ls-remote --symref":    "ref: refs/heads/main\tHEAD\n",
+		"push --force":          "",
+	}
+	pushed := false
+
+	obj.GitRunner = func(_ context.Context, cmd gisty.GitCommand) error {
+		args := cmd.Args
+		if args[0] == "-c" {
+			args = args[4:] // skip the credential options.
+			pushed = pushed || args[0] == "push"
+		}
+
+		for prefix, output := range gitOutputs {
+			if strings.HasPrefix(strings.Join(args, " "), prefix) {
+				_, err := cmd.Stdout.Write([]byte(output))
+
+				return err
+			}
+		}
+
+		t.Fatalf("unexpected git command: %v", cmd.Args)
+
+		return nil
+	}
+
+	opts := gisty.NewVisibilityOptions()
+	opts.WithHistory = true
+
+	_, err := obj.SetVisibility(gistID, true, opts)
+
+	require.ErrorIs(t, err, gisty.ErrSecretFound)
+	require.ErrorContains(t, err, "config.yml@1111111:1: aws-access-key-id")
+	require.Len(t, srv.Gists(), 1, "gist should not be made public")
+	require.False(t, pushed, "history should not be pushed")
+
+	// Explicit override.
+	opts.AllowSecrets = true
+
+	result, err := obj.SetVisibility(gistID, true, opts)
+
+	require.NoError(t, err)
+	require.True(t, result.History)
+	require.True(t, pushed)
+}
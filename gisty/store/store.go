@@ -0,0 +1,236 @@
+/*
+Package store is a local mirror of gists in an embedded bbolt database.
+
+It holds the metadata, the file contents and the comments of the gists so that
+they can be read without network access. The mirror is filled and refreshed by
+gisty.Gisty.Mirror and read by gisty.Gisty.Read and gisty.Gisty.Comments if
+gisty.Gisty.Store is set.
+
+A database file can be opened by only one process at a time.
+*/
+package store
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// openTimeout is the time to wait for the lock of the database file held by
+// another process.
+const openTimeout = time.Second
+
+// bucketGists is the bucket of the gists keyed by the gist ID.
+var bucketGists = []byte("gists")
+
+// ErrNotFound is returned if the gist is not in the store.
+var ErrNotFound = errors.New("gist not found in store")
+
+// ----------------------------------------------------------------------------
+//  Type: Gist, File, Comment
+// ----------------------------------------------------------------------------
+
+// Gist is a mirrored gist.
+type Gist struct {
+	// UpdatedAt is the update time of the gist on GitHub. It is compared with
+	// the listed gists to refresh the mirror incrementally.
+	UpdatedAt time.Time `json:"updated_at"`
+	// MirroredAt is the time when the gist was stored.
+	MirroredAt  time.Time `json:"mirrored_at"`
+	Files       []File    `json:"files"`
+	Comments    []Comment `json:"comments"`
+	ID          string    `json:"id"`
+	Description string    `json:"description"`
+	Owner       string    `json:"owner"`
+	HTMLURL     string    `json:"html_url"`
+	Public      bool      `json:"public"`
+}
+
+// File is a file of a mirrored gist.
+type File struct {
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Content  string `json:"content"`
+}
+
+// Comment is a comment of a mirrored gist.
+type Comment struct {
+	CreatedAt time.Time `json:"created_at"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+}
+
+// ----------------------------------------------------------------------------
+//  Type: Store
+// ----------------------------------------------------------------------------
+
+// Store is a local mirror of gists. It is safe for concurrent use.
+type Store struct {
+	db *bolt.DB
+}
+
+// Open opens the store at pathFile, creating the file and its directory if
+// they do not exist. The store must be closed after use.
+func Open(pathFile string) (*Store, error) {
+	err := os.MkdirAll(filepath.Dir(pathFile), 0o700)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create directory of store: %w", err)
+	}
+
+	//nolint:exhaustruct // other options are the defaults of bbolt
+	db, err := bolt.Open(pathFile, 0o600, &bolt.Options{Timeout: openTimeout})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open store %s: %w", pathFile, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(bucketGists)
+
+		return err //nolint:wrapcheck // wrapped below
+	})
+	if err != nil {
+		db.Close()
+
+		return nil, fmt.Errorf("failed to initialize store %s: %w", pathFile, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the store.
+func (s *Store) Close() error {
+	return s.db.Close() //nolint:wrapcheck // the error of bbolt is descriptive enough
+}
+
+// Get returns the gist with the given ID. ErrNotFound is returned if the gist
+// is not in the store.
+func (s *Store) Get(gistID string) (Gist, error) {
+	var gist Gist
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket(bucketGists).Get([]byte(gistID))
+		if data == nil {
+			return fmt.Errorf("%w: %s", ErrNotFound, gistID)
+		}
+
+		return decode(data, &gist)
+	})
+
+	return gist, err
+}
+
+// List returns all the gists in the store, the most recently updated first.
+func (s *Store) List() ([]Gist, error) {
+	gists := []Gist{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketGists).ForEach(func(_, data []byte) error {
+			var gist Gist
+
+			err := decode(data, &gist)
+			if err != nil {
+				return err
+			}
+
+			gists = append(gists, gist)
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(gists, func(a, b Gist) int {
+		return cmp.Or(b.UpdatedAt.Compare(a.UpdatedAt), cmp.Compare(a.ID, b.ID))
+	})
+
+	return gists, nil
+}
+
+// Put adds or replaces the gists in a single transaction.
+func (s *Store) Put(gists ...Gist) error {
+	return s.db.Update(func(tx *bolt.Tx) error { //nolint:wrapcheck // the errors are wrapped inside
+		bucket := tx.Bucket(bucketGists)
+
+		for _, gist := range gists {
+			if gist.ID == "" {
+				return errors.New("failed to store gist: empty gist ID")
+			}
+
+			data, err := json.Marshal(gist)
+			if err != nil {
+				return fmt.Errorf("failed to encode gist %s: %w", gist.ID, err)
+			}
+
+			err = bucket.Put([]byte(gist.ID), data)
+			if err != nil {
+				return fmt.Errorf("failed to store gist %s: %w", gist.ID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// Delete removes the gists from the store. The IDs not in the store are
+// ignored.
+func (s *Store) Delete(gistIDs ...string) error {
+	return s.db.Update(func(tx *bolt.Tx) error { //nolint:wrapcheck // the errors are wrapped inside
+		bucket := tx.Bucket(bucketGists)
+
+		for _, gistID := range gistIDs {
+			err := bucket.Delete([]byte(gistID))
+			if err != nil {
+				return fmt.Errorf("failed to delete gist %s: %w", gistID, err)
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateTimes returns the update time of each gist in the store, keyed by the
+// gist ID. It is used to find the outdated gists. Every stored gist is read,
+// but only its update time is decoded and kept in memory.
+func (s *Store) UpdateTimes() (map[string]time.Time, error) {
+	times := map[string]time.Time{}
+
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(bucketGists).ForEach(func(key, data []byte) error {
+			var gist struct {
+				UpdatedAt time.Time `json:"updated_at"`
+			}
+
+			err := decode(data, &gist)
+			if err != nil {
+				return err
+			}
+
+			times[string(key)] = gist.UpdatedAt
+
+			return nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return times, nil
+}
+
+func decode(data []byte, value any) error {
+	err := json.Unmarshal(data, value)
+	if err != nil {
+		return fmt.Errorf("failed to decode stored gist: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package store_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/KEINOS/go-gisty/gisty/store"
+	"github.com/stretchr/testify/require"
+)
+
+func openStore(t *testing.T) (*store.Store, string) {
+	t.Helper()
+
+	pathFile := filepath.Join(t.TempDir(), "cache", "mirror.db")
+
+	mirror, err := store.Open(pathFile)
+	require.NoError(t, err)
+
+	return mirror, pathFile
+}
+
+func newGist(id string, updatedAt time.Time) store.Gist {
+	return store.Gist{
+		UpdatedAt:   updatedAt,
+		MirroredAt:  updatedAt,
+		Files:       []store.File{{Name: "main.go", Language: "Go", Content: "package main\n"}},
+		Comments:    []store.Comment{{CreatedAt: updatedAt, Author: "alice", Body: "nice"}},
+		ID:          id,
+		Description: "gist " + id,
+		Owner:       "octocat",
+		HTMLURL:     "https://gist.github.com/" + id,
+		Public:      false,
+	}
+}
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	mirror, pathFile := openStore(t)
+	older := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	newer := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	require.NoError(t, mirror.Put(newGist("a", older), newGist("b", newer)))
+
+	gist, err := mirror.Get("a")
+
+	require.NoError(t, err)
+	require.Equal(t, newGist("a", older), gist)
+
+	gists, err := mirror.List()
+
+	require.NoError(t, err)
+	require.Len(t, gists, 2)
+	require.Equal(t, "b", gists[0].ID, "the most recently updated gist should be first")
+
+	times, err := mirror.UpdateTimes()
+
+	require.NoError(t, err)
+	require.Equal(t, map[string]time.Time{"a": older, "b": newer}, times)
+
+	require.NoError(t, mirror.Delete("a", "unknown"))
+
+	_, err = mirror.Get("a")
+
+	require.ErrorIs(t, err, store.ErrNotFound)
+
+	// The gists are kept after reopening.
+	require.NoError(t, mirror.Close())
+
+	mirror, err = store.Open(pathFile)
+
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, mirror.Close()) })
+
+	gist, err = mirror.Get("b")
+
+	require.NoError(t, err)
+	require.Equal(t, "gist b", gist.Description)
+}
+
+func TestStore_errors(t *testing.T) {
+	t.Parallel()
+
+	mirror, pathFile := openStore(t)
+	t.Cleanup(func() { require.NoError(t, mirror.Close()) })
+
+	require.ErrorContains(t, mirror.Put(newGist("", time.Time{})), "empty gist ID")
+
+	// The file is locked by the open store.
+	_, err := store.Open(pathFile)
+
+	require.ErrorContains(t, err, "failed to open store")
+
+	// The directory cannot be created over a file.
+	pathNotDir := filepath.Join(t.TempDir(), "file")
+
+	require.NoError(t, os.WriteFile(pathNotDir, nil, 0o600))
+
+	_, err = store.Open(filepath.Join(pathNotDir, "mirror.db"))
+
+	require.ErrorContains(t, err, "failed to create directory of store")
+}
@@ -0,0 +1,529 @@
+package gisty
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Names of the vendor files, in the same directory, and the defaults.
+const (
+	// VendorManifestFile is the default name of the manifest. See
+	// VendorManifest.
+	VendorManifestFile = "gisty.vendor.json"
+	// VendorLockFile is the name of the lockfile written next to the manifest.
+	// See VendorLock.
+	VendorLockFile = "gisty.vendor.lock"
+	// VendorDirDefault is the directory the files are downloaded to, if the
+	// manifest does not specify one.
+	VendorDirDefault = "gists"
+	// VendorLockVersion is the version of the format of the lockfile.
+	VendorLockVersion = 1
+)
+
+// ----------------------------------------------------------------------------
+//  Type: VendorManifest, VendorDependency
+// ----------------------------------------------------------------------------
+
+// VendorManifest is the JSON manifest of the gist files to vendor. E.g.
+//
+//	{
+//	  "dir": "third_party/gists",
+//	  "dependencies": [
+//	    {"gist": "5b10b34f87955dfc86d310cd623a61d1", "file": ".golangci.yml"},
+//	    {"gist": "42f5f23b3f3c0e6d9a1b", "file": "ci.yml", "revision": "0a1b2c...", "path": "ci/lint.yml"}
+//	  ]
+//	}
+type VendorManifest struct {
+	// Dir is the directory to download the files to, relative to the manifest.
+	// If empty, VendorDirDefault is used.
+	Dir          string             `json:"dir,omitempty"`
+	Dependencies []VendorDependency `json:"dependencies"`
+}
+
+// VendorDependency is a gist file to vendor.
+type VendorDependency struct {
+	Gist string `json:"gist"` // gist reference as accepted by ParseGistRef.
+	// File is the name of the file in the gist. It may be omitted if Gist is a
+	// raw file URL.
+	File string `json:"file,omitempty"`
+	// Revision is the SHA of the gist revision to pin the file to. If empty,
+	// the latest revision is locked and kept until updated.
+	Revision string `json:"revision,omitempty"`
+	// Path is the path to download the file to, relative to the Dir of the
+	// manifest. If empty, File is used.
+	Path string `json:"path,omitempty"`
+}
+
+// LoadVendorManifest reads the VendorManifest in JSON from r and validates it.
+// Unknown fields are rejected to catch typos.
+func LoadVendorManifest(r io.Reader) (VendorManifest, error) {
+	var manifest VendorManifest
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	err := decoder.Decode(&manifest)
+	if err != nil {
+		return manifest, WrapIfErr(err, "failed to parse vendor manifest")
+	}
+
+	if manifest.Dir != "" && !filepath.IsLocal(filepath.FromSlash(manifest.Dir)) {
+		return manifest, NewErr("invalid dir of vendor manifest: %q. It must be relative to the manifest", manifest.Dir)
+	}
+
+	seen := map[string]int{}
+
+	for index, dep := range manifest.Dependencies {
+		ref, err := ParseGistRef(dep.Gist)
+		if err != nil {
+			return manifest, WrapIfErr(err, "dependency #%d of vendor manifest", index+1)
+		}
+
+		// The revision and the file may be given by the gist reference, e.g.
+		// "<id>@<revision>" or a raw file URL.
+		switch {
+		case dep.Revision == "":
+			dep.Revision = ref.Revision
+		case ref.Revision != "" && ref.Revision != dep.Revision:
+			return manifest, NewErr("dependency #%d of vendor manifest: conflicting revisions %s and %s",
+				index+1, ref.Revision, dep.Revision)
+		}
+
+		if dep.File == "" {
+			dep.File = ref.File
+		}
+
+		if dep.File == "" {
+			return manifest, NewErr("dependency #%d of vendor manifest: file is required", index+1)
+		}
+
+		manifest.Dependencies[index] = dep
+
+		target := manifest.path(dep)
+		if !filepath.IsLocal(filepath.FromSlash(target)) {
+			return manifest, NewErr("dependency #%d of vendor manifest: invalid path %q", index+1, target)
+		}
+
+		if other, ok := seen[target]; ok {
+			return manifest, NewErr("dependencies #%d and #%d of vendor manifest are both vendored to %s",
+				other+1, index+1, target)
+		}
+
+		seen[target] = index
+	}
+
+	return manifest, nil
+}
+
+// path returns the slash-separated path of the vendored file, relative to the
+// manifest.
+func (m VendorManifest) path(dep VendorDependency) string {
+	dir := m.Dir
+	if dir == "" {
+		dir = VendorDirDefault
+	}
+
+	name := dep.Path
+	if name == "" {
+		name = dep.File
+	}
+
+	return path.Join(filepath.ToSlash(dir), filepath.ToSlash(name))
+}
+
+// ----------------------------------------------------------------------------
+//  Type: VendorLock, LockedDependency
+// ----------------------------------------------------------------------------
+
+// VendorLock is the JSON lockfile of the vendored files, written to
+// VendorLockFile next to the manifest. It should be committed along with the
+// manifest and the vendored files.
+type VendorLock struct {
+	// Dependencies are the vendored files sorted by path.
+	Dependencies []LockedDependency `json:"dependencies"`
+	Version      int                `json:"version"`
+}
+
+// LockedDependency is a vendored file pinned to a revision of its gist.
+type LockedDependency struct {
+	Path     string `json:"path"` // slash-separated path relative to the manifest.
+	Host     string `json:"host"` // GitHub host of the gist.
+	GistID   string `json:"gist_id"`
+	File     string `json:"file"`
+	Revision string `json:"revision"` // SHA of the gist revision.
+	SHA256   string `json:"sha256"`   // hex SHA-256 of the content.
+}
+
+// ----------------------------------------------------------------------------
+//  Type: VendorOptions, VendorResult
+// ----------------------------------------------------------------------------
+
+// VendorOptions are the options for the Vendor function.
+type VendorOptions struct {
+	// Update locks the dependencies without a revision in the manifest to the
+	// latest revision of their gists, instead of the locked one.
+	Update bool
+}
+
+// NewVendorOptions returns a new VendorOptions with the default values.
+func NewVendorOptions() VendorOptions {
+	return VendorOptions{
+		Update: false,
+	}
+}
+
+// VendorResult is the result of Vendor.
+type VendorResult struct {
+	Lock    VendorLock `json:"lock"`
+	Updated []string   `json:"updated"` // paths of the files written with a new content.
+	Removed []string   `json:"removed"` // paths of the files no longer in the manifest.
+}
+
+// ----------------------------------------------------------------------------
+//  Method: Vendor
+// ----------------------------------------------------------------------------
+
+// Vendor downloads the gist files of the manifest at manifestPath and writes
+// the lockfile next to it.
+//
+// Like Go modules, a dependency without a revision in the manifest keeps the
+// revision of the lockfile, so that vendoring again is reproducible. It is
+// locked to the latest revision of the gist only if it is not locked yet or
+// opts.Update is set. The file at a locked revision must match the hash of the
+// lockfile. The files vendored before but no longer in the manifest are
+// removed.
+//
+// The gists are requested to g.Host only. A manifest with a gist URL of another
+// host is rejected before any request, since the requests carry the token.
+func (g *Gisty) Vendor(manifestPath string, opts VendorOptions) (VendorResult, error) {
+	result := VendorResult{
+		Lock:    VendorLock{Dependencies: []LockedDependency{}, Version: VendorLockVersion},
+		Updated: []string{},
+		Removed: []string{},
+	}
+
+	manifest, err := g.readVendorManifest(manifestPath)
+	if err != nil {
+		return result, err
+	}
+
+	baseDir := filepath.Dir(manifestPath)
+	pathLock := filepath.Join(baseDir, VendorLockFile)
+
+	lock, err := readVendorLock(pathLock)
+	if err != nil {
+		return result, err
+	}
+
+	locked := lock.byPath()
+
+	for _, dep := range manifest.Dependencies {
+		target := manifest.path(dep)
+
+		prev, isLocked := locked[target]
+		isLocked = isLocked && g.isLockedAs(prev, dep)
+
+		revision := dep.Revision
+		if revision == "" && isLocked && !opts.Update {
+			revision = prev.Revision
+		}
+
+		pinned, err := g.fetchPinnedFile(dep.Gist, dep.File, revision, selectScriptFile)
+		if err != nil {
+			return result, WrapIfErr(err, "failed to vendor %s", target)
+		}
+
+		if isLocked && pinned.Revision == prev.Revision && pinned.SHA256 != prev.SHA256 {
+			return result, NewErr("checksum mismatch of %s at revision %s: got sha256 %s, locked %s",
+				target, pinned.Revision, pinned.SHA256, prev.SHA256)
+		}
+
+		updated, err := writeVendoredFile(filepath.Join(baseDir, filepath.FromSlash(target)), pinned.Content)
+		if err != nil {
+			return result, err
+		}
+
+		if updated {
+			result.Updated = append(result.Updated, target)
+		}
+
+		result.Lock.Dependencies = append(result.Lock.Dependencies, LockedDependency{
+			Path:     target,
+			Host:     g.host(),
+			GistID:   pinned.GistID,
+			File:     pinned.File,
+			Revision: pinned.Revision,
+			SHA256:   pinned.SHA256,
+		})
+	}
+
+	result.Lock.sort()
+
+	kept := result.Lock.byPath()
+
+	for _, prev := range lock.Dependencies {
+		if _, ok := kept[prev.Path]; ok {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(baseDir, filepath.FromSlash(prev.Path)))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return result, WrapIfErr(err, "failed to remove vendored file: %s", prev.Path)
+		}
+
+		result.Removed = append(result.Removed, prev.Path)
+	}
+
+	return result, writeVendorLock(pathLock, result.Lock)
+}
+
+// isLockedAs returns true if the locked dependency is the file of the gist of
+// the manifest dependency on g.host(). It compares them without requesting
+// GitHub. The manifest is validated when loaded, so the reference is valid.
+func (g *Gisty) isLockedAs(locked LockedDependency, dep VendorDependency) bool {
+	ref, _ := ParseGistRef(dep.Gist)
+
+	return locked.File == dep.File && locked.GistID == ref.ID && strings.EqualFold(locked.Host, g.host())
+}
+
+// writeVendoredFile writes the content to pathFile if it differs from the
+// current one. It returns true if the file was written.
+func writeVendoredFile(pathFile, content string) (bool, error) {
+	current, err := os.ReadFile(pathFile)
+	if err == nil && string(current) == content {
+		return false, nil
+	}
+
+	err = os.MkdirAll(filepath.Dir(pathFile), 0o755) //nolint:mnd,gosec // vendored files are part of the project
+	if err != nil {
+		return false, WrapIfErr(err, "failed to create directory of vendored file")
+	}
+
+	err = os.WriteFile(pathFile, []byte(content), 0o644) //nolint:mnd,gosec // vendored files are part of the project
+	if err != nil {
+		return false, WrapIfErr(err, "failed to write vendored file")
+	}
+
+	return true, nil
+}
+
+// ----------------------------------------------------------------------------
+//  Function: VerifyVendor
+// ----------------------------------------------------------------------------
+
+// VendorDrift is a difference between the manifest, the lockfile and the
+// vendored files.
+type VendorDrift struct {
+	Path   string `json:"path"` // slash-separated path relative to the manifest.
+	Reason string `json:"reason"`
+}
+
+// String returns the drift as "<path>: <reason>".
+func (d VendorDrift) String() string {
+	return d.Path + ": " + d.Reason
+}
+
+// VerifyVendor checks, without requesting GitHub, that the lockfile next to the
+// manifest at manifestPath matches the manifest and that the vendored files
+// match the hashes of the lockfile. The files must be locked on g.Host.
+//
+// If they drifted, e.g. a vendored file was edited or a dependency was added to
+// the manifest without running Vendor, a *VendorDriftError is returned.
+func (g *Gisty) VerifyVendor(manifestPath string) error {
+	manifest, err := g.readVendorManifest(manifestPath)
+	if err != nil {
+		return err
+	}
+
+	baseDir := filepath.Dir(manifestPath)
+
+	lock, err := readVendorLock(filepath.Join(baseDir, VendorLockFile))
+	if err != nil {
+		return err
+	}
+
+	locked := lock.byPath()
+	drifts := []VendorDrift{}
+	inManifest := map[string]bool{}
+
+	for _, dep := range manifest.Dependencies {
+		target := manifest.path(dep)
+		inManifest[target] = true
+
+		prev, ok := locked[target]
+
+		switch {
+		case ok && !strings.EqualFold(prev.Host, g.host()):
+			drifts = append(drifts, VendorDrift{
+				Path:   target,
+				Reason: fmt.Sprintf("locked on host %q but the host is %s", prev.Host, g.host()),
+			})
+
+			continue
+		case !ok || !g.isLockedAs(prev, dep):
+			drifts = append(drifts, VendorDrift{Path: target, Reason: "not locked"})
+
+			continue
+		case dep.Revision != "" && dep.Revision != prev.Revision:
+			drifts = append(drifts, VendorDrift{
+				Path:   target,
+				Reason: fmt.Sprintf("revision %s in manifest but %s locked", dep.Revision, prev.Revision),
+			})
+
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(baseDir, filepath.FromSlash(target)))
+
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			drifts = append(drifts, VendorDrift{Path: target, Reason: "missing"})
+		case err != nil:
+			return WrapIfErr(err, "failed to read vendored file: %s", target)
+		case contentSHA256(string(content)) != prev.SHA256:
+			drifts = append(drifts, VendorDrift{Path: target, Reason: "modified"})
+		}
+	}
+
+	for _, prev := range lock.Dependencies {
+		if !inManifest[prev.Path] {
+			drifts = append(drifts, VendorDrift{Path: prev.Path, Reason: "not in manifest"})
+		}
+	}
+
+	if len(drifts) > 0 {
+		return &VendorDriftError{Drifts: drifts}
+	}
+
+	return nil
+}
+
+// ----------------------------------------------------------------------------
+//  Type: VendorDriftError
+// ----------------------------------------------------------------------------
+
+// VendorDriftError is returned by VerifyVendor when the vendored files drifted
+// from the lockfile or the manifest. It wraps ErrVendorDrift.
+type VendorDriftError struct {
+	Drifts []VendorDrift
+}
+
+// Error implements the error interface.
+func (e *VendorDriftError) Error() string {
+	lines := make([]string, 0, len(e.Drifts))
+	for _, drift := range e.Drifts {
+		lines = append(lines, drift.String())
+	}
+
+	return fmt.Sprintf("%s: %s", ErrVendorDrift, strings.Join(lines, ", "))
+}
+
+// Unwrap returns ErrVendorDrift.
+func (e *VendorDriftError) Unwrap() error {
+	return ErrVendorDrift
+}
+
+// ----------------------------------------------------------------------------
+//  Helper functions
+// ----------------------------------------------------------------------------
+
+// readVendorManifest reads the manifest and rejects the gists of another host
+// than g.host().
+func (g *Gisty) readVendorManifest(manifestPath string) (VendorManifest, error) {
+	file, err := os.Open(manifestPath)
+	if err != nil {
+		return VendorManifest{Dir: "", Dependencies: nil}, WrapIfErr(err, "failed to open vendor manifest")
+	}
+
+	defer file.Close()
+
+	manifest, err := LoadVendorManifest(file)
+	if err != nil {
+		return manifest, WrapIfErr(err, "invalid vendor manifest: %s", manifestPath)
+	}
+
+	for index, dep := range manifest.Dependencies {
+		ref, _ := ParseGistRef(dep.Gist) // validated by LoadVendorManifest
+
+		err = g.checkGistHost(ref, dep.Gist)
+		if err != nil {
+			return manifest, WrapIfErr(err, "invalid vendor manifest: %s: dependency #%d", manifestPath, index+1)
+		}
+	}
+
+	return manifest, nil
+}
+
+// readVendorLock reads the lockfile. It is empty if the file does not exist.
+func readVendorLock(pathFile string) (VendorLock, error) {
+	lock := VendorLock{Dependencies: []LockedDependency{}, Version: VendorLockVersion}
+
+	data, err := os.ReadFile(pathFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, nil
+	}
+
+	if err != nil {
+		return lock, WrapIfErr(err, "failed to read vendor lockfile")
+	}
+
+	err = json.Unmarshal(data, &lock)
+	if err != nil {
+		return lock, WrapIfErr(err, "failed to parse vendor lockfile: %s", pathFile)
+	}
+
+	if lock.Version != VendorLockVersion {
+		return lock, NewErr("unsupported version %d of vendor lockfile: %s", lock.Version, pathFile)
+	}
+
+	// The vendored files of the removed dependencies are deleted by their path.
+	for index, dep := range lock.Dependencies {
+		if !filepath.IsLocal(filepath.FromSlash(dep.Path)) {
+			return lock, NewErr("dependency #%d of vendor lockfile: invalid path %q", index+1, dep.Path)
+		}
+	}
+
+	return lock, nil
+}
+
+// writeVendorLock writes the lockfile through a temporary file, so that an
+// interruption does not leave a broken file.
+func writeVendorLock(pathFile string, lock VendorLock) error {
+	data, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return WrapIfErr(err, "failed to encode vendor lockfile")
+	}
+
+	pathTemp := filepath.Join(filepath.Dir(pathFile), "."+filepath.Base(pathFile)+".tmp")
+
+	err = os.WriteFile(pathTemp, append(data, '\n'), 0o644) //nolint:mnd,gosec // the lockfile is part of the project
+	if err != nil {
+		return WrapIfErr(err, "failed to write vendor lockfile")
+	}
+
+	return WrapIfErr(os.Rename(pathTemp, pathFile), "failed to write vendor lockfile")
+}
+
+// byPath returns the locked dependencies by path.
+func (l VendorLock) byPath() map[string]LockedDependency {
+	locked := make(map[string]LockedDependency, len(l.Dependencies))
+	for _, dep := range l.Dependencies {
+		locked[dep.Path] = dep
+	}
+
+	return locked
+}
+
+func (l VendorLock) sort() {
+	slices.SortFunc(l.Dependencies, func(a, b LockedDependency) int {
+		return strings.Compare(a.Path, b.Path)
+	})
+}
@@ -0,0 +1,250 @@
+package gisty_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/KEINOS/go-gisty/gisty"
+	"github.com/KEINOS/go-gisty/gisty/gistytest"
+	"github.com/stretchr/testify/require"
+)
+
+func writeVendorManifest(t *testing.T, dir string, manifest gisty.VendorManifest) string {
+	t.Helper()
+
+	data, err := json.Marshal(manifest)
+	require.NoError(t, err)
+
+	pathManifest := filepath.Join(dir, gisty.VendorManifestFile)
+	require.NoError(t, os.WriteFile(pathManifest, data, 0o600))
+
+	return pathManifest
+}
+
+func TestGisty_Vendor(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistLint := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{".golangci.yml": "linters:\n  enable-all: true\n", "README.md": "# Lint"},
+	})
+	gistCI := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"ci.yml": "on: push\n"},
+	})
+
+	dir := t.TempDir()
+	pathManifest := writeVendorManifest(t, dir, gisty.VendorManifest{
+		Dir: "",
+		Dependencies: []gisty.VendorDependency{
+			{Gist: "https://gist.github.com/octocat/" + gistLint, File: ".golangci.yml", Revision: "", Path: ""},
+			{Gist: gistCI, File: "ci.yml", Revision: "", Path: "workflows/ci.yml"},
+		},
+	})
+
+	obj := srv.NewGisty()
+
+	result, err := obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"gists/.golangci.yml", "gists/workflows/ci.yml"}, result.Updated)
+	require.Empty(t, result.Removed)
+	require.FileExists(t, filepath.Join(dir, gisty.VendorLockFile))
+
+	content, err := os.ReadFile(filepath.Join(dir, "gists", "workflows", "ci.yml"))
+
+	require.NoError(t, err)
+	require.Equal(t, "on: push\n", string(content))
+
+	lintGist, _ := srv.Gist(gistLint)
+	locked := result.Lock.Dependencies[0]
+
+	require.Equal(t, "github.com", locked.Host)
+	require.Equal(t, gistLint, locked.GistID)
+	require.Equal(t, lintGist.Revision(), locked.Revision)
+	require.Len(t, locked.SHA256, 64)
+	require.NoError(t, obj.VerifyVendor(pathManifest))
+
+	// The locked revision is kept and the edited file is restored.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "gists", ".golangci.yml"), []byte("edited"), 0o600))
+
+	srv.ResetRequests()
+
+	result, err = obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"gists/.golangci.yml"}, result.Updated)
+	srv.AssertRequested(t, http.MethodGet, "/gists/"+gistLint+"/"+locked.Revision)
+
+	// Updated to the latest revision.
+	srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		ID:    gistCI,
+		Files: map[string]string{"ci.yml": "on: [push, pull_request]\n"},
+	})
+
+	opts := gisty.NewVendorOptions()
+	opts.Update = true
+
+	result, err = obj.Vendor(pathManifest, opts)
+
+	require.NoError(t, err)
+	require.Equal(t, []string{"gists/workflows/ci.yml"}, result.Updated)
+
+	// Removed from the manifest.
+	pathManifest = writeVendorManifest(t, dir, gisty.VendorManifest{
+		Dir:          "",
+		Dependencies: []gisty.VendorDependency{{Gist: gistCI, File: "ci.yml", Revision: "", Path: "workflows/ci.yml"}},
+	})
+
+	result, err = obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+	require.NoError(t, err)
+	require.Empty(t, result.Updated)
+	require.Equal(t, []string{"gists/.golangci.yml"}, result.Removed)
+	require.NoFileExists(t, filepath.Join(dir, "gists", ".golangci.yml"))
+	require.NoError(t, obj.VerifyVendor(pathManifest))
+}
+
+func TestGisty_Vendor_errors(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{Files: map[string]string{"a.txt": "a"}}) //nolint:exhaustruct // only the fields under test
+	obj := srv.NewGisty()
+
+	for name, test := range map[string]struct {
+		manifest string
+		wantErr  string
+	}{
+		"unknown field":  {`{"deps": []}`, "unknown field"},
+		"no file":        {`{"dependencies": [{"gist": "` + gistID + `"}]}`, "file is required"},
+		"invalid gist":   {`{"dependencies": [{"gist": "https://github.com/a/b", "file": "a.txt"}]}`, "invalid gist ID"},
+		"two revisions":  {`{"dependencies": [{"gist": "` + gistID + `@abcd", "file": "a.txt", "revision": "0000"}]}`, "conflicting revisions"},
+		"outside dir":    {`{"dir": "../x", "dependencies": []}`, "invalid dir"},
+		"another host":   {`{"dependencies": [{"gist": "https://gist.attacker.example/a/` + gistID + `", "file": "a.txt"}]}`, "gist on host attacker.example given"},
+		"outside path":   {`{"dependencies": [{"gist": "a", "file": "a.txt", "path": "../../a.txt"}]}`, "invalid path"},
+		"same path":      {`{"dependencies": [{"gist": "a", "file": "a.txt"}, {"gist": "b", "file": "a.txt"}]}`, "both vendored to gists/a.txt"},
+		"missing file":   {`{"dependencies": [{"gist": "` + gistID + `", "file": "b.txt"}]}`, "no file named \"b.txt\""},
+		"wrong revision": {`{"dependencies": [{"gist": "` + gistID + `", "file": "a.txt", "revision": "0000"}]}`, "gist not found"},
+	} {
+		dir := t.TempDir()
+		pathManifest := filepath.Join(dir, gisty.VendorManifestFile)
+
+		require.NoError(t, os.WriteFile(pathManifest, []byte(test.manifest), 0o600))
+
+		_, err := obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+		require.ErrorContains(t, err, test.wantErr, name)
+	}
+
+	_, err := obj.Vendor(filepath.Join(t.TempDir(), gisty.VendorManifestFile), gisty.NewVendorOptions())
+
+	require.ErrorContains(t, err, "failed to open vendor manifest")
+
+	// The gists of another host are rejected before any request, since the
+	// requests carry the token.
+	srv.ResetRequests()
+
+	pathManifest := writeVendorManifest(t, t.TempDir(), gisty.VendorManifest{
+		Dir: "",
+		Dependencies: []gisty.VendorDependency{
+			{Gist: gistID, File: "a.txt", Revision: "", Path: ""},
+			{Gist: "https://ghe.example.com/gist/octocat/" + gistID, File: "a.txt", Revision: "", Path: "b.txt"},
+		},
+	})
+
+	_, err = obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+	require.ErrorIs(t, err, gisty.ErrInvalidGistID)
+	require.ErrorContains(t, err, "dependency #2")
+	require.Empty(t, srv.Requests(), "no request should be sent with a gist of another host")
+
+	// Paths of the lockfile outside the directory are not removed.
+	dir := t.TempDir()
+	pathOutside := filepath.Join(dir, "outside.txt")
+
+	require.NoError(t, os.WriteFile(pathOutside, []byte("keep"), 0o600))
+
+	baseDir := filepath.Join(dir, "project")
+	require.NoError(t, os.Mkdir(baseDir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(baseDir, gisty.VendorLockFile),
+		[]byte(`{"version": 1, "dependencies": [{"path": "../outside.txt"}]}`), 0o600))
+
+	pathManifest = writeVendorManifest(t, baseDir, gisty.VendorManifest{Dir: "", Dependencies: []gisty.VendorDependency{}})
+
+	_, err = obj.Vendor(pathManifest, gisty.NewVendorOptions())
+
+	require.ErrorContains(t, err, "invalid path \"../outside.txt\"")
+	require.FileExists(t, pathOutside)
+}
+
+func TestVerifyVendor(t *testing.T) {
+	t.Parallel()
+
+	srv := gistytest.NewServer()
+	t.Cleanup(srv.Close)
+
+	gistID := srv.AddGist(gistytest.Gist{ //nolint:exhaustruct // only the fields under test
+		Files: map[string]string{"a.txt": "a", "b.txt": "b", "c.txt": "c"},
+	})
+	dir := t.TempDir()
+	deps := []gisty.VendorDependency{
+		{Gist: gistID, File: "a.txt", Revision: "", Path: ""},
+		{Gist: gistID, File: "b.txt", Revision: "", Path: ""},
+		{Gist: gistID, File: "c.txt", Revision: "", Path: ""},
+	}
+	pathManifest := writeVendorManifest(t, dir, gisty.VendorManifest{Dir: "third_party", Dependencies: deps})
+
+	obj := srv.NewGisty()
+
+	_, err := obj.Vendor(pathManifest, gisty.NewVendorOptions())
+	require.NoError(t, err)
+
+	// Drift the files and the manifest.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "third_party", "a.txt"), []byte("edited"), 0o600))
+	require.NoError(t, os.Remove(filepath.Join(dir, "third_party", "b.txt")))
+
+	deps[2].Revision = "0123456"
+	deps = append(deps[:3], gisty.VendorDependency{Gist: gistID, File: "d.txt", Revision: "", Path: ""})
+	writeVendorManifest(t, dir, gisty.VendorManifest{Dir: "third_party", Dependencies: deps[1:]})
+
+	err = obj.VerifyVendor(pathManifest)
+
+	var errDrift *gisty.VendorDriftError
+
+	require.ErrorIs(t, err, gisty.ErrVendorDrift)
+	require.ErrorAs(t, err, &errDrift)
+
+	got := make([]string, 0, len(errDrift.Drifts))
+	for _, drift := range errDrift.Drifts {
+		got = append(got, drift.String())
+	}
+
+	require.Len(t, got, 4)
+	require.Equal(t, "third_party/b.txt: missing", got[0])
+	require.Regexp(t, `^third_party/c.txt: revision 0123456 in manifest but [0-9a-f]{40} locked$`, got[1])
+	require.Equal(t, "third_party/d.txt: not locked", got[2])
+	require.Equal(t, "third_party/a.txt: not in manifest", got[3])
+
+	// Modified file.
+	writeVendorManifest(t, dir, gisty.VendorManifest{Dir: "third_party", Dependencies: deps[:1]})
+
+	require.ErrorContains(t, obj.VerifyVendor(pathManifest), "third_party/a.txt: modified")
+
+	// Locked on another host.
+	pathLock := filepath.Join(dir, gisty.VendorLockFile)
+	lock, err := os.ReadFile(pathLock)
+
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(pathLock,
+		bytes.ReplaceAll(lock, []byte(`"host": "github.com"`), []byte(`"host": "ghe.example.com"`)), 0o600))
+	require.ErrorContains(t, obj.VerifyVendor(pathManifest),
+		`third_party/a.txt: locked on host "ghe.example.com" but the host is github.com`)
+}